@@ -0,0 +1,348 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package precompilebind generates the skeleton of a stateful precompile module
+// from the ABI of its Solidity interface.
+package precompilebind
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Juneo-io/jeth/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// allowListFunctions are the functions of the allow list interface. They are
+// implemented by the allowlist package, so no stub is generated for them.
+var allowListFunctions = []string{"readAllowList", "setAdmin", "setEnabled", "setManager", "setNone"}
+
+// allowListEvent is the event emitted by the allow list functions.
+const allowListEvent = "RoleSet"
+
+var (
+	errMissingType    = errors.New("missing precompile type name")
+	errMissingPackage = errors.New("missing precompile package name")
+)
+
+// PrecompileBind generates the sources of a precompile module named [typeName]
+// implementing the Solidity interface of [abiJSON], in package [pkg] and
+// deployed at [address]. It returns the generated sources keyed by file name.
+//
+// If the interface includes the allow list functions, the generated precompile
+// is permissioned by an allow list.
+func PrecompileBind(typeName string, abiJSON string, pkg string, address string) (map[string]string, error) {
+	if len(typeName) == 0 {
+		return nil, errMissingType
+	}
+	if len(pkg) == 0 {
+		return nil, errMissingPackage
+	}
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid precompile address %q", address)
+	}
+	evmABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	contract := &tmplContract{
+		Type:      abi.ToCamelCase(typeName),
+		ConfigKey: decapitalise(typeName) + "Config",
+		Address:   common.HexToAddress(address).Hex(),
+		AllowList: hasAllowList(evmABI),
+	}
+	for _, method := range sortedMethods(evmABI) {
+		if contract.AllowList && isAllowListFunction(method.Name) {
+			continue
+		}
+		function, err := newTmplFunc(method)
+		if err != nil {
+			return nil, err
+		}
+		contract.Funcs = append(contract.Funcs, function)
+		contract.HasWriteFuncs = contract.HasWriteFuncs || !function.ReadOnly
+		contract.HasArgs = contract.HasArgs || len(function.Inputs) != 0 || len(function.Outputs) != 0
+		contract.UsesBig = contract.UsesBig || function.UsesBig
+	}
+	for _, event := range sortedEvents(evmABI) {
+		if contract.AllowList && event.Name == allowListEvent {
+			continue
+		}
+		tmplEvent, err := newTmplEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		contract.Events = append(contract.Events, tmplEvent)
+		contract.EventsUseBig = contract.EventsUseBig || tmplEvent.UsesBig
+	}
+	if len(contract.Funcs) == 0 && !contract.AllowList {
+		return nil, errors.New("ABI does not define any function")
+	}
+
+	data := &tmplData{
+		Package:  pkg,
+		Contract: contract,
+	}
+	sources := map[string]string{
+		"contract.abi": abiJSON,
+	}
+	for file, source := range tmplSources {
+		code, err := executeTemplate(file, source, data)
+		if err != nil {
+			return nil, err
+		}
+		sources[file] = code
+	}
+	return sources, nil
+}
+
+// executeTemplate fills the template [source] of [file] with [data] and
+// formats the resulting Go code.
+func executeTemplate(file string, source string, data *tmplData) (string, error) {
+	buffer := new(bytes.Buffer)
+	tmpl := template.Must(template.New(file).Parse(source))
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return "", fmt.Errorf("failed to generate %s: %w", file, err)
+	}
+	code, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("failed to format %s: %w\n%s", file, err, buffer)
+	}
+	return string(code), nil
+}
+
+// hasAllowList returns true if [evmABI] includes all the allow list functions.
+func hasAllowList(evmABI abi.ABI) bool {
+	for _, name := range allowListFunctions {
+		if _, ok := evmABI.Methods[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func isAllowListFunction(name string) bool {
+	for _, allowListFunction := range allowListFunctions {
+		if name == allowListFunction {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedMethods returns the methods of [evmABI] in the order of their names,
+// so that the generated code is deterministic.
+func sortedMethods(evmABI abi.ABI) []abi.Method {
+	methods := make([]abi.Method, 0, len(evmABI.Methods))
+	for _, method := range evmABI.Methods {
+		methods = append(methods, method)
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+	return methods
+}
+
+// sortedEvents returns the events of [evmABI] in the order of their names.
+func sortedEvents(evmABI abi.ABI) []abi.Event {
+	events := make([]abi.Event, 0, len(evmABI.Events))
+	for _, event := range evmABI.Events {
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Name < events[j].Name })
+	return events
+}
+
+func newTmplFunc(method abi.Method) (*tmplFunc, error) {
+	normalized := abi.ToCamelCase(method.Name)
+	function := &tmplFunc{
+		Original:   method,
+		Normalized: normalized,
+		Run:        identifier(decapitalise(normalized)),
+		ReadOnly:   method.IsConstant(),
+	}
+	var err error
+	if function.Inputs, err = newTmplArgs(method.Inputs); err != nil {
+		return nil, fmt.Errorf("failed to bind inputs of %s: %w", method.Sig, err)
+	}
+	if function.Outputs, err = newTmplArgs(method.Outputs); err != nil {
+		return nil, fmt.Errorf("failed to bind outputs of %s: %w", method.Sig, err)
+	}
+	function.InputType, function.InputZero = argsType(function.Inputs, normalized+"Input")
+	function.OutputType, function.OutputZero = argsType(function.Outputs, normalized+"Output")
+	function.UsesBig = usesBig(function.Inputs) || usesBig(function.Outputs)
+	return function, nil
+}
+
+func newTmplEvent(event abi.Event) (*tmplEvent, error) {
+	args, err := newTmplArgs(event.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind event %s: %w", event.Sig, err)
+	}
+	tmplEvent := &tmplEvent{
+		Original:   event,
+		Normalized: abi.ToCamelCase(event.Name),
+		Args:       args,
+		UsesBig:    usesBig(args),
+	}
+	if !event.Anonymous {
+		tmplEvent.Topics++
+	}
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			tmplEvent.Topics++
+		} else {
+			tmplEvent.DataWords++
+		}
+	}
+	return tmplEvent, nil
+}
+
+func newTmplArgs(arguments abi.Arguments) ([]*tmplArg, error) {
+	args := make([]*tmplArg, 0, len(arguments))
+	names := make(map[string]bool, len(arguments))
+	for i, argument := range arguments {
+		goType, err := bindType(argument.Type)
+		if err != nil {
+			return nil, err
+		}
+		name := abi.ToCamelCase(argument.Name)
+		if len(name) == 0 || names[name] {
+			name = fmt.Sprintf("Arg%d", i)
+		}
+		names[name] = true
+		args = append(args, &tmplArg{
+			Name:    name,
+			Param:   identifier(decapitalise(name)),
+			Type:    goType,
+			Zero:    zeroValue(argument.Type),
+			UsesBig: strings.Contains(goType, "*big.Int"),
+		})
+	}
+	return args, nil
+}
+
+// argsType returns the Go type holding [args] and its zero value. Multiple
+// arguments are held by the struct type [structName].
+func argsType(args []*tmplArg, structName string) (string, string) {
+	switch len(args) {
+	case 0:
+		return "", ""
+	case 1:
+		return args[0].Type, args[0].Zero
+	}
+	fields := make([]string, 0, len(args))
+	for _, arg := range args {
+		fields = append(fields, fmt.Sprintf("%s: %s", arg.Name, arg.Zero))
+	}
+	return structName, fmt.Sprintf("%s{%s}", structName, strings.Join(fields, ", "))
+}
+
+func usesBig(args []*tmplArg) bool {
+	for _, arg := range args {
+		if arg.UsesBig {
+			return true
+		}
+	}
+	return false
+}
+
+// bindType returns the Go type the ABI unpacks a value of [kind] into.
+// Tuples are not supported.
+func bindType(kind abi.Type) (string, error) {
+	switch kind.T {
+	case abi.AddressTy:
+		return "common.Address", nil
+	case abi.IntTy, abi.UintTy:
+		if isBigInt(kind) {
+			return "*big.Int", nil
+		}
+		if kind.T == abi.UintTy {
+			return fmt.Sprintf("uint%d", kind.Size), nil
+		}
+		return fmt.Sprintf("int%d", kind.Size), nil
+	case abi.BoolTy:
+		return "bool", nil
+	case abi.StringTy:
+		return "string", nil
+	case abi.BytesTy:
+		return "[]byte", nil
+	case abi.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", kind.Size), nil
+	case abi.SliceTy:
+		elem, err := bindType(*kind.Elem)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case abi.ArrayTy:
+		elem, err := bindType(*kind.Elem)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("[%d]%s", kind.Size, elem), nil
+	default:
+		return "", fmt.Errorf("unsupported ABI type %q", kind.String())
+	}
+}
+
+// zeroValue returns a Go expression of the zero value of [kind] that can be
+// packed by the ABI. Assumes [kind] is supported by bindType.
+func zeroValue(kind abi.Type) string {
+	goType, _ := bindType(kind)
+	switch kind.T {
+	case abi.IntTy, abi.UintTy:
+		if isBigInt(kind) {
+			// The ABI cannot pack nil big integers
+			return "new(big.Int)"
+		}
+		return "0"
+	case abi.BoolTy:
+		return "false"
+	case abi.StringTy:
+		return `""`
+	case abi.ArrayTy:
+		elemType, _ := bindType(*kind.Elem)
+		if !strings.Contains(elemType, "*big.Int") {
+			return goType + "{}"
+		}
+		elems := make([]string, kind.Size)
+		for i := range elems {
+			elems[i] = zeroValue(*kind.Elem)
+		}
+		return fmt.Sprintf("%s{%s}", goType, strings.Join(elems, ", "))
+	default:
+		return goType + "{}"
+	}
+}
+
+func isBigInt(kind abi.Type) bool {
+	switch kind.Size {
+	case 8, 16, 32, 64:
+		return false
+	default:
+		return true
+	}
+}
+
+// decapitalise makes a camel-case string which starts with a lower case character.
+func decapitalise(input string) string {
+	if len(input) == 0 {
+		return input
+	}
+	goForm := abi.ToCamelCase(input)
+	return strings.ToLower(goForm[:1]) + goForm[1:]
+}
+
+// identifier returns [name], suffixed if it is a Go keyword.
+func identifier(name string) string {
+	if token.IsKeyword(name) {
+		return name + "_"
+	}
+	return name
+}
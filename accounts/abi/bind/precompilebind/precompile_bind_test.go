@@ -0,0 +1,218 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompilebind
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAddress = "0x0300000000000000000000000000000000000001"
+
+	testABI = `[
+	{"type":"function","name":"sayHello","stateMutability":"view","inputs":[],"outputs":[{"name":"result","type":"string"}]},
+	{"type":"function","name":"setGreeting","stateMutability":"nonpayable","inputs":[{"name":"greeting","type":"string"}],"outputs":[]},
+	{"type":"function","name":"sum","stateMutability":"pure","inputs":[{"name":"a","type":"uint256"},{"name":"b","type":"uint64"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"event","name":"GreetingChanged","anonymous":false,"inputs":[{"indexed":true,"name":"sender","type":"address"},{"indexed":false,"name":"greeting","type":"string"}]}
+]`
+
+	testAllowListABI = `[
+	{"type":"function","name":"readAllowList","stateMutability":"view","inputs":[{"name":"addr","type":"address"}],"outputs":[{"name":"role","type":"uint256"}]},
+	{"type":"function","name":"setAdmin","stateMutability":"nonpayable","inputs":[{"name":"addr","type":"address"}],"outputs":[]},
+	{"type":"function","name":"setEnabled","stateMutability":"nonpayable","inputs":[{"name":"addr","type":"address"}],"outputs":[]},
+	{"type":"function","name":"setManager","stateMutability":"nonpayable","inputs":[{"name":"addr","type":"address"}],"outputs":[]},
+	{"type":"function","name":"setNone","stateMutability":"nonpayable","inputs":[{"name":"addr","type":"address"}],"outputs":[]},
+	{"type":"function","name":"store","stateMutability":"nonpayable","inputs":[{"name":"key","type":"bytes32"},{"name":"values","type":"int256[2]"}],"outputs":[]},
+	{"type":"event","name":"RoleSet","anonymous":false,"inputs":[{"indexed":true,"name":"role","type":"uint256"},{"indexed":true,"name":"account","type":"address"},{"indexed":true,"name":"sender","type":"address"},{"indexed":false,"name":"oldRole","type":"uint256"}]}
+]`
+)
+
+// declarations returns the names of the top level declarations of [source].
+func declarations(t *testing.T, file string, source string) map[string]bool {
+	parsed, err := parser.ParseFile(token.NewFileSet(), file, source, 0)
+	require.NoError(t, err)
+	names := make(map[string]bool)
+	for _, decl := range parsed.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			names[decl.Name.Name] = true
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						names[name.Name] = true
+					}
+				case *ast.TypeSpec:
+					names[spec.Name.Name] = true
+				}
+			}
+		}
+	}
+	return names
+}
+
+func TestPrecompileBind(t *testing.T) {
+	tests := map[string]struct {
+		abi              string
+		expectedDecls    map[string][]string
+		notExpectedDecls map[string][]string
+	}{
+		"plain precompile": {
+			abi: testABI,
+			expectedDecls: map[string][]string{
+				"module.go": {"ConfigKey", "ContractAddress", "Module", "MakeConfig", "Configure"},
+				"config.go": {"Config", "NewConfig", "NewDisableConfig", "Key", "Verify", "Equal", "errHelloWorldCannotBeActivated"},
+				"contract.go": {
+					"HelloWorldRawABI", "HelloWorldABI", "HelloWorldPrecompile", "createHelloWorldPrecompile",
+					"SayHelloGasCost", "PackSayHello", "PackSayHelloOutput", "UnpackSayHelloOutput", "sayHello",
+					"SetGreetingGasCost", "PackSetGreeting", "UnpackSetGreetingInput", "setGreeting",
+					"SumGasCost", "SumInput", "PackSum", "UnpackSumInput", "PackSumOutput", "sum",
+					"GreetingChangedEventGasCost", "PackGreetingChangedEvent",
+				},
+				"config_test.go":   {"TestVerify", "TestEqual"},
+				"contract_test.go": {"TestHelloWorld", "testCallerAddr"},
+			},
+			notExpectedDecls: map[string][]string{
+				"contract.go": {"UnpackSayHelloInput", "PackSetGreetingOutput", "SumOutput", "ErrNotEnabled"},
+			},
+		},
+		"allow list precompile": {
+			abi: testAllowListABI,
+			expectedDecls: map[string][]string{
+				"config.go":        {"Config", "NewConfig", "Verify", "Equal"},
+				"contract.go":      {"ErrNotEnabled", "GetHelloWorldAllowListStatus", "SetHelloWorldAllowListStatus", "StoreInput", "PackStore", "UnpackStoreInput", "store"},
+				"config_test.go":   {"testAdminAddr", "testEnabledAddr", "testManagerAddr", "testNoRoleAddr"},
+				"contract_test.go": {"TestHelloWorld"},
+			},
+			notExpectedDecls: map[string][]string{
+				// The allow list functions are implemented by the allowlist package
+				"contract.go": {"PackSetAdmin", "readAllowList", "PackRoleSetEvent"},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			sources, err := PrecompileBind("HelloWorld", test.abi, "helloworld", testAddress)
+			require.NoError(err)
+			require.Len(sources, 6)
+			require.Equal(test.abi, sources["contract.abi"])
+			require.Contains(sources["module.go"], `const ConfigKey = "helloWorldConfig"`)
+			require.Contains(sources["module.go"], `common.HexToAddress("0x0300000000000000000000000000000000000001")`)
+
+			for file, expectedDecls := range test.expectedDecls {
+				decls := declarations(t, file, sources[file])
+				for _, decl := range expectedDecls {
+					require.Truef(decls[decl], "%s should declare %s", file, decl)
+				}
+			}
+			for file, notExpectedDecls := range test.notExpectedDecls {
+				decls := declarations(t, file, sources[file])
+				for _, decl := range notExpectedDecls {
+					require.Falsef(decls[decl], "%s should not declare %s", file, decl)
+				}
+			}
+		})
+	}
+}
+
+// TestPrecompileBindBuild builds the generated precompiles against the current
+// source tree and runs their generated tests.
+func TestPrecompileBindBuild(t *testing.T) {
+	require := require.New(t)
+
+	// Skip the test if no Go command can be found
+	gocmd := runtime.GOROOT() + "/bin/go"
+	if !common.FileExist(gocmd) {
+		t.Skip("go sdk not found for testing")
+	}
+	ws := t.TempDir()
+	packages := map[string]string{
+		"helloworld":     testABI,
+		"helloallowlist": testAllowListABI,
+	}
+	for pkg, abi := range packages {
+		sources, err := PrecompileBind("HelloWorld", abi, pkg, testAddress)
+		require.NoError(err)
+		dir := filepath.Join(ws, pkg)
+		require.NoError(os.MkdirAll(dir, 0o700))
+		for file, source := range sources {
+			require.NoError(os.WriteFile(filepath.Join(dir, file), []byte(source), 0o600))
+		}
+	}
+
+	// Convert the workspace to a module using the current source tree
+	pwd, err := os.Getwd()
+	require.NoError(err)
+	commands := [][]string{
+		{"mod", "init", "precompilebindtest"},
+		{"mod", "edit", "-require", "github.com/Juneo-io/jeth@v0.0.0", "-replace", "github.com/Juneo-io/jeth=" + filepath.Join(pwd, "..", "..", "..", "..")}, // Repo root
+		{"mod", "tidy", "-compat=1.21"},
+		{"vet", "./..."},
+		{"test", "-count", "1", "./..."},
+	}
+	for _, args := range commands {
+		cmd := exec.Command(gocmd, args...)
+		cmd.Dir = ws
+		out, err := cmd.CombinedOutput()
+		require.NoErrorf(err, "go %v failed:\n%s", args, out)
+	}
+}
+
+func TestPrecompileBindErrors(t *testing.T) {
+	tests := map[string]struct {
+		typeName    string
+		abi         string
+		address     string
+		expectedErr string
+	}{
+		"missing type": {
+			abi:         testABI,
+			address:     testAddress,
+			expectedErr: errMissingType.Error(),
+		},
+		"invalid address": {
+			typeName:    "HelloWorld",
+			abi:         testABI,
+			address:     "0x03",
+			expectedErr: "invalid precompile address",
+		},
+		"invalid ABI": {
+			typeName:    "HelloWorld",
+			abi:         `{`,
+			address:     testAddress,
+			expectedErr: "failed to parse ABI",
+		},
+		"no function": {
+			typeName:    "HelloWorld",
+			abi:         `[]`,
+			address:     testAddress,
+			expectedErr: "ABI does not define any function",
+		},
+		"tuple argument": {
+			typeName:    "HelloWorld",
+			abi:         `[{"type":"function","name":"f","stateMutability":"view","inputs":[{"name":"t","type":"tuple","components":[{"name":"a","type":"uint256"}]}],"outputs":[]}]`,
+			address:     testAddress,
+			expectedErr: "unsupported ABI type",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := PrecompileBind(test.typeName, test.abi, "helloworld", test.address)
+			require.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Juneo-io/jeth/accounts/abi/bind/precompilebind"
+	"github.com/Juneo-io/jeth/cmd/utils"
+	"github.com/Juneo-io/jeth/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// Flags needed by precompilegen
+	abiFlag = &cli.StringFlag{
+		Name:  "abi",
+		Usage: "Path to the ABI json of the precompile Solidity interface, - for STDIN",
+	}
+	typeFlag = &cli.StringFlag{
+		Name:  "type",
+		Usage: "Type name of the precompile, e.g. HelloWorld",
+	}
+	pkgFlag = &cli.StringFlag{
+		Name:  "pkg",
+		Usage: "Package name to generate the precompile into (default = lower case type name)",
+	}
+	outFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "Output directory for the generated precompile (default = package name)",
+	}
+	addressFlag = &cli.StringFlag{
+		Name:  "address",
+		Usage: "Hex address of the precompile, e.g. 0x0300000000000000000000000000000000000001",
+	}
+)
+
+var app = flags.NewApp("Stateful precompile code generator")
+
+func init() {
+	app.Name = "precompilegen"
+	app.Flags = []cli.Flag{
+		abiFlag,
+		typeFlag,
+		pkgFlag,
+		outFlag,
+		addressFlag,
+	}
+	app.Action = precompilegen
+}
+
+func precompilegen(c *cli.Context) error {
+	if c.String(abiFlag.Name) == "" {
+		utils.Fatalf("No precompile ABI specified (--abi)")
+	}
+	if c.String(typeFlag.Name) == "" {
+		utils.Fatalf("No precompile type name specified (--type)")
+	}
+	if c.String(addressFlag.Name) == "" {
+		utils.Fatalf("No precompile address specified (--address)")
+	}
+	var (
+		abi []byte
+		err error
+	)
+	input := c.String(abiFlag.Name)
+	if input == "-" {
+		abi, err = io.ReadAll(os.Stdin)
+	} else {
+		abi, err = os.ReadFile(input)
+	}
+	if err != nil {
+		utils.Fatalf("Failed to read input ABI: %v", err)
+	}
+
+	kind := c.String(typeFlag.Name)
+	pkg := c.String(pkgFlag.Name)
+	if pkg == "" {
+		pkg = strings.ToLower(kind)
+	}
+	out := c.String(outFlag.Name)
+	if out == "" {
+		out = pkg
+	}
+
+	// Generate the precompile module
+	sources, err := precompilebind.PrecompileBind(kind, string(abi), pkg, c.String(addressFlag.Name))
+	if err != nil {
+		utils.Fatalf("Failed to generate precompile: %v", err)
+	}
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		utils.Fatalf("Failed to create output directory: %v", err)
+	}
+	files := make([]string, 0, len(sources))
+	for file := range sources {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		path := filepath.Join(out, file)
+		if err := os.WriteFile(path, []byte(sources[file]), 0o600); err != nil {
+			utils.Fatalf("Failed to write %s: %v", path, err)
+		}
+		log.Info("Generated precompile file", "path", path)
+	}
+	log.Info("Register the precompile by importing its package in precompile/registry", "package", pkg)
+	return nil
+}
+
+func main() {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StreamHandler(os.Stderr, log.TerminalFormat(true))))
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
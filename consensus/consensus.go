@@ -33,6 +33,7 @@ import (
 	"github.com/Juneo-io/jeth/core/state"
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contracts/feemanager"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -53,6 +54,10 @@ type ChainHeaderReader interface {
 
 	// GetHeaderByHash retrieves a block header from the database by its hash.
 	GetHeaderByHash(hash common.Hash) *types.Header
+
+	// GetFeeConfigAt retrieves the fee config of the fee manager precompile that
+	// applies to the children of [parent], or nil if the fee manager is not enabled.
+	GetFeeConfigAt(parent *types.Header) (*feemanager.FeeConfig, error)
 }
 
 // ChainReader defines a small collection of methods needed to access the local
@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/Juneo-io/jeth/core/state"
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contracts/feemanager"
 	"github.com/Juneo-io/juneogo/utils/wrappers"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -34,11 +36,33 @@ var (
 	rollupWindow                  uint64 = 10
 )
 
+// GetFeeConfig returns the fee config of the fee manager precompile that applies
+// to the children of [parent], or nil if the fee manager is not enabled at the
+// timestamp of [parent]. In that case, the fee parameters of the network upgrades
+// apply. [stateAt] is only used to open the state of [parent] when the fee manager
+// is enabled.
+func GetFeeConfig(config *params.ChainConfig, parent *types.Header, stateAt func(root common.Hash) (*state.StateDB, error)) (*feemanager.FeeConfig, error) {
+	if !config.IsPrecompileEnabled(feemanager.ContractAddress, parent.Time) {
+		return nil, nil
+	}
+	statedb, err := stateAt(parent.Root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state of parent %s: %w", parent.Hash(), err)
+	}
+	feeConfig := feemanager.GetStoredFeeConfig(statedb)
+	if err := feeConfig.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid fee config stored at parent %s: %w", parent.Hash(), err)
+	}
+	return &feeConfig, nil
+}
+
 // CalcBaseFee takes the previous header and the timestamp of its child block
 // and calculates the expected base fee as well as the encoding of the past
 // pricing information for the child block.
+// If [feeConfig] is non-nil, its target gas, base fee change denominator and
+// minimum base fee replace the values of the current network upgrade.
 // CalcBaseFee should only be called if [timestamp] >= [config.ApricotPhase3Timestamp]
-func CalcBaseFee(config *params.ChainConfig, parent *types.Header, timestamp uint64) ([]byte, *big.Int, error) {
+func CalcBaseFee(config *params.ChainConfig, feeConfig *feemanager.FeeConfig, parent *types.Header, timestamp uint64) ([]byte, *big.Int, error) {
 	// If the current block is the first EIP-1559 block, or it is the genesis block
 	// return the initial slice and initial base fee.
 	var (
@@ -76,7 +100,11 @@ func CalcBaseFee(config *params.ChainConfig, parent *types.Header, timestamp uin
 		baseFeeChangeDenominator = ApricotPhase4BaseFeeChangeDenominator
 		parentGasTarget          = params.ApricotPhase3TargetGas
 	)
-	if isApricotPhase5 {
+	switch {
+	case feeConfig != nil:
+		baseFeeChangeDenominator = feeConfig.BaseFeeChangeDenominator
+		parentGasTarget = feeConfig.TargetGas.Uint64()
+	case isApricotPhase5:
 		baseFeeChangeDenominator = ApricotPhase5BaseFeeChangeDenominator
 		parentGasTarget = params.ApricotPhase5TargetGas
 	}
@@ -177,6 +205,8 @@ func CalcBaseFee(config *params.ChainConfig, parent *types.Header, timestamp uin
 
 	// Ensure that the base fee does not increase/decrease outside of the bounds
 	switch {
+	case feeConfig != nil:
+		baseFee = selectBigWithinBounds(feeConfig.MinBaseFee, baseFee, nil)
 	case isApricotPhase5:
 		baseFee = selectBigWithinBounds(config.GetCurrentBaseFee(parent.Time), baseFee, nil)
 	case isApricotPhase4:
@@ -193,11 +223,11 @@ func CalcBaseFee(config *params.ChainConfig, parent *types.Header, timestamp uin
 // If [timestamp] is less than the timestamp of [parent], then it uses the same timestamp as parent.
 // Warning: This function should only be used in estimation and should not be used when calculating the canonical
 // base fee for a subsequent block.
-func EstimateNextBaseFee(config *params.ChainConfig, parent *types.Header, timestamp uint64) ([]byte, *big.Int, error) {
+func EstimateNextBaseFee(config *params.ChainConfig, feeConfig *feemanager.FeeConfig, parent *types.Header, timestamp uint64) ([]byte, *big.Int, error) {
 	if timestamp < parent.Time {
 		timestamp = parent.Time
 	}
-	return CalcBaseFee(config, parent, timestamp)
+	return CalcBaseFee(config, feeConfig, parent, timestamp)
 }
 
 // selectBigWithinBounds returns [value] if it is within the bounds:
@@ -321,6 +351,34 @@ func calcBlockGasCost(
 	return blockGasCost
 }
 
+// calcBlockGasCostWithConfig calculates the required block gas cost of a block
+// at [timestamp] with the parameters of [feeConfig] if it is non-nil, or the
+// ones of the current network upgrade otherwise.
+func calcBlockGasCostWithConfig(config *params.ChainConfig, feeConfig *feemanager.FeeConfig, parent *types.Header, timestamp uint64) *big.Int {
+	if feeConfig != nil {
+		return calcBlockGasCost(
+			feeConfig.TargetBlockRate.Uint64(),
+			feeConfig.MinBlockGasCost,
+			feeConfig.MaxBlockGasCost,
+			feeConfig.BlockGasCostStep,
+			parent.BlockGasCost,
+			parent.Time, timestamp,
+		)
+	}
+	blockGasCostStep := ApricotPhase4BlockGasCostStep
+	if config.IsApricotPhase5(timestamp) {
+		blockGasCostStep = ApricotPhase5BlockGasCostStep
+	}
+	return calcBlockGasCost(
+		ApricotPhase4TargetBlockRate,
+		ApricotPhase4MinBlockGasCost,
+		ApricotPhase4MaxBlockGasCost,
+		blockGasCostStep,
+		parent.BlockGasCost,
+		parent.Time, timestamp,
+	)
+}
+
 // MinRequiredTip is the estimated minimum tip a transaction would have
 // needed to pay to be included in a given block (assuming it paid a tip
 // proportional to its gas usage). In reality, there is no minimum tip that
@@ -10,9 +10,11 @@ import (
 
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contracts/feemanager"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func testRollup(t *testing.T, longs []uint64, roll int) {
@@ -204,7 +206,7 @@ func testDynamicFeesStaysWithinRange(t *testing.T, test test) {
 	}
 
 	for index, block := range blocks[1:] {
-		nextExtraData, nextBaseFee, err := CalcBaseFee(params.TestApricotPhase3Config, header, block.timestamp)
+		nextExtraData, nextBaseFee, err := CalcBaseFee(params.TestApricotPhase3Config, nil, header, block.timestamp)
 		if err != nil {
 			t.Fatalf("Failed to calculate base fee at index %d: %s", index, err)
 		}
@@ -409,7 +411,7 @@ func TestCalcBaseFeeAP4(t *testing.T) {
 
 	for index, event := range events {
 		block := event.block
-		nextExtraData, nextBaseFee, err := CalcBaseFee(params.TestApricotPhase4Config, header, block.timestamp)
+		nextExtraData, nextBaseFee, err := CalcBaseFee(params.TestApricotPhase4Config, nil, header, block.timestamp)
 		assert.NoError(t, err)
 		log.Info("Update", "baseFee", nextBaseFee)
 		header = &types.Header{
@@ -420,7 +422,7 @@ func TestCalcBaseFeeAP4(t *testing.T) {
 			Extra:   nextExtraData,
 		}
 
-		nextExtraData, nextBaseFee, err = CalcBaseFee(params.TestApricotPhase4Config, extDataHeader, block.timestamp)
+		nextExtraData, nextBaseFee, err = CalcBaseFee(params.TestApricotPhase4Config, nil, extDataHeader, block.timestamp)
 		assert.NoError(t, err)
 		log.Info("Update", "baseFee (w/extData)", nextBaseFee)
 		extDataHeader = &types.Header{
@@ -537,3 +539,73 @@ func TestCalcBlockGasCost(t *testing.T) {
 		})
 	}
 }
+
+func TestCalcBaseFeeWithFeeConfig(t *testing.T) {
+	parent := &types.Header{
+		Number:         big.NewInt(1),
+		Time:           10,
+		BaseFee:        big.NewInt(100_000_000_000),
+		Extra:          make([]byte, params.DynamicFeeExtraDataSize),
+		ExtDataGasUsed: big.NewInt(0),
+	}
+	newFeeConfig := func(minBaseFee int64) *feemanager.FeeConfig {
+		return &feemanager.FeeConfig{
+			TargetGas:                big.NewInt(10_000_000),
+			MinBaseFee:               big.NewInt(minBaseFee),
+			BaseFeeChangeDenominator: big.NewInt(2),
+			TargetBlockRate:          big.NewInt(2),
+			MinBlockGasCost:          big.NewInt(0),
+			MaxBlockGasCost:          big.NewInt(1_000_000),
+			BlockGasCostStep:         big.NewInt(200_000),
+		}
+	}
+
+	// No gas was used in the window, so the base fee decreases by 1/[BaseFeeChangeDenominator]
+	_, baseFee, err := CalcBaseFee(params.TestApricotPhase5Config, newFeeConfig(0), parent, 12)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(50_000_000_000), baseFee)
+
+	// The base fee cannot decrease below [MinBaseFee]
+	_, baseFee, err = CalcBaseFee(params.TestApricotPhase5Config, newFeeConfig(90_000_000_000), parent, 12)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(90_000_000_000), baseFee)
+
+	// Without a fee config, the less responsive denominator of AP5 applies
+	_, baseFee, err = CalcBaseFee(params.TestApricotPhase5Config, nil, parent, 12)
+	require.NoError(t, err)
+	require.Positive(t, baseFee.Cmp(big.NewInt(90_000_000_000)))
+}
+
+func TestCalcBlockGasCostWithConfig(t *testing.T) {
+	parent := &types.Header{
+		Time:         10,
+		BlockGasCost: big.NewInt(500),
+	}
+	feeConfig := &feemanager.FeeConfig{
+		TargetGas:                big.NewInt(10_000_000),
+		MinBaseFee:               big.NewInt(0),
+		BaseFeeChangeDenominator: big.NewInt(36),
+		TargetBlockRate:          big.NewInt(4),
+		MinBlockGasCost:          big.NewInt(10),
+		MaxBlockGasCost:          big.NewInt(850),
+		BlockGasCostStep:         big.NewInt(100),
+	}
+
+	// 1s after the parent, 3s before the target block rate
+	require.Equal(t, big.NewInt(800), calcBlockGasCostWithConfig(params.TestApricotPhase5Config, feeConfig, parent, 11))
+	// Bounded by [MaxBlockGasCost]
+	require.Equal(t, big.NewInt(850), calcBlockGasCostWithConfig(params.TestApricotPhase5Config, feeConfig, parent, 10))
+	// Bounded by [MinBlockGasCost]
+	require.Equal(t, big.NewInt(10), calcBlockGasCostWithConfig(params.TestApricotPhase5Config, feeConfig, parent, 30))
+
+	// Without a fee config, the AP5 parameters apply
+	expected := calcBlockGasCost(
+		ApricotPhase4TargetBlockRate,
+		ApricotPhase4MinBlockGasCost,
+		ApricotPhase4MaxBlockGasCost,
+		ApricotPhase5BlockGasCostStep,
+		parent.BlockGasCost,
+		parent.Time, 11,
+	)
+	require.Equal(t, expected, calcBlockGasCostWithConfig(params.TestApricotPhase5Config, nil, parent, 11))
+}
@@ -0,0 +1,21 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+// WarpDestination encodes the destination of the warp messages addressed to a
+// contract of another chain. Warp messages do not carry a destination, so the
+// nodes only index a sent message by destination, for warp_getMessagesByDestination
+// and the in-process relayer, if its payload is exactly the encoding returned by
+// encode.
+library WarpDestination {
+  // encode returns the payload of a warp message carrying [payload] to
+  // [destinationAddress] on [destinationChainID]. It is the ABI encoding of a
+  // call to warpDestination(bytes32,address,bytes).
+  function encode(
+    bytes32 destinationChainID,
+    address destinationAddress,
+    bytes memory payload
+  ) internal pure returns (bytes memory) {
+    require(destinationChainID != bytes32(0), "WarpDestination: empty destination chain");
+    return abi.encodeWithSignature("warpDestination(bytes32,address,bytes)", destinationChainID, destinationAddress, payload);
+  }
+}
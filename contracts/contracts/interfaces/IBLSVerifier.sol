@@ -0,0 +1,44 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+// IBLSVerifier is accessible at 0x0300000000000000000000000000000000000001
+// Public keys are 48 byte compressed G1 points and signatures are 96 byte
+// compressed G2 points, as used by the validators signing warp messages.
+// Malformed public keys and signatures revert.
+interface IBLSVerifier {
+  // Returns true if [signature] is the signature of [message] by [publicKey].
+  function verifySignature(
+    bytes calldata publicKey,
+    bytes calldata signature,
+    bytes calldata message
+  ) external view returns (bool valid);
+
+  // Returns true if [proofOfPossession] proves the possession of the secret key of
+  // [publicKey], as registered by the validators along with their key.
+  function verifyProofOfPossession(
+    bytes calldata publicKey,
+    bytes calldata proofOfPossession
+  ) external view returns (bool valid);
+
+  // Returns true if [signature] is the aggregate signature of [message] by all the [publicKeys].
+  // The proofs of possession of [publicKeys] are not verified, so a key chosen from
+  // the other keys can forge an aggregate signature. Only pass keys whose proof of
+  // possession was verified with verifyProofOfPossession, or the keys of registered
+  // validators.
+  function verifyAggregateSignature(
+    bytes[] calldata publicKeys,
+    bytes calldata signature,
+    bytes calldata message
+  ) external view returns (bool valid);
+
+  // Returns the compressed aggregate of [publicKeys].
+  // The proofs of possession of [publicKeys] are not verified.
+  function aggregatePublicKeys(bytes[] calldata publicKeys) external view returns (bytes memory aggregatePublicKey);
+
+  // Returns the aggregate of [signatures].
+  function aggregateSignatures(bytes[] calldata signatures) external view returns (bytes memory aggregateSignature);
+}
@@ -0,0 +1,50 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+import "./IAllowList.sol";
+
+// IFeeManager is accessible at 0x0200000000000000000000000000000000000003
+interface IFeeManager is IAllowList {
+  event FeeConfigChanged(
+    address indexed sender,
+    uint256 targetGas,
+    uint256 minBaseFee,
+    uint256 baseFeeChangeDenominator,
+    uint256 targetBlockRate,
+    uint256 minBlockGasCost,
+    uint256 maxBlockGasCost,
+    uint256 blockGasCostStep
+  );
+
+  // Set the fee config used from the next block on. Requires the enabled role.
+  function setFeeConfig(
+    uint256 targetGas,
+    uint256 minBaseFee,
+    uint256 baseFeeChangeDenominator,
+    uint256 targetBlockRate,
+    uint256 minBlockGasCost,
+    uint256 maxBlockGasCost,
+    uint256 blockGasCostStep
+  ) external;
+
+  // Get the current fee config.
+  function getFeeConfig()
+    external
+    view
+    returns (
+      uint256 targetGas,
+      uint256 minBaseFee,
+      uint256 baseFeeChangeDenominator,
+      uint256 targetBlockRate,
+      uint256 minBlockGasCost,
+      uint256 maxBlockGasCost,
+      uint256 blockGasCostStep
+    );
+
+  // Get the number of the block where the fee config was last changed.
+  function getFeeConfigLastChangedAt() external view returns (uint256 blockNumber);
+}
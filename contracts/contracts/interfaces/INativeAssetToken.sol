@@ -0,0 +1,40 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+// INativeAssetToken is accessible at 0x0300000000000000000000000000000000000000
+interface INativeAssetToken {
+  // Returns the address of the ERC-20 token of a native asset:
+  // the last 20 bytes of keccak256(abi.encodePacked(address(this), assetID)).
+  // The token is only accessible if the asset is exposed by the chain config.
+  function tokenAddress(bytes32 assetID) external view returns (address token);
+}
+
+// INativeAssetERC20 is implemented by the tokens of the native assets.
+// Balances are the multicoin balances of the asset.
+interface INativeAssetERC20 {
+  event Transfer(address indexed from, address indexed to, uint256 value);
+  event Approval(address indexed owner, address indexed spender, uint256 value);
+
+  function name() external view returns (string memory);
+
+  function symbol() external view returns (string memory);
+
+  function decimals() external view returns (uint8);
+
+  function assetID() external view returns (bytes32);
+
+  function balanceOf(address account) external view returns (uint256);
+
+  function allowance(address owner, address spender) external view returns (uint256);
+
+  function approve(address spender, uint256 value) external returns (bool);
+
+  function transfer(address to, uint256 value) external returns (bool);
+
+  // An allowance of type(uint256).max is not spent.
+  function transferFrom(address from, address to, uint256 value) external returns (bool);
+}
@@ -0,0 +1,11 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+import "./IAllowList.sol";
+
+// ITxAllowList is accessible at 0x0200000000000000000000000000000000000002
+interface ITxAllowList is IAllowList {}
@@ -43,12 +43,14 @@ import (
 	"github.com/Juneo-io/jeth/consensus/misc/eip4844"
 	"github.com/Juneo-io/jeth/core/rawdb"
 	"github.com/Juneo-io/jeth/core/state"
+	"github.com/Juneo-io/jeth/core/state/pruner"
 	"github.com/Juneo-io/jeth/core/state/snapshot"
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/core/vm"
 	"github.com/Juneo-io/jeth/internal/version"
 	"github.com/Juneo-io/jeth/metrics"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contracts/feemanager"
 	"github.com/Juneo-io/jeth/trie"
 	"github.com/Juneo-io/jeth/trie/triedb/hashdb"
 	"github.com/Juneo-io/jeth/trie/triedb/pathdb"
@@ -103,14 +105,17 @@ var (
 	errCacheConfigNotSpecified = errors.New("must specify cache config")
 	errInvalidOldChain         = errors.New("invalid old chain")
 	errInvalidNewChain         = errors.New("invalid new chain")
+
+	errOnlinePruningUnsupported = errors.New("online pruning requires pruning enabled with the hash state scheme")
 )
 
 const (
-	bodyCacheLimit     = 256
-	blockCacheLimit    = 256
-	receiptsCacheLimit = 32
-	txLookupCacheLimit = 1024
-	badBlockLimit      = 10
+	bodyCacheLimit      = 256
+	blockCacheLimit     = 256
+	receiptsCacheLimit  = 32
+	txLookupCacheLimit  = 1024
+	feeConfigCacheLimit = 256
+	badBlockLimit       = 10
 
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
 	//
@@ -171,6 +176,9 @@ type CacheConfig struct {
 
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
+
+	OnlinePruningBloomSize  uint64        // Memory allowance (MB) of the online pruner bloom filter, disabled if 0
+	OnlinePruningBatchDelay time.Duration // Delay between two batches of the online pruner
 }
 
 // triedbConfig derives the configures for trie database.
@@ -258,11 +266,12 @@ type BlockChain struct {
 
 	currentBlock atomic.Pointer[types.Header] // Current head of the block chain
 
-	bodyCache     *lru.Cache[common.Hash, *types.Body]                // Cache for the most recent block bodies
-	receiptsCache *lru.Cache[common.Hash, []*types.Receipt]           // Cache for the most recent receipts per block
-	blockCache    *lru.Cache[common.Hash, *types.Block]               // Cache for the most recent entire blocks
-	txLookupCache *lru.Cache[common.Hash, *rawdb.LegacyTxLookupEntry] // Cache for the most recent transaction lookup data.
-	badBlocks     *lru.Cache[common.Hash, *badBlock]                  // Cache for bad blocks
+	bodyCache      *lru.Cache[common.Hash, *types.Body]                // Cache for the most recent block bodies
+	receiptsCache  *lru.Cache[common.Hash, []*types.Receipt]           // Cache for the most recent receipts per block
+	blockCache     *lru.Cache[common.Hash, *types.Block]               // Cache for the most recent entire blocks
+	txLookupCache  *lru.Cache[common.Hash, *rawdb.LegacyTxLookupEntry] // Cache for the most recent transaction lookup data.
+	feeConfigCache *lru.Cache[common.Hash, *feemanager.FeeConfig]      // Cache for the fee manager configs per state root
+	badBlocks      *lru.Cache[common.Hash, *badBlock]                  // Cache for bad blocks
 
 	stopping atomic.Bool // false if chain is running, true when stopped
 
@@ -317,6 +326,10 @@ type BlockChain struct {
 
 	// [txIndexTailLock] is used to synchronize the updating of the tx index tail.
 	txIndexTailLock sync.Mutex
+
+	// [onlinePruner] deletes stale trie nodes in the background when started,
+	// nil if online pruning is not supported by the configuration.
+	onlinePruner *pruner.OnlinePruner
 }
 
 // NewBlockChain returns a fully initialised block chain using information
@@ -359,6 +372,7 @@ func NewBlockChain(
 		receiptsCache:     lru.NewCache[common.Hash, []*types.Receipt](receiptsCacheLimit),
 		blockCache:        lru.NewCache[common.Hash, *types.Block](blockCacheLimit),
 		txLookupCache:     lru.NewCache[common.Hash, *rawdb.LegacyTxLookupEntry](txLookupCacheLimit),
+		feeConfigCache:    lru.NewCache[common.Hash, *feemanager.FeeConfig](feeConfigCacheLimit),
 		badBlocks:         lru.NewCache[common.Hash, *badBlock](badBlockLimit),
 		engine:            engine,
 		vmConfig:          vmConfig,
@@ -385,6 +399,15 @@ func NewBlockChain(
 	// Create the state manager
 	bc.stateManager = NewTrieWriter(bc.triedb, cacheConfig)
 
+	// Online pruning is only supported when the trie database garbage collects
+	// the hash-based state.
+	if cacheConfig.Pruning && cacheConfig.StateScheme == rawdb.HashScheme && cacheConfig.OnlinePruningBloomSize > 0 {
+		bc.onlinePruner = pruner.NewOnlinePruner(bc.db, bc.triedb, bc.genesisBlock.Root(), pruner.OnlineConfig{
+			BloomSize:  cacheConfig.OnlinePruningBloomSize,
+			BatchDelay: cacheConfig.OnlinePruningBatchDelay,
+		})
+	}
+
 	// Re-generate current block state if it is missing
 	if err := bc.loadLastState(lastAcceptedHash); err != nil {
 		return nil, err
@@ -634,7 +657,13 @@ func (bc *BlockChain) startAcceptor() {
 		acceptorQueueGauge.Dec(1)
 
 		if err := bc.flattenSnapshot(func() error {
-			return bc.stateManager.AcceptTrie(next)
+			if err := bc.stateManager.AcceptTrie(next); err != nil {
+				return err
+			}
+			if bc.onlinePruner != nil {
+				return bc.onlinePruner.Accept(next.Hash(), next.Root())
+			}
+			return nil
 		}, next.Hash()); err != nil {
 			log.Crit("unable to flatten snapshot from acceptor", "blockHash", next.Hash(), "err", err)
 		}
@@ -1000,6 +1029,11 @@ func (bc *BlockChain) stopWithoutSaving() {
 	bc.stopAcceptor()
 	log.Info("Acceptor queue drained", "t", time.Since(start))
 
+	if bc.onlinePruner != nil {
+		log.Info("Stopping online pruner")
+		bc.onlinePruner.Stop()
+	}
+
 	// Stop senderCacher's goroutines
 	log.Info("Shutting down sender cacher")
 	bc.senderCacher.Shutdown()
@@ -1225,7 +1259,9 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	blockBatch := bc.db.NewBatch()
 	rawdb.WriteBlock(blockBatch, block)
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
+	rawdb.WriteSyntheticLogs(blockBatch, block.Hash(), block.NumberU64(), state.SyntheticLogs())
 	rawdb.WritePreimages(blockBatch, state.Preimages())
+	rawdb.WriteMultiCoinIDs(bc.db, blockBatch, state.MultiCoinIDs())
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
 	}
@@ -1242,6 +1278,9 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	if err != nil {
 		return err
 	}
+	if bc.onlinePruner != nil {
+		bc.onlinePruner.Insert(block.Hash())
+	}
 	// If node is running in path mode, skip explicit gc operation
 	// which is unnecessary in this mode.
 	if bc.triedb.Scheme() == rawdb.PathScheme {
@@ -1467,7 +1506,7 @@ func (bc *BlockChain) insertBlock(block *types.Block, writes bool) error {
 }
 
 // collectUnflattenedLogs collects the logs that were generated or removed during
-// the processing of a block.
+// the processing of a block, followed by the block's synthetic logs if any.
 func (bc *BlockChain) collectUnflattenedLogs(b *types.Block, removed bool) [][]*types.Log {
 	var blobGasPrice *big.Int
 	excessBlobGas := b.ExcessBlobGas()
@@ -1482,6 +1521,7 @@ func (bc *BlockChain) collectUnflattenedLogs(b *types.Block, removed bool) [][]*
 	// Note: gross but this needs to be initialized here because returning nil will be treated specially as an incorrect
 	// error case downstream.
 	logs := make([][]*types.Log, len(receipts))
+	var logIndex uint
 	for i, receipt := range receipts {
 		receiptLogs := make([]*types.Log, len(receipt.Logs))
 		for i, log := range receipt.Logs {
@@ -1491,6 +1531,20 @@ func (bc *BlockChain) collectUnflattenedLogs(b *types.Block, removed bool) [][]*
 			receiptLogs[i] = log
 		}
 		logs[i] = receiptLogs
+		logIndex += uint(len(receiptLogs))
+	}
+
+	// Synthetic logs (such as multicoin transfers) are not part of any receipt,
+	// so they are appended as an extra group after the receipt logs.
+	if syntheticLogs := rawdb.ReadSyntheticLogs(bc.db, b.Hash(), b.NumberU64()); len(syntheticLogs) > 0 {
+		for _, log := range syntheticLogs {
+			log.BlockNumber = b.NumberU64()
+			log.BlockHash = b.Hash()
+			log.Index = logIndex
+			log.Removed = removed
+			logIndex++
+		}
+		logs = append(logs, syntheticLogs)
 	}
 	return logs
 }
@@ -2166,6 +2220,14 @@ func (bc *BlockChain) ResetToStateSyncedBlock(block *types.Block) error {
 	bc.currentBlock.Store(block.Header())
 	bc.hc.SetCurrentHeader(block.Header())
 
+	// The path-based trie database holds layers on top of the state that was
+	// persisted before state sync. Rebuild it on top of the synced state.
+	if bc.triedb.Scheme() == rawdb.PathScheme {
+		if err := bc.triedb.Enable(block.Root()); err != nil {
+			return err
+		}
+	}
+
 	lastAcceptedHash := block.Hash()
 	bc.stateCache = state.NewDatabaseWithNodeDB(bc.db, bc.triedb)
 
@@ -2193,6 +2255,37 @@ func (bc *BlockChain) CacheConfig() *CacheConfig {
 	return bc.cacheConfig
 }
 
+// StartOnlinePruning starts deleting stale trie nodes in the background, or
+// resumes the current run if paused.
+func (bc *BlockChain) StartOnlinePruning() error {
+	if bc.onlinePruner == nil {
+		return errOnlinePruningUnsupported
+	}
+	// Hold [chainmu] so that no block is inserted while the pruner starts
+	// tracking the committed trie nodes.
+	bc.chainmu.Lock()
+	defer bc.chainmu.Unlock()
+
+	return bc.onlinePruner.Start()
+}
+
+// PauseOnlinePruning pauses the current run of the online pruner.
+func (bc *BlockChain) PauseOnlinePruning() error {
+	if bc.onlinePruner == nil {
+		return errOnlinePruningUnsupported
+	}
+	return bc.onlinePruner.Pause()
+}
+
+// OnlinePruningStatus returns the progress of the current, or last, run of the
+// online pruner.
+func (bc *BlockChain) OnlinePruningStatus() (pruner.OnlineStatus, error) {
+	if bc.onlinePruner == nil {
+		return pruner.OnlineStatus{}, errOnlinePruningUnsupported
+	}
+	return bc.onlinePruner.Status(), nil
+}
+
 func (bc *BlockChain) setTxIndexTail(newTail uint64) error {
 	bc.txIndexTailLock.Lock()
 	defer bc.txIndexTailLock.Unlock()
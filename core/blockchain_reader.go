@@ -28,12 +28,14 @@ package core
 
 import (
 	"github.com/Juneo-io/jeth/consensus"
+	"github.com/Juneo-io/jeth/consensus/dummy"
 	"github.com/Juneo-io/jeth/core/rawdb"
 	"github.com/Juneo-io/jeth/core/state"
 	"github.com/Juneo-io/jeth/core/state/snapshot"
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/core/vm"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contracts/feemanager"
 	"github.com/Juneo-io/jeth/trie"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
@@ -240,6 +242,24 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
 	return state.New(root, bc.stateCache, bc.snaps)
 }
 
+// GetFeeConfigAt returns the fee config of the fee manager precompile that
+// applies to the children of [parent], or nil if the fee manager is not enabled
+// at the timestamp of [parent]. The returned config must not be modified.
+func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (*feemanager.FeeConfig, error) {
+	if !bc.chainConfig.IsPrecompileEnabled(feemanager.ContractAddress, parent.Time) {
+		return nil, nil
+	}
+	if feeConfig, ok := bc.feeConfigCache.Get(parent.Root); ok {
+		return feeConfig, nil
+	}
+	feeConfig, err := dummy.GetFeeConfig(bc.chainConfig, parent, bc.StateAt)
+	if err != nil {
+		return nil, err
+	}
+	bc.feeConfigCache.Add(parent.Root, feeConfig)
+	return feeConfig, nil
+}
+
 // Config retrieves the chain's fork configuration.
 func (bc *BlockChain) Config() *params.ChainConfig { return bc.chainConfig }
 
@@ -65,9 +65,11 @@ func (b *BloomIndexer) Reset(ctx context.Context, section uint64, lastSectionHea
 }
 
 // Process implements core.ChainIndexerBackend, adding a new header's bloom into
-// the index.
+// the index. The bloom of the block's synthetic logs, which is not part of the
+// header, is merged in so that they can be found through the index as well.
 func (b *BloomIndexer) Process(ctx context.Context, header *types.Header) error {
-	b.gen.AddBloom(uint(header.Number.Uint64()-b.section*b.size), header.Bloom)
+	bloom := rawdb.ReadBloomWithSyntheticLogs(b.db, header)
+	b.gen.AddBloom(uint(header.Number.Uint64()-b.section*b.size), bloom)
 	b.head = header.Hash()
 	return nil
 }
@@ -86,6 +88,23 @@ func (b *BloomIndexer) Commit() error {
 	return batch.Write()
 }
 
+// WriteBloomSection generates the bloombits index of [section] from the given
+// [headers], which must be all the headers of the section ordered by number,
+// and writes it to [db]. It is used to index sections that were not processed
+// by the chain indexer, such as the ones backfilled after state sync.
+func WriteBloomSection(db ethdb.Database, size, section uint64, headers []*types.Header) error {
+	b := &BloomIndexer{db: db, size: size}
+	if err := b.Reset(context.Background(), section, common.Hash{}); err != nil {
+		return err
+	}
+	for _, header := range headers {
+		if err := b.Process(context.Background(), header); err != nil {
+			return err
+		}
+	}
+	return b.Commit()
+}
+
 // Prune returns an empty error since we don't support pruning here.
 func (b *BloomIndexer) Prune(threshold uint64) error {
 	return nil
@@ -38,6 +38,7 @@ import (
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/core/vm"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contracts/feemanager"
 	"github.com/Juneo-io/jeth/trie"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -256,9 +257,15 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 	chainreader := &fakeChainReader{config: config}
 	genblock := func(i int, parent *types.Block, triedb *trie.Database, statedb *state.StateDB) (*types.Block, types.Receipts, error) {
 		b := &BlockGen{i: i, chain: blocks, parent: parent, statedb: statedb, config: config, engine: engine}
+		// [statedb] is the state of [parent] until the transactions of the block are applied
+		feeConfig, err := dummy.GetFeeConfig(config, parent.Header(), func(common.Hash) (*state.StateDB, error) { return statedb, nil })
+		if err != nil {
+			return nil, nil, err
+		}
+		chainreader.feeConfig = feeConfig
 		b.header = makeHeader(chainreader, config, parent, gap, statedb, b.engine)
 
-		err := ApplyUpgrades(config, &parent.Header().Time, b, statedb)
+		err = ApplyUpgrades(config, &parent.Header().Time, b, statedb)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to configure precompiles %v", err)
 		}
@@ -356,8 +363,11 @@ func makeHeader(chain consensus.ChainReader, config *params.ChainConfig, parent
 		Time:     time,
 	}
 	if chain.Config().IsApricotPhase3(time) {
-		var err error
-		header.Extra, header.BaseFee, err = dummy.CalcBaseFee(chain.Config(), parent.Header(), time)
+		feeConfig, err := chain.GetFeeConfigAt(parent.Header())
+		if err != nil {
+			panic(err)
+		}
+		header.Extra, header.BaseFee, err = dummy.CalcBaseFee(chain.Config(), feeConfig, parent.Header(), time)
 		if err != nil {
 			panic(err)
 		}
@@ -380,7 +390,8 @@ func makeHeader(chain consensus.ChainReader, config *params.ChainConfig, parent
 }
 
 type fakeChainReader struct {
-	config *params.ChainConfig
+	config    *params.ChainConfig
+	feeConfig *feemanager.FeeConfig // fee config of the parent of the block being generated
 }
 
 // Config returns the chain configuration.
@@ -393,3 +404,9 @@ func (cr *fakeChainReader) GetHeaderByNumber(number uint64) *types.Header
 func (cr *fakeChainReader) GetHeaderByHash(hash common.Hash) *types.Header          { return nil }
 func (cr *fakeChainReader) GetHeader(hash common.Hash, number uint64) *types.Header { return nil }
 func (cr *fakeChainReader) GetBlock(hash common.Hash, number uint64) *types.Block   { return nil }
+
+// GetFeeConfigAt returns the fee config read from the state of the parent of
+// the block being generated.
+func (cr *fakeChainReader) GetFeeConfigAt(parent *types.Header) (*feemanager.FeeConfig, error) {
+	return cr.feeConfig, nil
+}
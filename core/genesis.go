@@ -216,9 +216,21 @@ func SetupGenesisBlock(
 	if storedcfg == nil {
 		log.Warn("Found genesis block without chain config")
 		rawdb.WriteChainConfig(db, stored, newcfg)
+		rawdb.WriteUpgradeConfig(db, stored, &newcfg.UpgradeConfig)
 		return newcfg, stored, nil
 	}
 	storedData, _ := json.Marshal(storedcfg)
+	// The upgrade config is not part of the JSON encoding of the chain config,
+	// so it is stored separately. If it was never stored, the given upgrades are
+	// taken as the accepted history.
+	var storedUpgradeData []byte
+	if storedUpgradeConfig := rawdb.ReadUpgradeConfig(db, stored); storedUpgradeConfig != nil {
+		storedcfg.UpgradeConfig = *storedUpgradeConfig
+		storedUpgradeData, _ = json.Marshal(storedUpgradeConfig)
+	} else {
+		log.Warn("Found chain config without upgrade config")
+		storedcfg.UpgradeConfig = newcfg.UpgradeConfig
+	}
 	// Check config compatibility and write the config. Compatibility errors
 	// are returned to the caller unless we're already at block zero.
 	// we use last accepted block for cfg compatibility check. Note this allows
@@ -245,6 +257,9 @@ func SetupGenesisBlock(
 	if newData, _ := json.Marshal(newcfg); !bytes.Equal(storedData, newData) {
 		rawdb.WriteChainConfig(db, stored, newcfg)
 	}
+	if newUpgradeData, _ := json.Marshal(newcfg.UpgradeConfig); !bytes.Equal(storedUpgradeData, newUpgradeData) {
+		rawdb.WriteUpgradeConfig(db, stored, &newcfg.UpgradeConfig)
+	}
 	return newcfg, stored, nil
 }
 
@@ -360,6 +375,16 @@ func (g *Genesis) Commit(db ethdb.Database, triedb *trie.Database) (*types.Block
 	rawdb.WriteHeadBlockHash(db, block.Hash())
 	rawdb.WriteHeadHeaderHash(db, block.Hash())
 	rawdb.WriteChainConfig(db, block.Hash(), config)
+	rawdb.WriteUpgradeConfig(db, block.Hash(), &config.UpgradeConfig)
+	for _, account := range g.Alloc {
+		coinIDs := make(map[common.Hash]common.Hash, len(account.MCBalance))
+		for coinID := range account.MCBalance {
+			normalizedCoinID := coinID
+			state.NormalizeCoinID(&normalizedCoinID)
+			coinIDs[normalizedCoinID] = coinID
+		}
+		rawdb.WriteMultiCoinIDs(db, db, coinIDs)
+	}
 	return block, nil
 }
 
@@ -438,6 +438,88 @@ func DeleteReceipts(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	}
 }
 
+// storedSyntheticLogRLP is the storage encoding of a synthetic log. Unlike
+// receipt logs, the tx a synthetic log belongs to cannot be derived from its
+// position, so it is stored alongside the log.
+type storedSyntheticLogRLP struct {
+	Address common.Address
+	Topics  []common.Hash
+	Data    []byte
+	TxHash  common.Hash
+	TxIndex uint64
+}
+
+// ReadSyntheticLogs retrieves the synthetic logs recorded for a block. The
+// block number, block hash and log index fields are not populated.
+func ReadSyntheticLogs(db ethdb.KeyValueReader, hash common.Hash, number uint64) []*types.Log {
+	data, _ := db.Get(syntheticLogsKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var stored []storedSyntheticLogRLP
+	if err := rlp.DecodeBytes(data, &stored); err != nil {
+		log.Error("Invalid synthetic log array RLP", "hash", hash, "err", err)
+		return nil
+	}
+	logs := make([]*types.Log, len(stored))
+	for i, l := range stored {
+		logs[i] = &types.Log{
+			Address: l.Address,
+			Topics:  l.Topics,
+			Data:    l.Data,
+			TxHash:  l.TxHash,
+			TxIndex: uint(l.TxIndex),
+		}
+	}
+	return logs
+}
+
+// ReadBloomWithSyntheticLogs returns the bloom of [header] merged with the bloom
+// of the synthetic logs recorded for its block, which are not part of the
+// header bloom.
+func ReadBloomWithSyntheticLogs(db ethdb.KeyValueReader, header *types.Header) types.Bloom {
+	bloom := header.Bloom
+	if logs := ReadSyntheticLogs(db, header.Hash(), header.Number.Uint64()); len(logs) > 0 {
+		syntheticBloom := types.BytesToBloom(types.LogsBloom(logs))
+		for i := range bloom {
+			bloom[i] |= syntheticBloom[i]
+		}
+	}
+	return bloom
+}
+
+// WriteSyntheticLogs stores the synthetic logs recorded for a block. Nothing is
+// written if [logs] is empty.
+func WriteSyntheticLogs(db ethdb.KeyValueWriter, hash common.Hash, number uint64, logs []*types.Log) {
+	if len(logs) == 0 {
+		return
+	}
+	stored := make([]storedSyntheticLogRLP, len(logs))
+	for i, l := range logs {
+		stored[i] = storedSyntheticLogRLP{
+			Address: l.Address,
+			Topics:  l.Topics,
+			Data:    l.Data,
+			TxHash:  l.TxHash,
+			TxIndex: uint64(l.TxIndex),
+		}
+	}
+	bytes, err := rlp.EncodeToBytes(stored)
+	if err != nil {
+		log.Crit("Failed to encode block synthetic logs", "err", err)
+	}
+	if err := db.Put(syntheticLogsKey(number, hash), bytes); err != nil {
+		log.Crit("Failed to store block synthetic logs", "err", err)
+	}
+}
+
+// DeleteSyntheticLogs removes the synthetic logs recorded for a block.
+func DeleteSyntheticLogs(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(syntheticLogsKey(number, hash)); err != nil {
+		log.Crit("Failed to delete block synthetic logs", "err", err)
+	}
+}
+
 // storedReceiptRLP is the storage encoding of a receipt.
 // Re-definition in core/types/receipt.go.
 // TODO: Re-use the existing definition.
@@ -534,6 +616,7 @@ func WriteBlock(db ethdb.KeyValueWriter, block *types.Block) {
 // DeleteBlock removes all block data associated with a hash.
 func DeleteBlock(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
+	DeleteSyntheticLogs(db, hash, number)
 	DeleteHeader(db, hash, number)
 	DeleteBody(db, hash, number)
 }
@@ -542,6 +625,7 @@ func DeleteBlock(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 // the hash to number mapping.
 func DeleteBlockWithoutNumber(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
+	DeleteSyntheticLogs(db, hash, number)
 	deleteHeaderWithoutNumber(db, hash, number)
 	DeleteBody(db, hash, number)
 }
@@ -320,6 +320,57 @@ func TestBlockReceiptStorage(t *testing.T) {
 	}
 }
 
+// Tests that synthetic logs can be stored, retrieved and deleted.
+func TestSyntheticLogsStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	hash := common.BytesToHash([]byte{0x03, 0x14})
+	if logs := ReadSyntheticLogs(db, hash, 0); logs != nil {
+		t.Fatalf("non existent synthetic logs returned: %v", logs)
+	}
+	// Writing no synthetic logs should not store anything
+	WriteSyntheticLogs(db, hash, 0, nil)
+	if has, _ := db.Has(syntheticLogsKey(0, hash)); has {
+		t.Fatalf("empty synthetic logs stored")
+	}
+
+	logs := []*types.Log{
+		{Address: common.BytesToAddress([]byte{0x11}), Topics: []common.Hash{common.HexToHash("dead"), common.HexToHash("beef")}, Data: []byte{0x01}, TxHash: common.BytesToHash([]byte{0x22}), TxIndex: 1},
+		{Address: common.BytesToAddress([]byte{0x33}), Topics: []common.Hash{}, Data: []byte{}, TxHash: common.BytesToHash([]byte{0x44}), TxIndex: 3},
+	}
+	WriteSyntheticLogs(db, hash, 0, logs)
+	if have := ReadSyntheticLogs(db, hash, 0); !reflect.DeepEqual(have, logs) {
+		t.Fatalf("synthetic logs mismatch: have %v, want %v", have, logs)
+	}
+	DeleteSyntheticLogs(db, hash, 0)
+	if logs := ReadSyntheticLogs(db, hash, 0); logs != nil {
+		t.Fatalf("deleted synthetic logs returned: %v", logs)
+	}
+}
+
+// Tests that the bloom of a block's synthetic logs is merged into its header bloom.
+func TestReadBloomWithSyntheticLogs(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	receiptLog := &types.Log{Address: common.BytesToAddress([]byte{0x11})}
+	syntheticLog := &types.Log{Address: common.BytesToAddress([]byte{0x22}), Topics: []common.Hash{common.HexToHash("dead")}}
+	header := &types.Header{Number: big.NewInt(1), Bloom: types.BytesToBloom(types.LogsBloom([]*types.Log{receiptLog}))}
+	if bloom := ReadBloomWithSyntheticLogs(db, header); bloom != header.Bloom {
+		t.Fatalf("bloom without synthetic logs mismatch: have %x, want %x", bloom, header.Bloom)
+	}
+
+	WriteSyntheticLogs(db, header.Hash(), 1, []*types.Log{syntheticLog})
+	bloom := ReadBloomWithSyntheticLogs(db, header)
+	for _, data := range [][]byte{receiptLog.Address.Bytes(), syntheticLog.Address.Bytes(), syntheticLog.Topics[0].Bytes()} {
+		if !bloom.Test(data) {
+			t.Fatalf("bloom does not include %x", data)
+		}
+	}
+	if header.Bloom.Test(syntheticLog.Address.Bytes()) {
+		t.Fatalf("header bloom was modified")
+	}
+}
+
 func checkReceiptsRLP(have, want types.Receipts) error {
 	if len(have) != len(want) {
 		return fmt.Errorf("receipts sizes mismatch: have %d, want %d", len(have), len(want))
@@ -91,6 +91,35 @@ func WriteChainConfig(db ethdb.KeyValueWriter, hash common.Hash, cfg *params.Cha
 	}
 }
 
+// ReadUpgradeConfig retrieves the upgrade config the chain was last started
+// with based on the given genesis hash.
+func ReadUpgradeConfig(db ethdb.KeyValueReader, hash common.Hash) *params.UpgradeConfig {
+	data, _ := db.Get(upgradeConfigKey(hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var upgradeConfig params.UpgradeConfig
+	if err := json.Unmarshal(data, &upgradeConfig); err != nil {
+		log.Error("Invalid upgrade config JSON", "hash", hash, "err", err)
+		return nil
+	}
+	return &upgradeConfig
+}
+
+// WriteUpgradeConfig writes the upgrade config to the database.
+func WriteUpgradeConfig(db ethdb.KeyValueWriter, hash common.Hash, upgradeConfig *params.UpgradeConfig) {
+	if upgradeConfig == nil {
+		return
+	}
+	data, err := json.Marshal(upgradeConfig)
+	if err != nil {
+		log.Crit("Failed to JSON encode upgrade config", "err", err)
+	}
+	if err := db.Put(upgradeConfigKey(hash), data); err != nil {
+		log.Crit("Failed to store upgrade config", "err", err)
+	}
+}
+
 // crashList is a list of unclean-shutdown-markers, for rlp-encoding to the
 // database
 type crashList struct {
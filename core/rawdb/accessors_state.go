@@ -51,6 +51,29 @@ func WritePreimages(db ethdb.KeyValueWriter, preimages map[common.Hash][]byte) {
 	preimageHitCounter.Inc(int64(len(preimages)))
 }
 
+// ReadMultiCoinID retrieves the coin ID whose multicoin balances are stored
+// under [normalizedCoinID], or false if it was not recorded.
+func ReadMultiCoinID(db ethdb.KeyValueReader, normalizedCoinID common.Hash) (common.Hash, bool) {
+	data, _ := db.Get(multiCoinIDKey(normalizedCoinID))
+	if len(data) != common.HashLength {
+		return common.Hash{}, false
+	}
+	return common.BytesToHash(data), true
+}
+
+// WriteMultiCoinIDs records the coin IDs of the provided map from normalized
+// coin IDs, unless a coin ID was already recorded for the normalized coin ID.
+func WriteMultiCoinIDs(db ethdb.KeyValueStore, writer ethdb.KeyValueWriter, coinIDs map[common.Hash]common.Hash) {
+	for normalizedCoinID, coinID := range coinIDs {
+		if has, _ := db.Has(multiCoinIDKey(normalizedCoinID)); has {
+			continue
+		}
+		if err := writer.Put(multiCoinIDKey(normalizedCoinID), coinID.Bytes()); err != nil {
+			log.Crit("Failed to store multicoin ID", "err", err)
+		}
+	}
+}
+
 // ReadCode retrieves the contract code of the provided code hash.
 func ReadCode(db ethdb.KeyValueReader, hash common.Hash) []byte {
 	// Try with the prefixed code scheme first and only. The legacy scheme was never used in coreth.
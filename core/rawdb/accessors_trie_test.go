@@ -0,0 +1,83 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Juneo-io/jeth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStateScheme(t *testing.T) {
+	// writeHashState stores a genesis header and its root node with the hash scheme.
+	writeHashState := func(db ethdb.Database) {
+		header := &types.Header{Number: big.NewInt(0), Root: common.Hash{1}}
+		WriteHeader(db, header)
+		WriteCanonicalHash(db, header.Hash(), 0)
+		WriteLegacyTrieNode(db, header.Root, []byte{0x01})
+	}
+	// writePathState stores the root node of the account trie with the path scheme.
+	writePathState := func(db ethdb.Database) {
+		WriteAccountTrieNode(db, nil, []byte{0x01})
+	}
+
+	tests := map[string]struct {
+		writeState     func(db ethdb.Database)
+		provided       string
+		expectedScheme string
+		expectedErr    string
+	}{
+		"empty database defaults to hash": {
+			expectedScheme: HashScheme,
+		},
+		"empty database uses provided scheme": {
+			provided:       PathScheme,
+			expectedScheme: PathScheme,
+		},
+		"stored hash scheme is reused": {
+			writeState:     writeHashState,
+			expectedScheme: HashScheme,
+		},
+		"stored path scheme is reused": {
+			writeState:     writePathState,
+			expectedScheme: PathScheme,
+		},
+		"matching schemes": {
+			writeState:     writePathState,
+			provided:       PathScheme,
+			expectedScheme: PathScheme,
+		},
+		"path scheme over hash state": {
+			writeState:  writeHashState,
+			provided:    PathScheme,
+			expectedErr: "incompatible state scheme, stored: hash, provided: path",
+		},
+		"hash scheme over path state": {
+			writeState:  writePathState,
+			provided:    HashScheme,
+			expectedErr: "incompatible state scheme, stored: path, provided: hash",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := NewMemoryDatabase()
+			if test.writeState != nil {
+				test.writeState(db)
+			}
+			scheme, err := ParseStateScheme(test.provided, db)
+			if test.expectedErr != "" {
+				require.ErrorContains(err, test.expectedErr)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expectedScheme, scheme)
+		})
+	}
+}
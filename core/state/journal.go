@@ -144,7 +144,8 @@ type (
 	addLogChange struct {
 		txhash common.Hash
 	}
-	addPreimageChange struct {
+	addSyntheticLogChange struct{}
+	addPreimageChange     struct {
 		hash common.Hash
 	}
 	touchChange struct {
@@ -288,6 +289,14 @@ func (ch addLogChange) dirtied() *common.Address {
 	return nil
 }
 
+func (ch addSyntheticLogChange) revert(s *StateDB) {
+	s.syntheticLogs = s.syntheticLogs[:len(s.syntheticLogs)-1]
+}
+
+func (ch addSyntheticLogChange) dirtied() *common.Address {
+	return nil
+}
+
 func (ch addPreimageChange) revert(s *StateDB) {
 	delete(s.preimages, ch.hash)
 }
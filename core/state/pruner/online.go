@@ -0,0 +1,487 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pruner
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Juneo-io/jeth/core/types"
+	"github.com/Juneo-io/jeth/metrics"
+	"github.com/Juneo-io/jeth/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// onlineBatchItems is the number of trie nodes marked, or database entries
+// scanned, by the online pruner between two throttling delays.
+const onlineBatchItems = 10_000
+
+var (
+	errOnlinePrunerRunning    = errors.New("online pruning is already running")
+	errOnlinePrunerNotRunning = errors.New("online pruning is not running")
+	errOnlinePrunerStopped    = errors.New("online pruner stopped")
+
+	onlinePhaseGauge        = metrics.NewRegisteredGauge("state/pruner/online/phase", nil)
+	onlinePausedGauge       = metrics.NewRegisteredGauge("state/pruner/online/paused", nil)
+	onlineMarkedGauge       = metrics.NewRegisteredGauge("state/pruner/online/marked", nil)
+	onlineScannedGauge      = metrics.NewRegisteredGauge("state/pruner/online/scanned", nil)
+	onlineDeletedGauge      = metrics.NewRegisteredGauge("state/pruner/online/deleted", nil)
+	onlineDeletedBytesGauge = metrics.NewRegisteredGauge("state/pruner/online/deleted/bytes", nil)
+)
+
+// OnlinePhase is the phase of a run of the online pruner.
+type OnlinePhase int
+
+const (
+	// OnlinePhaseIdle means no run is in progress.
+	OnlinePhaseIdle OnlinePhase = iota
+	// OnlinePhaseWaiting means the pruner waits for the first block inserted
+	// after the start of the run to be accepted, its state being the target.
+	OnlinePhaseWaiting
+	// OnlinePhaseMarking means the target state is being walked.
+	OnlinePhaseMarking
+	// OnlinePhaseSweeping means the unmarked trie nodes are being deleted.
+	OnlinePhaseSweeping
+)
+
+func (p OnlinePhase) String() string {
+	switch p {
+	case OnlinePhaseIdle:
+		return "idle"
+	case OnlinePhaseWaiting:
+		return "waiting"
+	case OnlinePhaseMarking:
+		return "marking"
+	case OnlinePhaseSweeping:
+		return "sweeping"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(p))
+	}
+}
+
+// OnlineConfig includes the configurations for online pruning.
+type OnlineConfig struct {
+	BloomSize  uint64        // The Megabytes of memory allocated to bloom-filter
+	BatchDelay time.Duration // The delay between two batches of marked nodes or scanned entries
+}
+
+// OnlineStatus reports the progress of the current, or last, run of the online
+// pruner.
+type OnlineStatus struct {
+	Phase       string             `json:"phase"`
+	Paused      bool               `json:"paused"`
+	Root        common.Hash        `json:"root"`
+	Marked      uint64             `json:"marked"`
+	Scanned     uint64             `json:"scanned"`
+	Deleted     uint64             `json:"deleted"`
+	DeletedSize common.StorageSize `json:"deletedSize"`
+	Error       string             `json:"error,omitempty"`
+}
+
+// OnlinePruner deletes the stale trie nodes of the hash-based state scheme in
+// the background, while blocks keep being inserted and accepted. A run goes
+// through the following steps:
+//
+//   - every node committed to the trie database is marked in a bloom filter,
+//     from the start of the run until its end
+//   - the state of the first block inserted after the start of the run is
+//     committed to disk once accepted and walked, marking all its nodes
+//   - the database is iterated in throttled batches, deleting all the trie
+//     nodes which aren't marked and aren't held in the dirty cache
+//
+// Every state accepted after the target descends from it, so its nodes were
+// either part of the target state or committed after the start of the run.
+// The genesis state is always retained.
+type OnlinePruner struct {
+	config      OnlineConfig
+	db          ethdb.Database
+	triedb      *trie.Database
+	genesisRoot common.Hash
+
+	lock     sync.Mutex
+	cond     *sync.Cond               // Signaled when the pruner is resumed or stopped
+	phase    OnlinePhase              // Phase of the current run
+	paused   bool                     // Whether the current run is paused
+	stopped  bool                     // Whether the pruner is stopped
+	bloom    *stateBloom              // Marked trie nodes, nil if idle
+	inserted map[common.Hash]struct{} // Blocks inserted while waiting for the target
+	status   OnlineStatus             // Progress of the current, or last, run
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewOnlinePruner creates an online pruner of the state held in [triedb],
+// which must use the hash-based state scheme.
+func NewOnlinePruner(db ethdb.Database, triedb *trie.Database, genesisRoot common.Hash, config OnlineConfig) *OnlinePruner {
+	p := &OnlinePruner{
+		config:      config,
+		db:          db,
+		triedb:      triedb,
+		genesisRoot: genesisRoot,
+		quit:        make(chan struct{}),
+	}
+	p.cond = sync.NewCond(&p.lock)
+	p.status.Phase = OnlinePhaseIdle.String()
+	return p
+}
+
+// Start starts a new run of the pruner, or resumes the current one if paused.
+//
+// The caller must ensure no block is being inserted concurrently, so that the
+// nodes of every block reported by [Insert] are marked.
+func (p *OnlinePruner) Start() error {
+	p.lock.Lock()
+	if p.stopped {
+		p.lock.Unlock()
+		return errOnlinePrunerStopped
+	}
+	if p.phase != OnlinePhaseIdle {
+		defer p.lock.Unlock()
+		if !p.paused {
+			return errOnlinePrunerRunning
+		}
+		p.paused = false
+		onlinePausedGauge.Update(0)
+		p.cond.Broadcast()
+		log.Info("Resumed online pruning", "phase", p.phase)
+		return nil
+	}
+	bloom, err := newStateBloomWithSize(p.config.BloomSize)
+	if err != nil {
+		p.lock.Unlock()
+		return err
+	}
+	p.bloom = bloom
+	p.inserted = make(map[common.Hash]struct{})
+	p.status = OnlineStatus{}
+	p.setPhase(OnlinePhaseWaiting)
+	onlineMarkedGauge.Update(0)
+	onlineScannedGauge.Update(0)
+	onlineDeletedGauge.Update(0)
+	onlineDeletedBytesGauge.Update(0)
+	p.lock.Unlock()
+
+	// The hook is invoked while holding the trie database lock, so it must be
+	// set without holding [p.lock].
+	if err := p.triedb.SetUpdateHook(p.onUpdate); err != nil {
+		p.finish(err)
+		return err
+	}
+	log.Info("Started online pruning")
+	return nil
+}
+
+// Pause pauses the current run of the pruner. Nodes keep being marked while
+// paused, so the run can be resumed with [Start].
+func (p *OnlinePruner) Pause() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.phase == OnlinePhaseIdle {
+		return errOnlinePrunerNotRunning
+	}
+	p.paused = true
+	onlinePausedGauge.Update(1)
+	log.Info("Paused online pruning", "phase", p.phase)
+	return nil
+}
+
+// Status returns the progress of the current, or last, run of the pruner.
+func (p *OnlinePruner) Status() OnlineStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	status := p.status
+	status.Paused = p.paused
+	return status
+}
+
+// Stop aborts the current run of the pruner, if any, and waits for it to exit.
+// The pruner cannot be started again.
+func (p *OnlinePruner) Stop() {
+	p.lock.Lock()
+	if p.stopped {
+		p.lock.Unlock()
+		return
+	}
+	p.stopped = true
+	close(p.quit)
+	p.cond.Broadcast()
+	p.lock.Unlock()
+
+	p.wg.Wait()
+	if err := p.triedb.SetUpdateHook(nil); err != nil {
+		log.Error("Failed to remove online pruning hook", "err", err)
+	}
+}
+
+// Insert records that the state of the block [hash] has been committed to the
+// trie database.
+func (p *OnlinePruner) Insert(hash common.Hash) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.phase == OnlinePhaseWaiting {
+		p.inserted[hash] = struct{}{}
+	}
+}
+
+// Accept records that the block [hash] with state [root] has been accepted. If
+// its state is the first one inserted since the start of the run, it's written
+// to disk and the pruner starts marking it in the background.
+func (p *OnlinePruner) Accept(hash common.Hash, root common.Hash) error {
+	p.lock.Lock()
+	if _, ok := p.inserted[hash]; p.phase != OnlinePhaseWaiting || !ok || p.stopped {
+		p.lock.Unlock()
+		return nil
+	}
+	p.inserted = nil
+	p.status.Root = root
+	p.setPhase(OnlinePhaseMarking)
+	p.lock.Unlock()
+
+	// Commit the target state, so that a node restarting after the run has
+	// a complete state to re-process from.
+	if err := p.triedb.Commit(root, false); err != nil {
+		p.finish(err)
+		return fmt.Errorf("failed to commit online pruning target %s: %w", root, err)
+	}
+	log.Info("Online pruning target accepted", "hash", hash, "root", root)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		err := p.mark(root)
+		if err == nil {
+			err = p.sweep()
+		}
+		p.finish(err)
+	}()
+	return nil
+}
+
+// onUpdate marks the nodes committed to the trie database.
+func (p *OnlinePruner) onUpdate(_ common.Hash, nodes []common.Hash) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.bloom == nil {
+		return
+	}
+	for _, hash := range nodes {
+		p.bloom.Put(hash.Bytes(), nil)
+	}
+}
+
+// marked returns whether the trie node [hash] is marked.
+func (p *OnlinePruner) marked(hash common.Hash) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.bloom.Contain(hash.Bytes())
+}
+
+// mark marks all the trie nodes of the genesis state and the state [root].
+func (p *OnlinePruner) mark(root common.Hash) error {
+	log.Info("Marking online pruning target", "root", root)
+	start := time.Now()
+
+	hashes := make([]common.Hash, 0, onlineBatchItems)
+	flush := func(force bool) error {
+		if len(hashes) == 0 || (!force && len(hashes) < onlineBatchItems) {
+			return nil
+		}
+		p.lock.Lock()
+		for _, hash := range hashes {
+			p.bloom.Put(hash.Bytes(), nil)
+		}
+		p.status.Marked += uint64(len(hashes))
+		onlineMarkedGauge.Update(int64(p.status.Marked))
+		p.lock.Unlock()
+
+		hashes = hashes[:0]
+		return p.throttle()
+	}
+	for _, stateRoot := range []common.Hash{p.genesisRoot, root} {
+		t, err := trie.NewStateTrie(trie.StateTrieID(stateRoot), p.triedb)
+		if err != nil {
+			return err
+		}
+		accIter, err := t.NodeIterator(nil)
+		if err != nil {
+			return err
+		}
+		for accIter.Next(true) {
+			// Embedded nodes don't have hash.
+			if hash := accIter.Hash(); hash != (common.Hash{}) {
+				hashes = append(hashes, hash)
+			}
+			if accIter.Leaf() {
+				var acc types.StateAccount
+				if err := rlp.DecodeBytes(accIter.LeafBlob(), &acc); err != nil {
+					return err
+				}
+				if acc.Root != types.EmptyRootHash {
+					id := trie.StorageTrieID(stateRoot, common.BytesToHash(accIter.LeafKey()), acc.Root)
+					storageTrie, err := trie.NewStateTrie(id, p.triedb)
+					if err != nil {
+						return err
+					}
+					storageIter, err := storageTrie.NodeIterator(nil)
+					if err != nil {
+						return err
+					}
+					for storageIter.Next(true) {
+						if hash := storageIter.Hash(); hash != (common.Hash{}) {
+							hashes = append(hashes, hash)
+						}
+						if err := flush(false); err != nil {
+							return err
+						}
+					}
+					if storageIter.Error() != nil {
+						return storageIter.Error()
+					}
+				}
+			}
+			if err := flush(false); err != nil {
+				return err
+			}
+		}
+		if accIter.Error() != nil {
+			return accIter.Error()
+		}
+	}
+	if err := flush(true); err != nil {
+		return err
+	}
+	log.Info("Marked online pruning target", "root", root, "nodes", p.Status().Marked, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// sweep iterates the database in batches, deleting the unmarked trie nodes.
+func (p *OnlinePruner) sweep() error {
+	p.lock.Lock()
+	p.setPhase(OnlinePhaseSweeping)
+	p.lock.Unlock()
+
+	log.Info("Sweeping stale trie nodes")
+	start := time.Now()
+	var next []byte
+	for {
+		var (
+			iter    = p.db.NewIterator(nil, next)
+			keys    []common.Hash
+			scanned int
+			size    common.StorageSize
+			done    = true
+		)
+		for iter.Next() {
+			if scanned == onlineBatchItems {
+				next = common.CopyBytes(iter.Key())
+				done = false
+				break
+			}
+			scanned++
+
+			// All 32 byte keys are trie nodes of the hash-based scheme.
+			if key := iter.Key(); len(key) == common.HashLength {
+				keys = append(keys, common.BytesToHash(key))
+				size += common.StorageSize(len(key) + len(iter.Value()))
+			}
+		}
+		err := iter.Error()
+		iter.Release()
+		if err != nil {
+			return err
+		}
+		// The trie database rules out the nodes still held in memory, and
+		// prevents them from being inserted or flushed while deleting.
+		deleted, err := p.triedb.DeleteNodes(keys, p.marked)
+		if err != nil {
+			return err
+		}
+		p.lock.Lock()
+		p.status.Scanned += uint64(scanned)
+		p.status.Deleted += uint64(deleted)
+		if len(keys) > 0 {
+			// Approximate the deleted size with the average size of the candidates.
+			p.status.DeletedSize += size * common.StorageSize(deleted) / common.StorageSize(len(keys))
+		}
+		onlineScannedGauge.Update(int64(p.status.Scanned))
+		onlineDeletedGauge.Update(int64(p.status.Deleted))
+		onlineDeletedBytesGauge.Update(int64(p.status.DeletedSize))
+		p.lock.Unlock()
+
+		if done {
+			break
+		}
+		if err := p.throttle(); err != nil {
+			return err
+		}
+	}
+	status := p.Status()
+	log.Info("Swept stale trie nodes", "scanned", status.Scanned, "deleted", status.Deleted, "size", status.DeletedSize, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// throttle waits for the delay between two batches, and for the run to be
+// resumed if paused.
+func (p *OnlinePruner) throttle() error {
+	if p.config.BatchDelay > 0 {
+		select {
+		case <-time.After(p.config.BatchDelay):
+		case <-p.quit:
+			return errOnlinePrunerStopped
+		}
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for p.paused && !p.stopped {
+		p.cond.Wait()
+	}
+	if p.stopped {
+		return errOnlinePrunerStopped
+	}
+	return nil
+}
+
+// finish ends the current run with [err], which is nil if successful.
+func (p *OnlinePruner) finish(err error) {
+	if hookErr := p.triedb.SetUpdateHook(nil); hookErr != nil {
+		log.Error("Failed to remove online pruning hook", "err", hookErr)
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.bloom = nil
+	p.inserted = nil
+	p.paused = false
+	onlinePausedGauge.Update(0)
+	p.setPhase(OnlinePhaseIdle)
+	switch {
+	case err == nil:
+		log.Info("Online pruning completed", "root", p.status.Root, "deleted", p.status.Deleted, "size", p.status.DeletedSize)
+	case errors.Is(err, errOnlinePrunerStopped):
+		log.Info("Online pruning aborted", "root", p.status.Root)
+	default:
+		p.status.Error = err.Error()
+		log.Error("Online pruning failed", "root", p.status.Root, "err", err)
+	}
+}
+
+// setPhase updates the phase of the current run. Assumes [p.lock] is held.
+func (p *OnlinePruner) setPhase(phase OnlinePhase) {
+	p.phase = phase
+	p.status.Phase = phase.String()
+	onlinePhaseGauge.Update(int64(phase))
+}
@@ -0,0 +1,145 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pruner
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/core/state"
+	"github.com/Juneo-io/jeth/core/types"
+	"github.com/Juneo-io/jeth/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+// commitState applies [value] to the balance and storage of [accounts] on top
+// of [root] and returns the new state root, committed to the trie database.
+func commitState(t *testing.T, db state.Database, root common.Hash, accounts int, value int64) common.Hash {
+	statedb, err := state.New(root, db, nil)
+	require.NoError(t, err)
+	for i := 0; i < accounts; i++ {
+		addr := common.BigToAddress(big.NewInt(int64(i + 1)))
+		statedb.SetBalance(addr, big.NewInt(value))
+		statedb.SetState(addr, common.BigToHash(big.NewInt(value)), common.BigToHash(big.NewInt(int64(i+1))))
+	}
+	root, err = statedb.Commit(0, false, true)
+	require.NoError(t, err)
+	return root
+}
+
+// assertStateComplete asserts all the trie nodes of the state [root] can be
+// read from disk.
+func assertStateComplete(t *testing.T, diskdb ethdb.Database, root common.Hash) {
+	triedb := trie.NewDatabase(diskdb, nil)
+	accTrie, err := trie.NewStateTrie(trie.StateTrieID(root), triedb)
+	require.NoError(t, err)
+	accIter, err := accTrie.NodeIterator(nil)
+	require.NoError(t, err)
+	for accIter.Next(true) {
+		if !accIter.Leaf() {
+			continue
+		}
+		var acc types.StateAccount
+		require.NoError(t, rlp.DecodeBytes(accIter.LeafBlob(), &acc))
+		id := trie.StorageTrieID(root, common.BytesToHash(accIter.LeafKey()), acc.Root)
+		storageTrie, err := trie.NewStateTrie(id, triedb)
+		require.NoError(t, err)
+		storageIter, err := storageTrie.NodeIterator(nil)
+		require.NoError(t, err)
+		for storageIter.Next(true) {
+		}
+		require.NoError(t, storageIter.Error())
+	}
+	require.NoError(t, accIter.Error())
+}
+
+func waitOnlinePruner(t *testing.T, p *OnlinePruner) OnlineStatus {
+	require.Eventually(t, func() bool {
+		return p.Status().Phase == OnlinePhaseIdle.String()
+	}, 10*time.Second, 10*time.Millisecond)
+	return p.Status()
+}
+
+func TestOnlinePruner(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		diskdb = rawdb.NewMemoryDatabase()
+		triedb = trie.NewDatabase(diskdb, nil)
+		db     = state.NewDatabaseWithNodeDB(diskdb, triedb)
+	)
+	genesisRoot := commitState(t, db, common.Hash{}, 100, 1)
+	require.NoError(triedb.Commit(genesisRoot, false))
+	staleRoot := commitState(t, db, genesisRoot, 100, 2)
+	require.NoError(triedb.Commit(staleRoot, false))
+
+	p := NewOnlinePruner(diskdb, triedb, genesisRoot, OnlineConfig{BloomSize: 1})
+	defer p.Stop()
+	require.ErrorIs(p.Pause(), errOnlinePrunerNotRunning)
+	require.NoError(p.Start())
+	require.ErrorIs(p.Start(), errOnlinePrunerRunning)
+
+	// A block inserted before the start of the run cannot be the target.
+	require.NoError(p.Accept(common.Hash{1}, staleRoot))
+	require.Equal(OnlinePhaseWaiting.String(), p.Status().Phase)
+
+	// Pausing while waiting keeps the run paused once the target is accepted.
+	require.NoError(p.Pause())
+	targetRoot := commitState(t, db, staleRoot, 50, 3)
+	p.Insert(common.Hash{2})
+	require.NoError(p.Accept(common.Hash{2}, targetRoot))
+	status := p.Status()
+	require.Equal(OnlinePhaseMarking.String(), status.Phase)
+	require.True(status.Paused)
+	require.Equal(targetRoot, status.Root)
+
+	// Blocks inserted while pruning are marked by the hook.
+	tipRoot := commitState(t, db, targetRoot, 10, 4)
+	require.NoError(p.Start())
+
+	status = waitOnlinePruner(t, p)
+	require.Empty(status.Error)
+	require.False(status.Paused)
+	require.NotZero(status.Marked)
+	require.NotZero(status.Scanned)
+	require.NotZero(status.Deleted)
+
+	require.False(rawdb.HasLegacyTrieNode(diskdb, staleRoot))
+	assertStateComplete(t, diskdb, genesisRoot)
+	assertStateComplete(t, diskdb, targetRoot)
+	require.NoError(triedb.Commit(tipRoot, false))
+	assertStateComplete(t, diskdb, tipRoot)
+}
+
+func TestOnlinePrunerStop(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		diskdb = rawdb.NewMemoryDatabase()
+		triedb = trie.NewDatabase(diskdb, nil)
+		db     = state.NewDatabaseWithNodeDB(diskdb, triedb)
+	)
+	genesisRoot := commitState(t, db, common.Hash{}, 100, 1)
+	require.NoError(triedb.Commit(genesisRoot, false))
+
+	p := NewOnlinePruner(diskdb, triedb, genesisRoot, OnlineConfig{BloomSize: 1, BatchDelay: time.Hour})
+	require.NoError(p.Start())
+	targetRoot := commitState(t, db, genesisRoot, 100, 2)
+	p.Insert(common.Hash{1})
+	require.NoError(p.Accept(common.Hash{1}, targetRoot))
+
+	// Stopping interrupts the throttling delay and aborts the run.
+	p.Stop()
+	status := p.Status()
+	require.Equal(OnlinePhaseIdle.String(), status.Phase)
+	require.Empty(status.Error)
+	require.Zero(status.Deleted)
+	require.ErrorIs(p.Start(), errOnlinePrunerStopped)
+	assertStateComplete(t, diskdb, targetRoot)
+}
@@ -96,6 +96,9 @@ func NewPruner(db ethdb.Database, config Config) (*Pruner, error) {
 		return nil, errors.New("failed to load head block")
 	}
 	// Offline pruning is only supported in legacy hash based scheme.
+	if rawdb.ReadStateScheme(db) == rawdb.PathScheme {
+		return nil, errors.New("offline pruning is not supported with the path-based state scheme")
+	}
 	triedb := trie.NewDatabase(db, trie.HashDefaults)
 
 	// Note: we refuse to start a pruning session unless the snapshot disk layer exists, which should prevent
@@ -464,8 +464,10 @@ func (s *stateObject) SubBalanceMultiCoin(coinID common.Hash, amount *big.Int, d
 
 func (s *stateObject) SetBalanceMultiCoin(coinID common.Hash, amount *big.Int, db Database) {
 	s.EnableMultiCoin()
-	NormalizeCoinID(&coinID)
-	s.SetState(coinID, common.BigToHash(amount))
+	normalizedCoinID := coinID
+	NormalizeCoinID(&normalizedCoinID)
+	s.db.addMultiCoinID(normalizedCoinID, coinID)
+	s.SetState(normalizedCoinID, common.BigToHash(amount))
 }
 
 func (s *stateObject) setBalance(amount *big.Int) {
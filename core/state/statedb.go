@@ -112,9 +112,17 @@ type StateDB struct {
 	logs    map[common.Hash][]*types.Log
 	logSize uint
 
+	// Synthetic logs occurred in the scope of block, which are not part of
+	// the receipts (see AddSyntheticLog).
+	syntheticLogs []*types.Log
+
 	// Preimages occurred seen by VM in the scope of block.
 	preimages map[common.Hash][]byte
 
+	// Coin IDs of the multicoin balances set in the scope of block, by
+	// normalized coin ID (see MultiCoinIDs).
+	multiCoinIDs map[common.Hash]common.Hash
+
 	// Per-transaction access list
 	accessList *accessList
 	// Ordered storage slots to be used in predicate verification as set in the tx access list.
@@ -253,6 +261,45 @@ func (s *StateDB) AddLog(addr common.Address, topics []common.Hash, data []byte,
 	s.logSize++
 }
 
+// AddSyntheticLog adds a log which is not part of the receipt of the current
+// transaction, to the statedb. Synthetic logs are not included in receipts or
+// in the block bloom and therefore do not affect consensus.
+// The log is attributed to the current tx context.
+func (s *StateDB) AddSyntheticLog(addr common.Address, topics []common.Hash, data []byte) {
+	s.journal.append(addSyntheticLogChange{})
+	s.syntheticLogs = append(s.syntheticLogs, &types.Log{
+		Address: addr,
+		Topics:  topics,
+		Data:    data,
+		TxHash:  s.thash,
+		TxIndex: uint(s.txIndex),
+	})
+}
+
+// MultiCoinIDs returns the coin IDs of the multicoin balances set in the scope
+// of the block, by the normalized coin ID their balances are stored under.
+// Normalizing is lossy, so they are needed to recover the asset ID of a
+// multicoin balance from its storage key.
+func (s *StateDB) MultiCoinIDs() map[common.Hash]common.Hash {
+	return s.multiCoinIDs
+}
+
+// addMultiCoinID records [coinID] as the coin ID stored under [normalizedCoinID]
+func (s *StateDB) addMultiCoinID(normalizedCoinID, coinID common.Hash) {
+	if s.multiCoinIDs == nil {
+		s.multiCoinIDs = make(map[common.Hash]common.Hash)
+	}
+	if _, ok := s.multiCoinIDs[normalizedCoinID]; !ok {
+		s.multiCoinIDs[normalizedCoinID] = coinID
+	}
+}
+
+// SyntheticLogs returns the synthetic logs added in the scope of the block, in
+// the order they were added.
+func (s *StateDB) SyntheticLogs() []*types.Log {
+	return s.syntheticLogs
+}
+
 // GetLogs returns the logs matching the specified transaction hash, and annotates
 // them with the given blockNumber and blockHash.
 func (s *StateDB) GetLogs(hash common.Hash, blockNumber uint64, blockHash common.Hash) []*types.Log {
@@ -877,10 +924,20 @@ func (s *StateDB) Copy() *StateDB {
 		}
 		state.logs[hash] = cpy
 	}
+	if len(s.syntheticLogs) > 0 {
+		state.syntheticLogs = make([]*types.Log, len(s.syntheticLogs))
+		for i, l := range s.syntheticLogs {
+			state.syntheticLogs[i] = new(types.Log)
+			*state.syntheticLogs[i] = *l
+		}
+	}
 	// Deep copy the preimages occurred in the scope of block
 	for hash, preimage := range s.preimages {
 		state.preimages[hash] = preimage
 	}
+	for normalizedCoinID, coinID := range s.multiCoinIDs {
+		state.addMultiCoinID(normalizedCoinID, coinID)
+	}
 	// Do we need to copy the access list and transient storage?
 	// In practice: No. At the start of a transaction, these two lists are empty.
 	// In practice, we only ever copy state _between_ transactions/blocks, never
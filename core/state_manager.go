@@ -31,6 +31,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/Juneo-io/jeth/core/rawdb"
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -72,6 +73,11 @@ type TrieDB interface {
 }
 
 func NewTrieWriter(db TrieDB, config *CacheConfig) TrieWriter {
+	if config.StateScheme == rawdb.PathScheme {
+		// The path-based trie database maintains its own layers of
+		// in-memory state and flushes them to disk as needed.
+		return &pathTrieWriter{}
+	}
 	if config.Pruning {
 		cm := &cappedMemoryTrieWriter{
 			TrieDB:           db,
@@ -112,6 +118,17 @@ func (np *noPruningTrieWriter) RejectTrie(block *types.Block) error {
 
 func (np *noPruningTrieWriter) Shutdown() error { return nil }
 
+// pathTrieWriter is used with the path-based trie database, which caps its
+// diff layers on update. Tries of accepted blocks are persisted as the bottom
+// diff layers are flattened, and tries of rejected blocks are discarded when
+// their layers are capped, so there is nothing to do here.
+type pathTrieWriter struct{}
+
+func (*pathTrieWriter) InsertTrie(*types.Block) error { return nil }
+func (*pathTrieWriter) AcceptTrie(*types.Block) error { return nil }
+func (*pathTrieWriter) RejectTrie(*types.Block) error { return nil }
+func (*pathTrieWriter) Shutdown() error               { return nil }
+
 type cappedMemoryTrieWriter struct {
 	TrieDB
 	memoryCap        common.StorageSize
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/Juneo-io/jeth/core/rawdb"
 	"github.com/Juneo-io/jeth/core/types"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -102,3 +103,25 @@ func TestNoPruningTrieWriter(t *testing.T) {
 		m.LastDereference = common.Hash{}
 	}
 }
+
+func TestPathTrieWriter(t *testing.T) {
+	m := &MockTrieDB{}
+	w := NewTrieWriter(m, &CacheConfig{Pruning: true, CommitInterval: 1, StateScheme: rawdb.PathScheme})
+	assert := assert.New(t)
+	for i := 0; i < tipBufferSize+1; i++ {
+		bigI := big.NewInt(int64(i))
+		block := types.NewBlock(
+			&types.Header{
+				Root:   common.BigToHash(bigI),
+				Number: bigI,
+			},
+			nil, nil, nil, nil,
+		)
+
+		assert.NoError(w.InsertTrie(block))
+		assert.NoError(w.AcceptTrie(block))
+		assert.NoError(w.RejectTrie(block))
+		assert.Equal(common.Hash{}, m.LastDereference, "should not dereference tries with the path scheme")
+		assert.Equal(common.Hash{}, m.LastCommit, "should not commit tries with the path scheme")
+	}
+}
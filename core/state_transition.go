@@ -36,6 +36,7 @@ import (
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/core/vm"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contracts/txallowlist"
 	"github.com/Juneo-io/jeth/utils"
 	"github.com/Juneo-io/jeth/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
@@ -349,6 +350,13 @@ func (st *StateTransition) preCheck() error {
 		if vm.IsProhibited(msg.From) {
 			return fmt.Errorf("%w: address %v", vmerrs.ErrAddrProhibited, msg.From)
 		}
+		// Make sure the sender is allowed to issue transactions
+		if st.evm.ChainConfig().IsPrecompileEnabled(txallowlist.ContractAddress, st.evm.Context.Time) {
+			txAllowListRole := txallowlist.GetTxAllowListStatus(st.state, msg.From)
+			if !txAllowListRole.IsEnabled() {
+				return fmt.Errorf("%w: %s", vmerrs.ErrSenderAddressNotAllowListed, msg.From)
+			}
+		}
 	}
 
 	// Make sure that transaction gasFeeCap is greater than the baseFee (post london)
@@ -404,8 +404,14 @@ func (p *BlobPool) Init(gasTip *big.Int, head *types.Header, reserve txpool.Addr
 	for addr := range p.index {
 		p.recheck(addr, nil)
 	}
+	feeConfig, err := dummy.GetFeeConfig(p.chain.Config(), p.head, p.chain.StateAt)
+	if err != nil {
+		p.Close()
+		return err
+	}
 	_, baseFee, err := dummy.EstimateNextBaseFee(
 		p.chain.Config(),
+		feeConfig,
 		p.head,
 		uint64(time.Now().Unix()),
 	)
@@ -794,8 +800,14 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 	if p.chain.Config().IsCancun(p.head.Number, p.head.Time) {
 		p.limbo.finalize(p.chain.CurrentFinalBlock())
 	}
+	feeConfig, err := dummy.GetFeeConfig(p.chain.Config(), p.head, p.chain.StateAt)
+	if err != nil {
+		log.Error("Failed to read fee config to reset blobpool fees", "err", err)
+		return
+	}
 	_, baseFee, err := dummy.EstimateNextBaseFee(
 		p.chain.Config(),
+		feeConfig,
 		p.head,
 		uint64(time.Now().Unix()),
 	)
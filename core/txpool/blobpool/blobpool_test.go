@@ -134,7 +134,7 @@ func (bc *testBlockChain) CurrentBlock() *types.Header {
 			Extra:    make([]byte, params.DynamicFeeExtraDataSize),
 		}
 		_, baseFee, err := dummy.CalcBaseFee(
-			bc.config, parent, blockTime,
+			bc.config, nil, parent, blockTime,
 		)
 		if err != nil {
 			panic(err)
@@ -45,8 +45,11 @@ import (
 	"github.com/Juneo-io/jeth/core/txpool"
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/allowlist"
+	"github.com/Juneo-io/jeth/precompile/contracts/txallowlist"
 	"github.com/Juneo-io/jeth/trie"
 	"github.com/Juneo-io/jeth/utils"
+	"github.com/Juneo-io/jeth/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
@@ -380,6 +383,35 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+func TestTxAllowList(t *testing.T) {
+	t.Parallel()
+
+	config := *params.TestChainConfig
+	config.UpgradeConfig = params.UpgradeConfig{
+		PrecompileUpgrades: []params.PrecompileUpgrade{
+			{Config: txallowlist.NewConfig(utils.NewUint64(0), nil, nil, nil)},
+		},
+	}
+	pool, key := setupPoolWithConfig(&config)
+	defer pool.Close()
+
+	tx := transaction(0, 100000, key)
+	from, _ := deriveSender(tx)
+	testAddBalance(pool, from, big.NewInt(0xffffffffffffff))
+
+	// Senders without a role are rejected
+	if err, want := pool.addRemote(tx), vmerrs.ErrSenderAddressNotAllowListed; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+
+	pool.mu.Lock()
+	txallowlist.SetTxAllowListStatus(pool.currentState, from, allowlist.EnabledRole)
+	pool.mu.Unlock()
+	if err := pool.addRemote(tx); err != nil {
+		t.Error("expected", nil, "got", err)
+	}
+}
+
 func TestQueue(t *testing.T) {
 	t.Parallel()
 
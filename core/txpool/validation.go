@@ -35,6 +35,7 @@ import (
 	"github.com/Juneo-io/jeth/core/state"
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contracts/txallowlist"
 	"github.com/Juneo-io/jeth/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -224,6 +225,15 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 		return err
 	}
 
+	// If the tx allow list is enabled, only senders with at least the enabled
+	// role may submit transactions.
+	if opts.Rules.IsPrecompileEnabled(txallowlist.ContractAddress) {
+		txAllowListRole := txallowlist.GetTxAllowListStatus(opts.State, from)
+		if !txAllowListRole.IsEnabled() {
+			return fmt.Errorf("%w: %s", vmerrs.ErrSenderAddressNotAllowListed, from)
+		}
+	}
+
 	// Drop the transaction if the gas fee cap is below the pool's minimum fee
 	if opts.MinimumFee != nil && tx.GasFeeCapIntCmp(opts.MinimumFee) < 0 {
 		return fmt.Errorf("%w: address %s have gas fee cap (%d) < pool minimum fee cap (%d)", ErrUnderpriced, from.Hex(), tx.GasFeeCap(), opts.MinimumFee)
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/precompile/contract"
 	"github.com/Juneo-io/jeth/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/holiman/uint256"
 )
 
@@ -23,8 +25,42 @@ var (
 	genesisContractAddr    = common.HexToAddress("0x0100000000000000000000000000000000000000")
 	NativeAssetBalanceAddr = common.HexToAddress("0x0100000000000000000000000000000000000001")
 	NativeAssetCallAddr    = common.HexToAddress("0x0100000000000000000000000000000000000002")
+
+	// NativeAssetTransferTopic is the signature of the synthetic log recorded
+	// from [NativeAssetCallAddr] for every multicoin balance transfer:
+	// NativeAssetTransfer(address indexed from, address indexed to, bytes32 indexed assetID, uint256 amount)
+	// Imports are recorded with a zero [from] address and exports with a zero
+	// [to] address. The logs of atomic txs have the atomic tx ID as TxHash and
+	// a TxIndex following the eth txs of the block, so they do not resolve to
+	// eth txs.
+	NativeAssetTransferTopic = crypto.Keccak256Hash([]byte("NativeAssetTransfer(address,address,bytes32,uint256)"))
 )
 
+// NativeAssetTransferLog returns the synthetic NativeAssetTransfer log of a
+// transfer of [amount] of [assetID] from [from] to [to]. The tx and block
+// fields of the log are not set.
+func NativeAssetTransferLog(from, to common.Address, assetID common.Hash, amount *big.Int) *types.Log {
+	return &types.Log{
+		Address: NativeAssetCallAddr,
+		Topics: []common.Hash{
+			NativeAssetTransferTopic,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+			assetID,
+		},
+		Data: common.BigToHash(amount).Bytes(),
+	}
+}
+
+// AddNativeAssetTransferLog records the synthetic NativeAssetTransfer log for
+// a transfer of [amount] of [assetID] from [from] to [to] in [db].
+// Synthetic logs are not part of the receipts or the header bloom, so they do
+// not affect consensus, but they are returned by log filters.
+func AddNativeAssetTransferLog(db StateDB, from, to common.Address, assetID common.Hash, amount *big.Int) {
+	log := NativeAssetTransferLog(from, to, assetID, amount)
+	db.AddSyntheticLog(log.Address, log.Topics, log.Data)
+}
+
 // nativeAssetBalance is a precompiled contract used to retrieve the native asset balance
 type nativeAssetBalance struct {
 	gasCost uint64
@@ -267,6 +267,17 @@ func TestStatefulPrecompile(t *testing.T) {
 				assert.Equal(t, big0, user2Balance, "user 2 balance")
 				assert.Equal(t, expectedBalance, user1AssetBalance, "user 1 asset balance")
 				assert.Equal(t, expectedBalance, user2AssetBalance, "user 2 asset balance")
+
+				logs := stateDB.(*state.StateDB).SyntheticLogs()
+				assert.Len(t, logs, 1, "synthetic logs")
+				assert.Equal(t, NativeAssetCallAddr, logs[0].Address, "synthetic log address")
+				assert.Equal(t, []common.Hash{
+					NativeAssetTransferTopic,
+					common.BytesToHash(userAddr1.Bytes()),
+					common.BytesToHash(userAddr2.Bytes()),
+					assetID,
+				}, logs[0].Topics, "synthetic log topics")
+				assert.Equal(t, fiftyBytes, logs[0].Data, "synthetic log data")
 			},
 		},
 		{
@@ -27,6 +27,7 @@
 package vm
 
 import (
+	"fmt"
 	"math/big"
 	"sync/atomic"
 	"time"
@@ -35,6 +36,8 @@ import (
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/params"
 	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/jeth/precompile/contracts/deployerallowlist"
+	"github.com/Juneo-io/jeth/precompile/contracts/nativeassettoken"
 	"github.com/Juneo-io/jeth/precompile/modules"
 	"github.com/Juneo-io/jeth/precompile/precompileconfig"
 	"github.com/Juneo-io/jeth/predicate"
@@ -108,6 +111,11 @@ func (evm *EVM) precompile(addr common.Address) (contract.StatefulPrecompiledCon
 		return module.Contract, ok
 	}
 
+	// Native asset tokens are accessible at addresses derived from their asset IDs.
+	if config, ok := evm.chainRules.ActivePrecompiles[nativeassettoken.ContractAddress].(*nativeassettoken.Config); ok {
+		return config.TokenContract(addr)
+	}
+
 	return nil, false
 }
 
@@ -599,6 +607,13 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, common.Address{}, gas, vmerrs.ErrDepth
 	}
+	// If the deployer allow list is enabled, check that [evm.TxContext.Origin] has permission to deploy a contract.
+	if evm.chainRules.IsPrecompileEnabled(deployerallowlist.ContractAddress) {
+		allowListRole := deployerallowlist.GetContractDeployerAllowListStatus(evm.StateDB, evm.TxContext.Origin)
+		if !allowListRole.IsEnabled() {
+			return nil, common.Address{}, 0, fmt.Errorf("tx.origin %s is not authorized to deploy a contract", evm.TxContext.Origin)
+		}
+	}
 	// Note: it is not possible for a negative value to be passed in here due to the fact
 	// that [value] will be popped from the stack and decoded to a *big.Int, which will
 	// always yield a positive result.
@@ -758,6 +773,9 @@ func (evm *EVM) NativeAssetCall(caller common.Address, input []byte, suppliedGas
 	} else {
 		evm.Context.TransferMultiCoin(evm.StateDB, caller, to, assetID, assetAmount)
 	}
+	if assetAmount.Sign() != 0 {
+		AddNativeAssetTransferLog(evm.StateDB, caller, to, assetID, assetAmount)
+	}
 	ret, remainingGas, err = evm.Call(AccountRef(caller), to, callData, remainingGas, new(big.Int))
 
 	// When an error was returned by the EVM or when setting the creation code
@@ -4,10 +4,21 @@
 package vm
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/core/state"
+	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/allowlist"
+	"github.com/Juneo-io/jeth/precompile/contracts/deployerallowlist"
+	"github.com/Juneo-io/jeth/precompile/contracts/nativeassettoken"
+	"github.com/Juneo-io/jeth/utils"
+	"github.com/Juneo-io/juneogo/ids"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsProhibited(t *testing.T) {
@@ -33,3 +44,81 @@ func TestIsProhibited(t *testing.T) {
 	assert.False(t, IsProhibited(common.HexToAddress("0x0200000000000000000000000000000000000100")))
 	assert.False(t, IsProhibited(common.HexToAddress("0x0300000000000000000000000000000000000100")))
 }
+
+func TestCreateDeployerAllowList(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		deployer = common.Address{1}
+		other    = common.Address{2}
+	)
+	chainConfig := *params.TestChainConfig
+	chainConfig.UpgradeConfig = params.UpgradeConfig{
+		PrecompileUpgrades: []params.PrecompileUpgrade{
+			{Config: deployerallowlist.NewConfig(utils.NewUint64(0), nil, []common.Address{deployer}, nil)},
+		},
+	}
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+	deployerallowlist.SetContractDeployerAllowListStatus(statedb, deployer, allowlist.EnabledRole)
+
+	vmCtx := BlockContext{
+		BlockNumber: big.NewInt(0),
+		Time:        0,
+		CanTransfer: CanTransfer,
+		Transfer:    Transfer,
+	}
+
+	// Addresses without a role cannot deploy, including through a contract they called
+	evm := NewEVM(vmCtx, TxContext{Origin: other}, statedb, &chainConfig, Config{})
+	_, _, _, err = evm.Create(AccountRef(other), nil, 100_000, big.NewInt(0))
+	require.ErrorContains(err, "is not authorized to deploy a contract")
+	_, _, _, err = evm.Create2(AccountRef(deployer), nil, 100_000, big.NewInt(0), uint256.NewInt(0))
+	require.ErrorContains(err, "is not authorized to deploy a contract")
+
+	evm = NewEVM(vmCtx, TxContext{Origin: deployer}, statedb, &chainConfig, Config{})
+	_, _, _, err = evm.Create(AccountRef(deployer), nil, 100_000, big.NewInt(0))
+	require.NoError(err)
+}
+
+func TestNativeAssetTokenCall(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		owner     = common.Address{1}
+		recipient = common.Address{2}
+		assetID   = ids.ID{'a', 's', 's', 'e', 't'}
+		token     = nativeassettoken.TokenAddress(assetID)
+	)
+	chainConfig := *params.TestChainConfig
+	chainConfig.UpgradeConfig = params.UpgradeConfig{
+		PrecompileUpgrades: []params.PrecompileUpgrade{
+			{Config: nativeassettoken.NewConfig(utils.NewUint64(0), []nativeassettoken.TokenConfig{{AssetID: assetID, Symbol: "TST"}})},
+		},
+	}
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+	statedb.AddBalanceMultiCoin(owner, common.Hash(assetID), big.NewInt(1000))
+
+	vmCtx := BlockContext{
+		BlockNumber: big.NewInt(0),
+		Time:        0,
+		CanTransfer: CanTransfer,
+		Transfer:    Transfer,
+	}
+	evm := NewEVM(vmCtx, TxContext{Origin: owner}, statedb, &chainConfig, Config{})
+
+	input, err := nativeassettoken.PackTransfer(recipient, big.NewInt(400))
+	require.NoError(err)
+	_, _, err = evm.Call(AccountRef(owner), token, input, 100_000, big.NewInt(0))
+	require.NoError(err)
+	require.Equal(big.NewInt(600), statedb.GetBalanceMultiCoin(owner, common.Hash(assetID)))
+	require.Equal(big.NewInt(400), statedb.GetBalanceMultiCoin(recipient, common.Hash(assetID)))
+
+	// Tokens of assets that are not configured are not accessible
+	otherAssetID := ids.ID{'o', 't', 'h', 'e', 'r'}
+	statedb.AddBalanceMultiCoin(owner, common.Hash(otherAssetID), big.NewInt(1000))
+	_, _, err = evm.Call(AccountRef(owner), nativeassettoken.TokenAddress(otherAssetID), input, 100_000, big.NewInt(0))
+	require.NoError(err)
+	require.Equal(big.NewInt(1000), statedb.GetBalanceMultiCoin(owner, common.Hash(otherAssetID)))
+}
@@ -93,6 +93,7 @@ type StateDB interface {
 
 	AddLog(addr common.Address, topics []common.Hash, data []byte, blockNumber uint64)
 	GetLogData() (topics [][]common.Hash, data [][]byte)
+	AddSyntheticLog(addr common.Address, topics []common.Hash, data []byte)
 	GetPredicateStorageSlots(address common.Address, index int) ([]byte, bool)
 	SetPredicateStorageSlots(address common.Address, predicates [][]byte)
 
@@ -27,7 +27,26 @@
 package eth
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/core/state/snapshot"
+	"github.com/Juneo-io/jeth/rpc"
+	"github.com/Juneo-io/jeth/trie"
+	"github.com/Juneo-io/juneogo/ids"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	errSnapshotsDisabled = errors.New("state snapshots are disabled")
+	errPreimagesDisabled = errors.New("preimages must be enabled to enumerate asset balances")
 )
 
 // EthereumAPI provides an API to access Ethereum full node-related information.
@@ -49,3 +68,105 @@ func (api *EthereumAPI) Etherbase() (common.Address, error) {
 func (api *EthereumAPI) Coinbase() (common.Address, error) {
 	return api.Etherbase()
 }
+
+// AssetBalance is the balance of a native asset held by an account.
+type AssetBalance struct {
+	AssetID ids.ID       `json:"assetID"`
+	Balance *hexutil.Big `json:"balance"`
+}
+
+// AssetBalances are the multicoin balances of an account.
+type AssetBalances struct {
+	Balances []AssetBalance `json:"balances"`
+	// Incomplete is set if some non-zero storage slots of the account could
+	// not be mapped back to an asset ID, so balances may be missing.
+	Incomplete bool `json:"incomplete"`
+}
+
+// GetAssetBalances returns every non-zero multicoin balance of [address] in the
+// state of the given block, read from the state snapshot. The storage keys of
+// the balances are mapped back to asset IDs through their preimages, so the
+// node must record preimages. The slots written before preimages were enabled,
+// or fetched by state sync, have no preimage: they are skipped and the result
+// is flagged as incomplete.
+func (api *EthereumAPI) GetAssetBalances(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*AssetBalances, error) {
+	if !api.e.config.Preimages {
+		return nil, errPreimagesDisabled
+	}
+	statedb, header, err := api.e.APIBackend.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	snaps := api.e.BlockChain().Snapshots()
+	if snaps == nil {
+		return nil, errSnapshotsDisabled
+	}
+	return assetBalances(snaps, api.e.ChainDb(), statedb.Database().TrieDB(), header.Root, address)
+}
+
+// assetBalances returns the non-zero multicoin balances of [address] in the
+// snapshot of [root] which can be mapped back to their asset ID.
+func assetBalances(snaps *snapshot.Tree, db ethdb.KeyValueReader, triedb *trie.Database, root common.Hash, address common.Address) (*AssetBalances, error) {
+	snap := snaps.Snapshot(root)
+	if snap == nil {
+		return nil, fmt.Errorf("state snapshot not available for root %s", root)
+	}
+
+	accountHash := crypto.Keccak256Hash(address.Bytes())
+	account, err := snap.Account(accountHash)
+	if err != nil {
+		return nil, err
+	}
+	balances := &AssetBalances{Balances: []AssetBalance{}}
+	if account == nil || !account.IsMultiCoin {
+		return balances, nil
+	}
+
+	it, err := snaps.StorageIterator(root, accountHash, common.Hash{}, false)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	for it.Next() {
+		slot := it.Slot()
+		if len(slot) == 0 {
+			continue
+		}
+		_, content, _, err := rlp.Split(slot)
+		if err != nil {
+			return nil, err
+		}
+		balance := new(big.Int).SetBytes(content)
+		if balance.Sign() == 0 {
+			continue
+		}
+		preimage := triedb.Preimage(it.Hash())
+		if preimage == nil {
+			// Preimages are only recorded once enabled, and are not fetched
+			// by state sync. Without the key, the slot may or may not be a
+			// multicoin balance.
+			balances.Incomplete = true
+			continue
+		}
+		key := common.BytesToHash(preimage)
+		// Multicoin balances are partitioned from regular storage by the
+		// lowest bit of the key.
+		if key[0]&0x01 == 0 {
+			continue
+		}
+		// The key is the normalized asset ID, which lost the lowest bit of
+		// the asset ID, so the asset ID is read from the record of the asset
+		// IDs seen by the node.
+		assetID, ok := rawdb.ReadMultiCoinID(db, key)
+		if !ok {
+			balances.Incomplete = true
+			continue
+		}
+		balances.Balances = append(balances.Balances, AssetBalance{
+			AssetID: ids.ID(assetID),
+			Balance: (*hexutil.Big)(balance),
+		})
+	}
+	return balances, it.Error()
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/Juneo-io/jeth/eth/gasprice"
 	"github.com/Juneo-io/jeth/eth/tracers"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contracts/feemanager"
 	"github.com/Juneo-io/jeth/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -506,6 +507,10 @@ func (b *EthAPIBackend) MinRequiredTip(ctx context.Context, header *types.Header
 	return dummy.MinRequiredTip(b.ChainConfig(), header)
 }
 
+func (b *EthAPIBackend) GetFeeConfigAt(parent *types.Header) (*feemanager.FeeConfig, error) {
+	return b.eth.blockchain.GetFeeConfigAt(parent)
+}
+
 func (b *EthAPIBackend) isLatestAndAllowed(number rpc.BlockNumber) bool {
 	return number.IsLatest() && b.IsAllowUnfinalizedQueries()
 }
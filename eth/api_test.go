@@ -0,0 +1,99 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Juneo-io/jeth/core"
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/core/state/snapshot"
+	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/rpc"
+	"github.com/Juneo-io/jeth/trie"
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetBalances(t *testing.T) {
+	var (
+		addr      = common.Address{1}
+		otherAddr = common.Address{2}
+		// The lowest bit of the first byte of [assetA] is set when its
+		// balances are stored, so it must be recovered from the record of
+		// asset IDs.
+		assetA = common.Hash{0x02, 1}
+		assetB = common.Hash{0x03, 2}
+	)
+	gspec := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: core.GenesisAlloc{
+			addr: {
+				Balance: big.NewInt(1),
+				MCBalance: core.GenesisMultiCoinBalance{
+					assetA: big.NewInt(10),
+					assetB: big.NewInt(20),
+				},
+			},
+			otherAddr: {Balance: big.NewInt(1)},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		preimages          bool
+		addr               common.Address
+		expected           []AssetBalance
+		expectedIncomplete bool
+	}{
+		{
+			name:      "two assets",
+			preimages: true,
+			addr:      addr,
+			expected: []AssetBalance{
+				{AssetID: ids.ID(assetA), Balance: (*hexutil.Big)(big.NewInt(10))},
+				{AssetID: ids.ID(assetB), Balance: (*hexutil.Big)(big.NewInt(20))},
+			},
+		},
+		{
+			name:      "no multicoin balances",
+			preimages: true,
+			addr:      otherAddr,
+			expected:  []AssetBalance{},
+		},
+		{
+			name:               "missing preimages",
+			preimages:          false,
+			addr:               addr,
+			expected:           []AssetBalance{},
+			expectedIncomplete: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := rawdb.NewMemoryDatabase()
+			triedb := trie.NewDatabase(db, &trie.Config{Preimages: test.preimages})
+			block := gspec.MustCommit(db, triedb)
+			snaps, err := snapshot.New(snapshot.Config{CacheSize: 16}, db, triedb, block.Hash(), block.Root())
+			require.NoError(err)
+
+			balances, err := assetBalances(snaps, db, triedb, block.Root(), test.addr)
+			require.NoError(err)
+			require.ElementsMatch(test.expected, balances.Balances)
+			require.Equal(test.expectedIncomplete, balances.Incomplete)
+		})
+	}
+}
+
+func TestAssetBalancesPreimagesDisabled(t *testing.T) {
+	api := NewEthereumAPI(&Ethereum{config: &Config{Preimages: false}})
+	_, err := api.GetAssetBalances(context.Background(), common.Address{1}, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	require.ErrorIs(t, err, errPreimagesDisabled)
+}
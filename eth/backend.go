@@ -219,6 +219,8 @@ func New(
 			SkipTxIndexing:                  config.SkipTxIndexing,
 			StateHistory:                    config.StateHistory,
 			StateScheme:                     config.StateScheme,
+			OnlinePruningBloomSize:          config.OnlinePruningBloomFilterSize,
+			OnlinePruningBatchDelay:         config.OnlinePruningBatchDelay,
 		}
 	)
 
@@ -427,6 +429,9 @@ func (s *Ethereum) handleOfflinePruning(cacheConfig *core.CacheConfig, gspec *co
 	if s.config.OfflinePruning && !s.config.Pruning {
 		return core.ErrRefuseToCorruptArchiver
 	}
+	if s.config.OfflinePruning && s.config.StateScheme == rawdb.PathScheme {
+		return fmt.Errorf("offline pruning is not supported with the %s state scheme", rawdb.PathScheme)
+	}
 
 	if !s.config.OfflinePruning {
 		// Delete the offline pruning marker to indicate that the node started with offline pruning disabled.
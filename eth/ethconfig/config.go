@@ -152,6 +152,11 @@ type Config struct {
 	OfflinePruningBloomFilterSize uint64
 	OfflinePruningDataDirectory   string
 
+	// OnlinePruningBloomFilterSize is the size (MB) of the bloom filter used to
+	// prune stale state in the background when requested, disabled if 0.
+	OnlinePruningBloomFilterSize uint64
+	OnlinePruningBatchDelay      time.Duration
+
 	// SkipUpgradeCheck disables checking that upgrades must take place before the last
 	// accepted block. Skipping this check is useful when a node operator does not update
 	// their node before the network upgrade and their node accepts blocks that have
@@ -33,6 +33,7 @@ import (
 	"math/big"
 
 	"github.com/Juneo-io/jeth/core/bloombits"
+	"github.com/Juneo-io/jeth/core/rawdb"
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/rpc"
 	"github.com/ethereum/go-ethereum/common"
@@ -326,8 +327,11 @@ func (f *Filter) unindexedLogs(ctx context.Context, end uint64, logChan chan *ty
 }
 
 // blockLogs returns the logs matching the filter criteria within a single block.
+// The bloom of the block's synthetic logs, which is not part of the header, is
+// merged in, as the bloombits index does.
 func (f *Filter) blockLogs(ctx context.Context, header *types.Header) ([]*types.Log, error) {
-	if bloomFilter(header.Bloom, f.addresses, f.topics) {
+	bloom := rawdb.ReadBloomWithSyntheticLogs(f.sys.backend.ChainDb(), header)
+	if bloomFilter(bloom, f.addresses, f.topics) {
 		return f.checkMatches(ctx, header)
 	}
 	return nil, nil
@@ -35,6 +35,7 @@ import (
 	"github.com/Juneo-io/jeth/core"
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contracts/feemanager"
 	"github.com/Juneo-io/jeth/rpc"
 	"github.com/Juneo-io/juneogo/utils/timer/mockable"
 	"github.com/ethereum/go-ethereum/common"
@@ -99,6 +100,7 @@ type OracleBackend interface {
 	SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) event.Subscription
 	MinRequiredTip(ctx context.Context, header *types.Header) (*big.Int, error)
 	LastAcceptedBlock() *types.Block
+	GetFeeConfigAt(parent *types.Header) (*feemanager.FeeConfig, error)
 }
 
 // Oracle recommends gas prices based on the content of recent
@@ -253,7 +255,11 @@ func (oracle *Oracle) estimateNextBaseFee(ctx context.Context) (*big.Int, error)
 	// If the block does have a baseFee, calculate the next base fee
 	// based on the current time and add it to the tip to estimate the
 	// total gas price estimate.
-	_, nextBaseFee, err := dummy.EstimateNextBaseFee(oracle.backend.ChainConfig(), header, oracle.clock.Unix())
+	feeConfig, err := oracle.backend.GetFeeConfigAt(header)
+	if err != nil {
+		return nil, err
+	}
+	_, nextBaseFee, err := dummy.EstimateNextBaseFee(oracle.backend.ChainConfig(), feeConfig, header, oracle.clock.Unix())
 	return nextBaseFee, err
 }
 
@@ -39,6 +39,7 @@ import (
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/core/vm"
 	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contracts/feemanager"
 	"github.com/Juneo-io/jeth/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -154,6 +155,10 @@ func (b *testBackend) MinRequiredTip(ctx context.Context, header *types.Header)
 	return dummy.MinRequiredTip(b.chain.Config(), header)
 }
 
+func (b *testBackend) GetFeeConfigAt(parent *types.Header) (*feemanager.FeeConfig, error) {
+	return b.chain.GetFeeConfigAt(parent)
+}
+
 func (b *testBackend) CurrentHeader() *types.Header {
 	return b.chain.CurrentHeader()
 }
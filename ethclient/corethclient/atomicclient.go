@@ -0,0 +1,82 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package corethclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Juneo-io/jeth/rpc"
+	"github.com/Juneo-io/juneogo/api"
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/Juneo-io/juneogo/utils/json"
+)
+
+// Statuses of an atomic tx, as returned by AtomicTxStatus
+const (
+	AtomicTxUnknown    = "Unknown"
+	AtomicTxDropped    = "Dropped"
+	AtomicTxProcessing = "Processing"
+	AtomicTxAccepted   = "Accepted"
+)
+
+// ErrAtomicTxDropped is returned by AwaitAtomicTxAccepted when the atomic tx
+// was dropped from the mempool.
+var ErrAtomicTxDropped = errors.New("atomic tx was dropped")
+
+// AtomicClient is a wrapper around rpc.Client for the atomic tx API, which is
+// served on the june endpoint of the chain (/ext/bc/<chain>/june) rather than
+// with the eth RPC API.
+type AtomicClient struct {
+	c *rpc.Client
+}
+
+// NewAtomicClient creates a client that uses the given RPC client, which must
+// be dialed to the june endpoint of the chain.
+func NewAtomicClient(c *rpc.Client) *AtomicClient {
+	return &AtomicClient{c}
+}
+
+// AtomicTxStatus is the status of an atomic tx
+type AtomicTxStatus struct {
+	Status string `json:"status"`
+	// BlockHeight is the height of the block containing the tx once accepted
+	BlockHeight *json.Uint64 `json:"blockHeight,omitempty"`
+}
+
+// AtomicTxStatus returns the status of the atomic tx [txID].
+func (ac *AtomicClient) AtomicTxStatus(ctx context.Context, txID ids.ID) (*AtomicTxStatus, error) {
+	var result AtomicTxStatus
+	err := ac.c.CallContext(ctx, &result, "june.getAtomicTxStatus", &api.JSONTxID{TxID: txID})
+	return &result, err
+}
+
+// AwaitAtomicTxAccepted polls the status of the atomic tx [txID] every [freq]
+// until it is accepted, and returns its final status. It returns
+// ErrAtomicTxDropped if the tx is dropped, and the context error if [ctx] is
+// done first.
+func (ac *AtomicClient) AwaitAtomicTxAccepted(ctx context.Context, txID ids.ID, freq time.Duration) (*AtomicTxStatus, error) {
+	ticker := time.NewTicker(freq)
+	defer ticker.Stop()
+
+	for {
+		status, err := ac.AtomicTxStatus(ctx, txID)
+		if err != nil {
+			return nil, err
+		}
+		switch status.Status {
+		case AtomicTxAccepted:
+			return status, nil
+		case AtomicTxDropped:
+			return status, ErrAtomicTxDropped
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return status, ctx.Err()
+		}
+	}
+}
@@ -34,9 +34,11 @@ import (
 	"runtime/debug"
 
 	"github.com/Juneo-io/jeth/core/types"
+	"github.com/Juneo-io/jeth/core/vm"
 	"github.com/Juneo-io/jeth/ethclient"
 	"github.com/Juneo-io/jeth/interfaces"
 	"github.com/Juneo-io/jeth/rpc"
+	"github.com/Juneo-io/juneogo/ids"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -180,6 +182,32 @@ func (ec *Client) SubscribePendingTransactions(ctx context.Context, ch chan<- co
 	return ec.c.EthSubscribe(ctx, ch, "newPendingTransactions")
 }
 
+// AssetBalanceAt returns the [assetID] balance of the given account.
+// The block number can be nil, in which case the balance is taken from the latest known block.
+func (ec *Client) AssetBalanceAt(ctx context.Context, account common.Address, assetID ids.ID, blockNumber *big.Int) (*big.Int, error) {
+	var result hexutil.Big
+	err := ec.c.CallContext(ctx, &result, "eth_getAssetBalance", account, ethclient.ToBlockNumArg(blockNumber), assetID)
+	return (*big.Int)(&result), err
+}
+
+// NativeAssetCallData returns the input of a call to the native asset call
+// precompile, transferring [amount] of [assetID] to [to] and calling it with [data].
+func NativeAssetCallData(to common.Address, assetID ids.ID, amount *big.Int, data []byte) []byte {
+	return vm.PackNativeAssetCallInput(to, common.Hash(assetID), amount, data)
+}
+
+// NativeAssetCallMsg returns a message from [from] calling the native asset
+// call precompile, transferring [amount] of [assetID] to [to] and calling it
+// with [data]. The message can be used with CallContract or to estimate gas.
+func NativeAssetCallMsg(from common.Address, to common.Address, assetID ids.ID, amount *big.Int, data []byte) interfaces.CallMsg {
+	precompileAddr := vm.NativeAssetCallAddr
+	return interfaces.CallMsg{
+		From: from,
+		To:   &precompileAddr,
+		Data: NativeAssetCallData(to, assetID, amount, data),
+	}
+}
+
 func toCallArg(msg interfaces.CallMsg) interface{} {
 	arg := map[string]interface{}{
 		"from": msg.From,
@@ -0,0 +1,174 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package corethclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Juneo-io/jeth/core/vm"
+	"github.com/Juneo-io/jeth/rpc"
+	"github.com/Juneo-io/juneogo/api"
+	"github.com/Juneo-io/juneogo/ids"
+	avalancheJSON "github.com/Juneo-io/juneogo/utils/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	avalancheRPC "github.com/gorilla/rpc/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// testEthService serves the asset balance of a single account
+type testEthService struct {
+	account common.Address
+	assetID ids.ID
+	balance *big.Int
+}
+
+func (s *testEthService) GetAssetBalance(_ context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash, assetID ids.ID) (*hexutil.Big, error) {
+	if number, ok := blockNrOrHash.Number(); !ok || number != rpc.LatestBlockNumber {
+		return nil, errors.New("unexpected block")
+	}
+	if address != s.account || assetID != s.assetID {
+		return (*hexutil.Big)(new(big.Int)), nil
+	}
+	return (*hexutil.Big)(s.balance), nil
+}
+
+func TestAssetBalanceAt(t *testing.T) {
+	require := require.New(t)
+
+	service := &testEthService{
+		account: common.Address{1},
+		assetID: ids.GenerateTestID(),
+		balance: big.NewInt(1234),
+	}
+	server := rpc.NewServer(0)
+	defer server.Stop()
+	require.NoError(server.RegisterName("eth", service))
+	client := New(rpc.DialInProc(server))
+	defer client.c.Close()
+
+	balance, err := client.AssetBalanceAt(context.Background(), service.account, service.assetID, nil)
+	require.NoError(err)
+	require.Equal(service.balance, balance)
+
+	balance, err = client.AssetBalanceAt(context.Background(), service.account, ids.GenerateTestID(), nil)
+	require.NoError(err)
+	require.Zero(balance.Sign())
+
+	_, err = client.AssetBalanceAt(context.Background(), service.account, service.assetID, big.NewInt(1))
+	require.ErrorContains(err, "unexpected block")
+}
+
+func TestNativeAssetCallMsg(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		from    = common.Address{1}
+		to      = common.Address{2}
+		assetID = ids.GenerateTestID()
+		amount  = big.NewInt(100)
+		data    = []byte{1, 2, 3}
+	)
+	msg := NativeAssetCallMsg(from, to, assetID, amount, data)
+	require.Equal(from, msg.From)
+	require.Equal(vm.NativeAssetCallAddr, *msg.To)
+
+	gotTo, gotAssetID, gotAmount, gotData, err := vm.UnpackNativeAssetCallInput(msg.Data)
+	require.NoError(err)
+	require.Equal(to, gotTo)
+	require.Equal(common.Hash(assetID), gotAssetID)
+	require.Equal(amount, gotAmount)
+	require.Equal(data, gotData)
+}
+
+// testAtomicService serves [statuses] in order, then the last one
+type testAtomicService struct {
+	txID     ids.ID
+	statuses []AtomicTxStatus
+	calls    int
+}
+
+func (s *testAtomicService) GetAtomicTxStatus(_ *http.Request, args *api.JSONTxID, reply *AtomicTxStatus) error {
+	if args.TxID != s.txID {
+		*reply = AtomicTxStatus{Status: AtomicTxUnknown}
+		return nil
+	}
+	*reply = s.statuses[min(s.calls, len(s.statuses)-1)]
+	s.calls++
+	return nil
+}
+
+func newTestAtomicClient(t *testing.T, service *testAtomicService) *AtomicClient {
+	server := avalancheRPC.NewServer()
+	server.RegisterCodec(avalancheJSON.NewCodec(), "application/json")
+	require.NoError(t, server.RegisterService(service, "june"))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	c, err := rpc.DialHTTP(httpServer.URL)
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	return NewAtomicClient(c)
+}
+
+func TestAwaitAtomicTxAccepted(t *testing.T) {
+	require := require.New(t)
+
+	height := avalancheJSON.Uint64(5)
+	service := &testAtomicService{
+		txID: ids.GenerateTestID(),
+		statuses: []AtomicTxStatus{
+			{Status: AtomicTxProcessing},
+			{Status: AtomicTxProcessing},
+			{Status: AtomicTxAccepted, BlockHeight: &height},
+		},
+	}
+	client := newTestAtomicClient(t, service)
+
+	status, err := client.AtomicTxStatus(context.Background(), ids.GenerateTestID())
+	require.NoError(err)
+	require.Equal(AtomicTxUnknown, status.Status)
+
+	status, err = client.AwaitAtomicTxAccepted(context.Background(), service.txID, time.Millisecond)
+	require.NoError(err)
+	require.Equal(AtomicTxAccepted, status.Status)
+	require.Equal(height, *status.BlockHeight)
+	require.Equal(3, service.calls)
+}
+
+func TestAwaitAtomicTxAcceptedDropped(t *testing.T) {
+	require := require.New(t)
+
+	service := &testAtomicService{
+		txID: ids.GenerateTestID(),
+		statuses: []AtomicTxStatus{
+			{Status: AtomicTxProcessing},
+			{Status: AtomicTxDropped},
+		},
+	}
+	client := newTestAtomicClient(t, service)
+
+	status, err := client.AwaitAtomicTxAccepted(context.Background(), service.txID, time.Millisecond)
+	require.ErrorIs(err, ErrAtomicTxDropped)
+	require.Equal(AtomicTxDropped, status.Status)
+}
+
+func TestAwaitAtomicTxAcceptedContextDone(t *testing.T) {
+	service := &testAtomicService{
+		txID:     ids.GenerateTestID(),
+		statuses: []AtomicTxStatus{{Status: AtomicTxProcessing}},
+	}
+	client := newTestAtomicClient(t, service)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.AwaitAtomicTxAccepted(ctx, service.txID, time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -165,8 +165,11 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 
 	// Set BaseFee and Extra data field if we are post ApricotPhase3
 	if w.chainConfig.IsApricotPhase3(timestamp) {
-		var err error
-		header.Extra, header.BaseFee, err = dummy.CalcBaseFee(w.chainConfig, parent, timestamp)
+		feeConfig, err := w.chain.GetFeeConfigAt(parent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get fee config at parent: %w", err)
+		}
+		header.Extra, header.BaseFee, err = dummy.CalcBaseFee(w.chainConfig, feeConfig, parent, timestamp)
 		if err != nil {
 			return nil, fmt.Errorf("failed to calculate new base fee: %w", err)
 		}
@@ -16,10 +16,39 @@ import (
 // - Timestamps that enable avalanche network upgrades,
 // - Enabling or disabling precompiles as network upgrades.
 type UpgradeConfig struct {
+	// Config for overriding the timestamps of the network upgrades set in genesis.
+	NetworkUpgradeOverrides *NetworkUpgradeOverrides `json:"networkUpgradeOverrides,omitempty"`
+
 	// Config for enabling and disabling precompiles as network upgrades.
 	PrecompileUpgrades []PrecompileUpgrade `json:"precompileUpgrades,omitempty"`
 }
 
+// NetworkUpgradeOverrides contains the timestamps of the network upgrades that
+// may be rescheduled without changing genesis. Only the timestamps that are set
+// replace the ones of the chain config.
+type NetworkUpgradeOverrides struct {
+	DurangoBlockTimestamp    *uint64 `json:"durangoBlockTimestamp,omitempty"`
+	FeeUpdate1BlockTimestamp *uint64 `json:"feeUpdate1BlockTimestamp,omitempty"`
+	CancunTime               *uint64 `json:"cancunTime,omitempty"`
+}
+
+// ApplyNetworkUpgradeOverrides replaces the timestamps of the network upgrades
+// of [c] with the ones set in [overrides].
+func (c *ChainConfig) ApplyNetworkUpgradeOverrides(overrides *NetworkUpgradeOverrides) {
+	if overrides == nil {
+		return
+	}
+	if overrides.DurangoBlockTimestamp != nil {
+		c.DurangoBlockTimestamp = utils.NewUint64(*overrides.DurangoBlockTimestamp)
+	}
+	if overrides.FeeUpdate1BlockTimestamp != nil {
+		c.FeeUpdate1BlockTimestamp = utils.NewUint64(*overrides.FeeUpdate1BlockTimestamp)
+	}
+	if overrides.CancunTime != nil {
+		c.CancunTime = utils.NewUint64(*overrides.CancunTime)
+	}
+}
+
 // AvalancheContext provides Avalanche specific context directly into the EVM.
 type AvalancheContext struct {
 	SnowCtx *snow.Context
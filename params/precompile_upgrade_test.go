@@ -0,0 +1,93 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"testing"
+
+	"github.com/Juneo-io/jeth/precompile/contracts/deployerallowlist"
+	"github.com/Juneo-io/jeth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCompatibleWithPrecompileUpgrades(t *testing.T) {
+	admins := []common.Address{{1}}
+	withUpgrades := func(upgrades ...PrecompileUpgrade) *ChainConfig {
+		config := *TestChainConfig
+		config.UpgradeConfig = UpgradeConfig{PrecompileUpgrades: upgrades}
+		return &config
+	}
+	enableAt := func(timestamp uint64) PrecompileUpgrade {
+		return PrecompileUpgrade{Config: deployerallowlist.NewConfig(utils.NewUint64(timestamp), admins, nil, nil)}
+	}
+	disableAt := func(timestamp uint64) PrecompileUpgrade {
+		return PrecompileUpgrade{Config: deployerallowlist.NewDisableConfig(utils.NewUint64(timestamp))}
+	}
+
+	tests := map[string]struct {
+		stored, new   *ChainConfig
+		headTimestamp uint64
+		expectedErr   string
+	}{
+		"identical upgrades": {
+			stored:        withUpgrades(enableAt(10)),
+			new:           withUpgrades(enableAt(10)),
+			headTimestamp: 20,
+		},
+		"reschedule pending upgrade": {
+			stored:        withUpgrades(enableAt(10)),
+			new:           withUpgrades(enableAt(30)),
+			headTimestamp: 5,
+		},
+		"add pending upgrade": {
+			stored:        withUpgrades(enableAt(10)),
+			new:           withUpgrades(enableAt(10), disableAt(30)),
+			headTimestamp: 20,
+		},
+		"reschedule activated upgrade": {
+			stored:        withUpgrades(enableAt(10)),
+			new:           withUpgrades(enableAt(15)),
+			headTimestamp: 20,
+			expectedErr:   "PrecompileUpgrade[0]",
+		},
+		"remove activated upgrade": {
+			stored:        withUpgrades(enableAt(10)),
+			new:           withUpgrades(),
+			headTimestamp: 20,
+			expectedErr:   "missing PrecompileUpgrade[0]",
+		},
+		"retroactive upgrade": {
+			stored:        withUpgrades(),
+			new:           withUpgrades(enableAt(10)),
+			headTimestamp: 20,
+			expectedErr:   "cannot retroactively enable PrecompileUpgrade[0]",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.stored.CheckCompatible(test.new, 0, test.headTimestamp)
+			if test.expectedErr == "" {
+				require.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			require.Contains(t, err.What, test.expectedErr)
+		})
+	}
+}
+
+func TestApplyNetworkUpgradeOverrides(t *testing.T) {
+	config := *TestChainConfig
+	config.ApplyNetworkUpgradeOverrides(&NetworkUpgradeOverrides{
+		DurangoBlockTimestamp: utils.NewUint64(100),
+	})
+	require.Equal(t, utils.NewUint64(100), config.DurangoBlockTimestamp)
+	// Timestamps that are not overridden are left unchanged
+	require.Equal(t, TestChainConfig.CortinaBlockTimestamp, config.CortinaBlockTimestamp)
+	require.Equal(t, TestChainConfig.FeeUpdate1BlockTimestamp, config.FeeUpdate1BlockTimestamp)
+
+	config.ApplyNetworkUpgradeOverrides(nil)
+	require.Equal(t, utils.NewUint64(100), config.DurangoBlockTimestamp)
+}
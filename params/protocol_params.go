@@ -195,6 +195,21 @@ const (
 	AssetCallApricot uint64 = 20000
 )
 
+const (
+	// BLS verifier stateful precompile params, based on the EIP-2537 prices of the
+	// BLS12-381 operations involved.
+	// Gas price for verifying a BLS signature: mapping the message to G2 and a pairing check of 2 pairs.
+	BLSVerifySignatureGas uint64 = Bls12381MapG2Gas + Bls12381PairingBaseGas + 2*Bls12381PairingPerPairGas
+	// Gas price for verifying a proof of possession: mapping the public key to G2 and a pairing check of 2 pairs.
+	BLSVerifyProofOfPossessionGas uint64 = Bls12381MapG2Gas + Bls12381PairingBaseGas + 2*Bls12381PairingPerPairGas
+	// Gas price per word of the input, covering the decoding of the arguments and the hashing of the message.
+	BLSInputPerWordGas uint64 = Sha256PerWordGas
+	// Gas price per aggregated public key: validating the key and a G1 point addition.
+	BLSAggregatePublicKeyGas uint64 = Bls12381G1MulGas + Bls12381G1AddGas
+	// Gas price per aggregated signature: validating the signature and a G2 point addition.
+	BLSAggregateSignatureGas uint64 = Bls12381G2MulGas + Bls12381G2AddGas
+)
+
 // Gas discount table for BLS12-381 G1 and G2 multi exponentiation operations
 var Bls12381MultiExpDiscountTable = [128]uint64{1200, 888, 764, 641, 594, 547, 500, 453, 438, 423, 408, 394, 379, 364, 349, 334, 330, 326, 322, 318, 314, 310, 306, 302, 298, 294, 289, 285, 281, 277, 273, 269, 268, 266, 265, 263, 262, 260, 259, 257, 256, 254, 253, 251, 250, 248, 247, 245, 244, 242, 241, 239, 238, 236, 235, 233, 232, 231, 229, 228, 226, 225, 223, 222, 221, 220, 219, 219, 218, 217, 216, 216, 215, 214, 213, 213, 212, 211, 211, 210, 209, 208, 208, 207, 206, 205, 205, 204, 203, 202, 202, 201, 200, 199, 199, 198, 197, 196, 196, 195, 194, 193, 193, 192, 191, 191, 190, 189, 188, 188, 187, 186, 185, 185, 184, 183, 182, 182, 181, 180, 179, 179, 178, 177, 176, 176, 175, 174}
 
@@ -7,8 +7,15 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/Juneo-io/jeth/core/state/pruner"
+	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/warp"
 	"github.com/Juneo-io/juneogo/api"
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/Juneo-io/juneogo/utils/json"
 	"github.com/Juneo-io/juneogo/utils/profiler"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -89,3 +96,156 @@ func (p *Admin) GetVMConfig(_ *http.Request, _ *struct{}, reply *ConfigReply) er
 	reply.Config = &p.vm.config
 	return nil
 }
+
+// DropMempoolTx removes a pending atomic tx from the mempool
+func (p *Admin) DropMempoolTx(_ *http.Request, args *api.JSONTxID, _ *api.EmptyReply) error {
+	log.Info("Admin: DropMempoolTx called", "txID", args.TxID)
+
+	if args.TxID == ids.Empty {
+		return errNilTxID
+	}
+	return p.vm.mempool.DropPendingTx(args.TxID, "dropped by admin")
+}
+
+type AddWarpOffChainMessageArgs struct {
+	Message hexutil.Bytes `json:"message"`
+}
+
+type AddWarpOffChainMessageReply struct {
+	MessageID ids.ID `json:"messageID"`
+}
+
+// AddWarpOffChainMessage persists an off-chain AddressedCall message the node
+// is willing to sign. The message is validated like the off-chain messages of
+// the config.
+func (p *Admin) AddWarpOffChainMessage(_ *http.Request, args *AddWarpOffChainMessageArgs, reply *AddWarpOffChainMessageReply) error {
+	log.Info("Admin: AddWarpOffChainMessage called")
+
+	messageID, err := p.vm.warpBackend.AddOffChainMessage(args.Message)
+	if err != nil {
+		return fmt.Errorf("failed to add off-chain message: %w", err)
+	}
+	reply.MessageID = messageID
+	return nil
+}
+
+type ListWarpOffChainMessagesReply struct {
+	Messages []warp.OffChainMessage `json:"messages"`
+}
+
+// ListWarpOffChainMessages returns the off-chain messages the node is willing
+// to sign, including the ones of the config.
+func (p *Admin) ListWarpOffChainMessages(_ *http.Request, _ *struct{}, reply *ListWarpOffChainMessagesReply) error {
+	log.Info("Admin: ListWarpOffChainMessages called")
+
+	reply.Messages = p.vm.warpBackend.GetOffChainMessages()
+	return nil
+}
+
+type RevokeWarpOffChainMessageArgs struct {
+	MessageID ids.ID `json:"messageID"`
+}
+
+// RevokeWarpOffChainMessage removes an off-chain message added with
+// AddWarpOffChainMessage, so the node no longer signs it.
+func (p *Admin) RevokeWarpOffChainMessage(_ *http.Request, args *RevokeWarpOffChainMessageArgs, _ *api.EmptyReply) error {
+	log.Info("Admin: RevokeWarpOffChainMessage called", "messageID", args.MessageID)
+
+	return p.vm.warpBackend.RevokeOffChainMessage(args.MessageID)
+}
+
+type ReloadUpgradeConfigReply struct {
+	UpgradeConfig *params.UpgradeConfig `json:"upgradeConfig"`
+}
+
+// ReloadUpgradeConfig reads the precompile upgrade file again and applies it if
+// it is compatible with the accepted and processing blocks.
+func (p *Admin) ReloadUpgradeConfig(_ *http.Request, _ *struct{}, reply *ReloadUpgradeConfigReply) error {
+	log.Info("Admin: ReloadUpgradeConfig called")
+
+	p.vm.ctx.Lock.Lock()
+	defer p.vm.ctx.Lock.Unlock()
+
+	upgradeConfig, err := p.vm.reloadUpgradeConfig()
+	if err != nil {
+		return fmt.Errorf("failed to reload upgrade config: %w", err)
+	}
+	reply.UpgradeConfig = upgradeConfig
+	return nil
+}
+
+// StartOnlinePruning starts deleting the stale state in the background, or
+// resumes it if paused.
+func (p *Admin) StartOnlinePruning(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	log.Info("Admin: StartOnlinePruning called")
+
+	p.vm.ctx.Lock.Lock()
+	defer p.vm.ctx.Lock.Unlock()
+
+	// The state exported to a state file must not be deleted while it's read.
+	if p.vm.stateFileExports > 0 {
+		return errOnlinePruningExporting
+	}
+	return p.vm.blockChain.StartOnlinePruning()
+}
+
+// PauseOnlinePruning pauses the background deletion of the stale state.
+func (p *Admin) PauseOnlinePruning(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	log.Info("Admin: PauseOnlinePruning called")
+
+	p.vm.ctx.Lock.Lock()
+	defer p.vm.ctx.Lock.Unlock()
+
+	return p.vm.blockChain.PauseOnlinePruning()
+}
+
+type OnlinePruningStatusReply struct {
+	pruner.OnlineStatus
+}
+
+// OnlinePruningStatus returns the progress of the current, or last, run of
+// the online pruner.
+func (p *Admin) OnlinePruningStatus(_ *http.Request, _ *struct{}, reply *OnlinePruningStatusReply) error {
+	log.Info("Admin: OnlinePruningStatus called")
+
+	status, err := p.vm.blockChain.OnlinePruningStatus()
+	if err != nil {
+		return err
+	}
+	reply.OnlineStatus = status
+	return nil
+}
+
+type ExportStateFileArgs struct {
+	Path   string      `json:"path"`
+	Height json.Uint64 `json:"height"`
+}
+
+type ExportStateFileReply struct {
+	BlockNumber json.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash `json:"blockHash"`
+	BlockRoot   common.Hash `json:"blockRoot"`
+	AtomicRoot  common.Hash `json:"atomicRoot"`
+	Checksum    common.Hash `json:"checksum"`
+}
+
+// ExportStateFile writes the state at the accepted state summary at the given
+// height, or at the last state summary if the height is 0, to a state file
+// that nodes can bootstrap from with the state-sync-file config option.
+func (p *Admin) ExportStateFile(r *http.Request, args *ExportStateFileArgs, reply *ExportStateFileReply) error {
+	log.Info("Admin: ExportStateFile called", "path", args.Path, "height", uint64(args.Height))
+
+	if args.Path == "" {
+		return fmt.Errorf("path must be specified")
+	}
+	summary, checksum, err := p.vm.exportStateFile(r.Context(), args.Path, uint64(args.Height))
+	if err != nil {
+		return err
+	}
+	reply.BlockNumber = json.Uint64(summary.BlockNumber)
+	reply.BlockHash = summary.BlockHash
+	reply.BlockRoot = summary.BlockRoot
+	reply.AtomicRoot = summary.AtomicRoot
+	reply.Checksum = checksum
+	return nil
+}
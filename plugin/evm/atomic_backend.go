@@ -17,7 +17,9 @@ import (
 	"github.com/Juneo-io/juneogo/utils/wrappers"
 	"github.com/Juneo-io/jeth/core/types"
 	syncclient "github.com/Juneo-io/jeth/sync/client"
+	"github.com/Juneo-io/jeth/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -56,15 +58,32 @@ type AtomicBackend interface {
 	// will not have been executed on shared memory.
 	MarkApplyToSharedMemoryCursor(previousLastAcceptedHeight uint64) error
 
-	// Syncer creates and returns a new Syncer object that can be used to sync the
+	// Syncer creates and returns a new AtomicSyncer object that can be used to sync the
 	// state of the atomic trie from peers
-	Syncer(client syncclient.LeafClient, targetRoot common.Hash, targetHeight uint64, requestSize uint16) (Syncer, error)
+	Syncer(client syncclient.LeafClient, targetRoot common.Hash, targetHeight uint64, requestSize uint16) (AtomicSyncer, error)
+
+	// Importer creates and returns a new AtomicTrieImporter object that can be
+	// used to import the state of the atomic trie from a state file
+	Importer(targetRoot common.Hash, targetHeight uint64) (AtomicTrieImporter, error)
 
 	// SetLastAccepted is used after state-sync to reset the last accepted block.
 	SetLastAccepted(lastAcceptedHash common.Hash)
 
 	// IsBonus returns true if the block for atomicState is a bonus block
 	IsBonus(blockHeight uint64, blockHash common.Hash) bool
+
+	// SubscribeAcceptedAtomicTxsEvent registers a subscription for the atomic
+	// txs of every accepted block containing atomic txs. Events are dropped
+	// if [ch] is full, so the accept path is never blocked by a subscriber.
+	SubscribeAcceptedAtomicTxsEvent(ch chan<- AcceptedAtomicTxsEvent) event.Subscription
+}
+
+// AcceptedAtomicTxsEvent is posted when a block containing atomic txs
+// is accepted and its atomic ops were applied to shared memory.
+type AcceptedAtomicTxsEvent struct {
+	BlockHash   common.Hash
+	BlockHeight uint64
+	Txs         []*Tx
 }
 
 // atomicBackend implements the AtomicBackend interface using
@@ -81,6 +100,8 @@ type atomicBackend struct {
 
 	lastAcceptedHash common.Hash
 	verifiedRoots    map[common.Hash]AtomicState
+
+	acceptedAtomicTxsFeed utils.NonBlockingFeed[AcceptedAtomicTxsEvent]
 }
 
 // NewAtomicBackend creates an AtomicBackend from the specified dependencies
@@ -379,12 +400,18 @@ func (a *atomicBackend) MarkApplyToSharedMemoryCursor(previousLastAcceptedHeight
 	return database.PutUInt64(a.metadataDB, appliedSharedMemoryCursorKey, previousLastAcceptedHeight+1)
 }
 
-// Syncer creates and returns a new Syncer object that can be used to sync the
+// Syncer creates and returns a new AtomicSyncer object that can be used to sync the
 // state of the atomic trie from peers
-func (a *atomicBackend) Syncer(client syncclient.LeafClient, targetRoot common.Hash, targetHeight uint64, requestSize uint16) (Syncer, error) {
+func (a *atomicBackend) Syncer(client syncclient.LeafClient, targetRoot common.Hash, targetHeight uint64, requestSize uint16) (AtomicSyncer, error) {
 	return newAtomicSyncer(client, a, targetRoot, targetHeight, requestSize)
 }
 
+// Importer creates and returns a new AtomicTrieImporter object that can be
+// used to import the state of the atomic trie from a state file
+func (a *atomicBackend) Importer(targetRoot common.Hash, targetHeight uint64) (AtomicTrieImporter, error) {
+	return newAtomicTrieImporter(a, targetRoot, targetHeight)
+}
+
 func (a *atomicBackend) GetVerifiedAtomicState(blockHash common.Hash) (AtomicState, error) {
 	if state, ok := a.verifiedRoots[blockHash]; ok {
 		return state, nil
@@ -480,3 +507,8 @@ func (a *atomicBackend) IsBonus(blockHeight uint64, blockHash common.Hash) bool
 func (a *atomicBackend) AtomicTrie() AtomicTrie {
 	return a.atomicTrie
 }
+
+// SubscribeAcceptedAtomicTxsEvent registers a subscription of AcceptedAtomicTxsEvent.
+func (a *atomicBackend) SubscribeAcceptedAtomicTxsEvent(ch chan<- AcceptedAtomicTxsEvent) event.Subscription {
+	return a.acceptedAtomicTxsFeed.Subscribe(ch)
+}
@@ -88,7 +88,17 @@ func (a *atomicState) Accept(commitBatch database.Batch, requests map[ids.ID]*at
 
 	// Otherwise, atomically commit pending changes in the version db with
 	// atomic ops to shared memory.
-	return a.backend.sharedMemory.Apply(a.atomicOps, commitBatch, atomicChangesBatch)
+	if err := a.backend.sharedMemory.Apply(a.atomicOps, commitBatch, atomicChangesBatch); err != nil {
+		return err
+	}
+	if len(a.txs) > 0 {
+		a.backend.acceptedAtomicTxsFeed.Send(AcceptedAtomicTxsEvent{
+			BlockHash:   a.blockHash,
+			BlockHeight: a.blockHeight,
+			Txs:         a.txs,
+		})
+	}
+	return nil
 }
 
 // Reject frees memory associated with the state change.
@@ -8,6 +8,8 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/Juneo-io/juneogo/database/versiondb"
 	"github.com/Juneo-io/juneogo/utils/wrappers"
@@ -20,10 +22,42 @@ import (
 )
 
 var (
-	_ Syncer                  = &atomicSyncer{}
+	_ AtomicSyncer            = &atomicSyncer{}
+	_ AtomicTrieImporter      = &atomicSyncer{}
 	_ syncclient.LeafSyncTask = &atomicSyncerLeafTask{}
 )
 
+// AtomicTrieImporter inserts the leafs of the atomic trie at a target root
+// read from a source other than peers, such as a state file.
+type AtomicTrieImporter interface {
+	// AddLeafs inserts [keys] and [vals], which must be sorted and follow the
+	// keys of the previous call.
+	AddLeafs(keys [][]byte, vals [][]byte) error
+
+	// Finish commits the atomic trie and verifies it has the target root.
+	Finish() error
+}
+
+// AtomicSyncer is a Syncer for the atomic trie, which reports its progress.
+type AtomicSyncer interface {
+	Syncer
+
+	// Progress returns a snapshot of the progress of the sync.
+	Progress() AtomicSyncProgress
+}
+
+// AtomicSyncProgress is a snapshot of the progress of syncing the atomic trie.
+type AtomicSyncProgress struct {
+	Root         common.Hash `json:"root"`
+	Height       uint64      `json:"height"`
+	TargetHeight uint64      `json:"targetHeight"`
+
+	// ETASeconds is the estimated time left to sync the atomic trie, assuming
+	// heights are synced at the same rate as since the sync started, or 0
+	// until a height is synced.
+	ETASeconds uint64 `json:"etaSeconds"`
+}
+
 // atomicSyncer is used to sync the atomic trie from the network. The CallbackLeafSyncer
 // is responsible for orchestrating the sync while atomicSyncer is responsible for maintaining
 // the state of progress and writing the actual atomic trie to the trieDB.
@@ -38,8 +72,13 @@ type atomicSyncer struct {
 	syncer *syncclient.CallbackLeafSyncer
 
 	// lastHeight is the greatest height for which key / values
-	// were last inserted into the [atomicTrie]
-	lastHeight uint64
+	// were last inserted into the [atomicTrie]. It is read
+	// concurrently by [Progress].
+	lastHeight atomic.Uint64
+
+	// startHeight and startTime are used to estimate the time left.
+	startHeight uint64
+	startTime   time.Time
 }
 
 // addZeros adds [common.HashLenth] zeros to [height] and returns the result as []byte
@@ -51,6 +90,20 @@ func addZeroes(height uint64) []byte {
 }
 
 func newAtomicSyncer(client syncclient.LeafClient, atomicBackend *atomicBackend, targetRoot common.Hash, targetHeight uint64, requestSize uint16) (*atomicSyncer, error) {
+	atomicSyncer, err := newAtomicTrieImporter(atomicBackend, targetRoot, targetHeight)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make(chan syncclient.LeafSyncTask, 1)
+	tasks <- &atomicSyncerLeafTask{atomicSyncer: atomicSyncer}
+	close(tasks)
+	atomicSyncer.syncer = syncclient.NewCallbackLeafSyncer(client, tasks, requestSize)
+	return atomicSyncer, nil
+}
+
+// newAtomicTrieImporter returns an atomicSyncer without a leaf syncer, which
+// inserts the leafs passed to AddLeafs on top of the last committed atomic trie.
+func newAtomicTrieImporter(atomicBackend *atomicBackend, targetRoot common.Hash, targetHeight uint64) (*atomicSyncer, error) {
 	atomicTrie := atomicBackend.AtomicTrie()
 	lastCommittedRoot, lastCommit := atomicTrie.LastCommitted()
 	trie, err := atomicTrie.OpenTrie(lastCommittedRoot)
@@ -58,23 +111,21 @@ func newAtomicSyncer(client syncclient.LeafClient, atomicBackend *atomicBackend,
 		return nil, err
 	}
 
-	atomicSyncer := &atomicSyncer{
+	syncer := &atomicSyncer{
 		db:           atomicBackend.db,
 		atomicTrie:   atomicTrie,
 		trie:         trie,
 		targetRoot:   targetRoot,
 		targetHeight: targetHeight,
-		lastHeight:   lastCommit,
+		startHeight:  lastCommit,
 	}
-	tasks := make(chan syncclient.LeafSyncTask, 1)
-	tasks <- &atomicSyncerLeafTask{atomicSyncer: atomicSyncer}
-	close(tasks)
-	atomicSyncer.syncer = syncclient.NewCallbackLeafSyncer(client, tasks, requestSize)
-	return atomicSyncer, nil
+	syncer.lastHeight.Store(lastCommit)
+	return syncer, nil
 }
 
 // Start begins syncing the target atomic root.
 func (s *atomicSyncer) Start(ctx context.Context) error {
+	s.startTime = time.Now()
 	s.syncer.Start(ctx, 1, s.onSyncFailure)
 	return nil
 }
@@ -87,7 +138,8 @@ func (s *atomicSyncer) onLeafs(keys [][]byte, values [][]byte) error {
 		}
 		// key = height + blockchainID
 		height := binary.BigEndian.Uint64(key[:wrappers.LongLen])
-		if height > s.lastHeight {
+		lastHeight := s.lastHeight.Load()
+		if height > lastHeight {
 			// If this key belongs to a new height, we commit
 			// the trie at the previous height before adding this key.
 			root, nodes, err := s.trie.Commit(false)
@@ -99,7 +151,7 @@ func (s *atomicSyncer) onLeafs(keys [][]byte, values [][]byte) error {
 			}
 			// AcceptTrie commits the trieDB and returns [isCommit] as true
 			// if we have reached or crossed a commit interval.
-			isCommit, err := s.atomicTrie.AcceptTrie(s.lastHeight, root)
+			isCommit, err := s.atomicTrie.AcceptTrie(lastHeight, root)
 			if err != nil {
 				return err
 			}
@@ -116,7 +168,7 @@ func (s *atomicSyncer) onLeafs(keys [][]byte, values [][]byte) error {
 				return err
 			}
 			s.trie = trie
-			s.lastHeight = height
+			s.lastHeight.Store(height)
 		}
 
 		if err := s.trie.Update(key, values[i]); err != nil {
@@ -140,6 +192,7 @@ func (s *atomicSyncer) onFinish() error {
 	if _, err := s.atomicTrie.AcceptTrie(s.targetHeight, root); err != nil {
 		return err
 	}
+	s.lastHeight.Store(s.targetHeight)
 	if err := s.db.Commit(); err != nil {
 		return err
 	}
@@ -152,6 +205,31 @@ func (s *atomicSyncer) onFinish() error {
 	return nil
 }
 
+// AddLeafs inserts [keys] and [values] into the atomic trie.
+func (s *atomicSyncer) AddLeafs(keys [][]byte, values [][]byte) error {
+	return s.onLeafs(keys, values)
+}
+
+// Finish commits the atomic trie and verifies its root.
+func (s *atomicSyncer) Finish() error {
+	return s.onFinish()
+}
+
+// Progress returns a snapshot of the progress of the sync.
+func (s *atomicSyncer) Progress() AtomicSyncProgress {
+	progress := AtomicSyncProgress{
+		Root:         s.targetRoot,
+		Height:       s.lastHeight.Load(),
+		TargetHeight: s.targetHeight,
+	}
+	if synced := progress.Height - s.startHeight; synced > 0 && progress.Height < s.targetHeight {
+		elapsed := time.Since(s.startTime)
+		eta := elapsed * time.Duration(s.targetHeight-progress.Height) / time.Duration(synced)
+		progress.ETASeconds = uint64(eta.Seconds())
+	}
+	return progress
+}
+
 // onSyncFailure is a no-op since we flush progress to disk at the regular commit interval when syncing
 // the atomic trie.
 func (s *atomicSyncer) onSyncFailure(error) error {
@@ -165,7 +243,7 @@ type atomicSyncerLeafTask struct {
 	atomicSyncer *atomicSyncer
 }
 
-func (a *atomicSyncerLeafTask) Start() []byte                  { return addZeroes(a.atomicSyncer.lastHeight + 1) }
+func (a *atomicSyncerLeafTask) Start() []byte                  { return addZeroes(a.atomicSyncer.lastHeight.Load() + 1) }
 func (a *atomicSyncerLeafTask) End() []byte                    { return nil }
 func (a *atomicSyncerLeafTask) NodeType() message.NodeType     { return message.AtomicTrieNode }
 func (a *atomicSyncerLeafTask) OnFinish(context.Context) error { return a.atomicSyncer.onFinish() }
@@ -0,0 +1,338 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Juneo-io/juneogo/database"
+	"github.com/Juneo-io/juneogo/database/prefixdb"
+	"github.com/Juneo-io/juneogo/database/versiondb"
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/Juneo-io/juneogo/utils/set"
+	"github.com/Juneo-io/juneogo/utils/wrappers"
+	"github.com/Juneo-io/juneogo/vms/components/avax"
+	"github.com/Juneo-io/juneogo/vms/secp256k1fx"
+)
+
+const (
+	// atomicTxAddressLen is the length of both EVM addresses and short IDs
+	atomicTxAddressLen = common.AddressLength
+	// atomicTxAddressKeyLen is the length of an address index key:
+	// [address type] + [address] + [height] + [txID]
+	atomicTxAddressKeyLen = 1 + atomicTxAddressLen + wrappers.LongLen + common.HashLength
+	// addressIndexCommitTxsCap is the number of txs indexed between commits
+	// while initializing the address index.
+	addressIndexCommitTxsCap = 10_000
+)
+
+const (
+	evmAtomicTxAddress byte = iota
+	shortAtomicTxAddress
+)
+
+var (
+	atomicTxAddressIndexDBPrefix     = []byte("atomicTxAddressIndexDB")
+	atomicTxAddressIndexMetaDBPrefix = []byte("atomicTxAddressIndexMetaDB")
+	addressIndexHeightKey            = []byte("addressIndexHeight")
+
+	_ AtomicTxRepository = &addressIndexedAtomicTxRepository{}
+)
+
+// atomicTxAddressesFn returns the EVM addresses and short addresses touched by [tx]
+type atomicTxAddressesFn func(tx *Tx) (set.Set[common.Address], set.Set[ids.ShortID], error)
+
+// AtomicTxAddressEntry is an entry of the atomic tx address index
+type AtomicTxAddressEntry struct {
+	Height uint64
+	TxID   ids.ID
+}
+
+// atomicTxAddressIndex maintains an index of [address] => [height]+[txID]
+// for every accepted atomic tx touching either an EVM address or a short
+// address of the source/destination chain.
+type atomicTxAddressIndex struct {
+	// [indexDB] maintains keys of [address type]+[address]+[height]+[txID]
+	indexDB database.Database
+
+	// [metadataDB] tracks the height up to which the address index has been built
+	metadataDB database.Database
+
+	// [db] is used to commit to the underlying versiondb.
+	db *versiondb.Database
+
+	addressesFn atomicTxAddressesFn
+}
+
+// newAtomicTxAddressIndex returns an address index over [repo], indexing any
+// heights accepted by [repo] that have not been indexed yet.
+func newAtomicTxAddressIndex(db *versiondb.Database, repo AtomicTxRepository, addressesFn atomicTxAddressesFn) (*atomicTxAddressIndex, error) {
+	index := &atomicTxAddressIndex{
+		indexDB:     prefixdb.New(atomicTxAddressIndexDBPrefix, db),
+		metadataDB:  prefixdb.New(atomicTxAddressIndexMetaDBPrefix, db),
+		db:          db,
+		addressesFn: addressesFn,
+	}
+	if err := index.initialize(repo); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// initialize indexes the heights present in [repo] above the height
+// the address index was last built to.
+func (a *atomicTxAddressIndex) initialize(repo AtomicTxRepository) error {
+	startTime := time.Now()
+	lastLogTime := startTime
+
+	indexedHeight, err := a.getIndexHeight()
+	if err != nil {
+		return err
+	}
+	repoHeight, err := repo.GetIndexHeight()
+	if err != nil {
+		return err
+	}
+	if indexedHeight >= repoHeight {
+		return nil
+	}
+	log.Info("Initializing atomic tx address index", "indexedHeight", indexedHeight, "repoHeight", repoHeight)
+
+	iter := repo.IterateByHeight(indexedHeight + 1)
+	defer iter.Release()
+
+	indexedTxs := 0
+	pendingTxs := 0
+	for iter.Next() {
+		height := binary.BigEndian.Uint64(iter.Key())
+		if height > repoHeight {
+			break
+		}
+		txs, err := ExtractAtomicTxsBatch(iter.Value(), repo.Codec())
+		if err != nil {
+			return err
+		}
+		if err := a.index(height, txs); err != nil {
+			return err
+		}
+		indexedTxs += len(txs)
+		pendingTxs += len(txs)
+
+		// Commit periodically so an interrupted initialization can resume
+		if pendingTxs > addressIndexCommitTxsCap {
+			if err := a.putIndexHeight(height); err != nil {
+				return err
+			}
+			if err := a.db.Commit(); err != nil {
+				return err
+			}
+			pendingTxs = 0
+		}
+		if time.Since(lastLogTime) > 15*time.Second {
+			lastLogTime = time.Now()
+			log.Info("Atomic tx address index initialization", "height", height, "indexedTxs", indexedTxs)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("atomic tx repository iterator errored while initializing address index: %w", err)
+	}
+
+	if err := a.putIndexHeight(repoHeight); err != nil {
+		return err
+	}
+	log.Info("Completed atomic tx address index initialization", "indexedTxs", indexedTxs, "duration", time.Since(startTime))
+	return a.db.Commit()
+}
+
+// Write indexes [txs] accepted at [height] by every address they touch.
+// The changes are committed along with the atomic tx repository.
+func (a *atomicTxAddressIndex) Write(height uint64, txs []*Tx) error {
+	if err := a.index(height, txs); err != nil {
+		return err
+	}
+	return a.putIndexHeight(height)
+}
+
+func (a *atomicTxAddressIndex) index(height uint64, txs []*Tx) error {
+	for _, tx := range txs {
+		evmAddrs, shortAddrs, err := a.addressesFn(tx)
+		if err != nil {
+			return fmt.Errorf("failed to get addresses of atomic tx %s: %w", tx.ID(), err)
+		}
+		txID := tx.ID()
+		for addr := range evmAddrs {
+			if err := a.indexDB.Put(atomicTxAddressKey(evmAtomicTxAddress, addr[:], height, txID), nil); err != nil {
+				return err
+			}
+		}
+		for addr := range shortAddrs {
+			if err := a.indexDB.Put(atomicTxAddressKey(shortAtomicTxAddress, addr[:], height, txID), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetByEVMAddress returns up to [limit] entries for atomic txs touching [addr],
+// ordered by height and starting at [startHeight]. If [startTxID] is non-empty
+// the entry at [startHeight]+[startTxID] is skipped, so the last entry of a
+// previous page can be used to fetch the next one.
+func (a *atomicTxAddressIndex) GetByEVMAddress(addr common.Address, startHeight uint64, startTxID ids.ID, limit int) ([]AtomicTxAddressEntry, error) {
+	return a.getByAddress(evmAtomicTxAddress, addr[:], startHeight, startTxID, limit)
+}
+
+// GetByShortAddress returns up to [limit] entries for atomic txs touching [addr],
+// with the same pagination semantics as GetByEVMAddress.
+func (a *atomicTxAddressIndex) GetByShortAddress(addr ids.ShortID, startHeight uint64, startTxID ids.ID, limit int) ([]AtomicTxAddressEntry, error) {
+	return a.getByAddress(shortAtomicTxAddress, addr[:], startHeight, startTxID, limit)
+}
+
+func (a *atomicTxAddressIndex) getByAddress(addrType byte, addr []byte, startHeight uint64, startTxID ids.ID, limit int) ([]AtomicTxAddressEntry, error) {
+	prefix := make([]byte, 1+atomicTxAddressLen)
+	prefix[0] = addrType
+	copy(prefix[1:], addr)
+	startKey := atomicTxAddressKey(addrType, addr, startHeight, startTxID)
+
+	iter := a.indexDB.NewIteratorWithStartAndPrefix(startKey, prefix)
+	defer iter.Release()
+
+	entries := make([]AtomicTxAddressEntry, 0, limit)
+	for len(entries) < limit && iter.Next() {
+		key := iter.Key()
+		if len(key) != atomicTxAddressKeyLen {
+			return nil, fmt.Errorf("atomic tx address index key had invalid length (%d) != (%d)", len(key), atomicTxAddressKeyLen)
+		}
+		if startTxID != ids.Empty && bytes.Equal(key, startKey) {
+			continue
+		}
+		heightStart := 1 + atomicTxAddressLen
+		txIDStart := heightStart + wrappers.LongLen
+		txID, err := ids.ToID(key[txIDStart:])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, AtomicTxAddressEntry{
+			Height: binary.BigEndian.Uint64(key[heightStart:txIDStart]),
+			TxID:   txID,
+		})
+	}
+	return entries, iter.Error()
+}
+
+func (a *atomicTxAddressIndex) getIndexHeight() (uint64, error) {
+	heightBytes, err := a.metadataDB.Get(addressIndexHeightKey)
+	switch {
+	case err == database.ErrNotFound:
+		// Note: there are no atomic txs in genesis
+		return 0, nil
+	case err != nil:
+		return 0, err
+	case len(heightBytes) != wrappers.LongLen:
+		return 0, fmt.Errorf("unexpected length for address index height %d", len(heightBytes))
+	}
+	return binary.BigEndian.Uint64(heightBytes), nil
+}
+
+func (a *atomicTxAddressIndex) putIndexHeight(height uint64) error {
+	heightBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(heightBytes, height)
+	return a.metadataDB.Put(addressIndexHeightKey, heightBytes)
+}
+
+// atomicTxAddressKey returns the index key [addrType]+[addr]+[height]+[txID]
+func atomicTxAddressKey(addrType byte, addr []byte, height uint64, txID ids.ID) []byte {
+	key := make([]byte, atomicTxAddressKeyLen)
+	key[0] = addrType
+	copy(key[1:], addr)
+	binary.BigEndian.PutUint64(key[1+atomicTxAddressLen:], height)
+	copy(key[1+atomicTxAddressLen+wrappers.LongLen:], txID[:])
+	return key
+}
+
+// addressIndexedAtomicTxRepository wraps an AtomicTxRepository so every write
+// is also recorded in the atomic tx address index.
+type addressIndexedAtomicTxRepository struct {
+	AtomicTxRepository
+	addressIndex *atomicTxAddressIndex
+}
+
+func (a *addressIndexedAtomicTxRepository) Write(height uint64, txs []*Tx) error {
+	if err := a.addressIndex.Write(height, txs); err != nil {
+		return err
+	}
+	return a.AtomicTxRepository.Write(height, txs)
+}
+
+// WriteBonus only indexes the txs that are not already accepted, matching
+// the txID => height index which is not overwritten for bonus blocks.
+func (a *addressIndexedAtomicTxRepository) WriteBonus(height uint64, txs []*Tx) error {
+	newTxs := make([]*Tx, 0, len(txs))
+	for _, tx := range txs {
+		switch _, _, err := a.GetByTxID(tx.ID()); err {
+		case nil:
+			continue
+		case database.ErrNotFound:
+			newTxs = append(newTxs, tx)
+		default:
+			return err
+		}
+	}
+	if err := a.addressIndex.Write(height, newTxs); err != nil {
+		return err
+	}
+	return a.AtomicTxRepository.WriteBonus(height, txs)
+}
+
+// atomicTxAddresses returns the EVM addresses credited or debited by [tx]
+// as well as the short addresses receiving exported funds or signing imports.
+func (vm *VM) atomicTxAddresses(tx *Tx) (set.Set[common.Address], set.Set[ids.ShortID], error) {
+	evmAddrs := set.Set[common.Address]{}
+	shortAddrs := set.Set[ids.ShortID]{}
+	switch utx := tx.UnsignedAtomicTx.(type) {
+	case *UnsignedImportTx:
+		for _, out := range utx.Outs {
+			evmAddrs.Add(out.Address)
+		}
+		// The owners of the imported UTXOs are not part of the tx, so the
+		// short addresses are recovered from the credentials instead.
+		for _, cred := range tx.Creds {
+			cred, ok := cred.(*secp256k1fx.Credential)
+			if !ok {
+				return nil, nil, fmt.Errorf("expected *secp256k1fx.Credential but got %T", cred)
+			}
+			for _, sig := range cred.Sigs {
+				pubKey, err := vm.secpCache.RecoverPublicKey(utx.Bytes(), sig[:])
+				if err != nil {
+					return nil, nil, err
+				}
+				shortAddrs.Add(pubKey.Address())
+			}
+		}
+	case *UnsignedExportTx:
+		for _, in := range utx.Ins {
+			evmAddrs.Add(in.Address)
+		}
+		for _, out := range utx.ExportedOutputs {
+			addressable, ok := out.Out.(avax.Addressable)
+			if !ok {
+				continue
+			}
+			for _, addrBytes := range addressable.Addresses() {
+				addr, err := ids.ToShortID(addrBytes)
+				if err != nil {
+					return nil, nil, err
+				}
+				shortAddrs.Add(addr)
+			}
+		}
+	}
+	return evmAddrs, shortAddrs, nil
+}
@@ -0,0 +1,98 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Juneo-io/juneogo/database/memdb"
+	"github.com/Juneo-io/juneogo/database/versiondb"
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/Juneo-io/juneogo/utils/set"
+)
+
+func TestAtomicTxAddressIndex(t *testing.T) {
+	require := require.New(t)
+
+	db := versiondb.New(memdb.New())
+	codec := testTxCodec()
+	repo, err := NewAtomicTxRepository(db, codec, 0, nil)
+	require.NoError(err)
+
+	var (
+		evmAddr   = common.Address{1}
+		shortAddr = ids.ShortID{2}
+		// [shortTxs] is the set of txs that also touch [shortAddr]
+		shortTxs = set.Set[ids.ID]{}
+	)
+	addressesFn := func(tx *Tx) (set.Set[common.Address], set.Set[ids.ShortID], error) {
+		shortAddrs := set.Set[ids.ShortID]{}
+		if shortTxs.Contains(tx.ID()) {
+			shortAddrs.Add(shortAddr)
+		}
+		return set.Of(evmAddr), shortAddrs, nil
+	}
+
+	// Write txs before the address index is enabled, so they are indexed
+	// when initializing the index.
+	txMap := make(map[uint64][]*Tx)
+	writeTxs(t, repo, 1, 50, constTxsPerHeight(2), txMap, nil)
+	for height := uint64(1); height < 50; height += 2 {
+		shortTxs.Add(txMap[height][0].ID())
+	}
+	require.NoError(db.Commit())
+
+	index, err := newAtomicTxAddressIndex(db, repo, addressesFn)
+	require.NoError(err)
+	indexedRepo := &addressIndexedAtomicTxRepository{
+		AtomicTxRepository: repo,
+		addressIndex:       index,
+	}
+	writeTxs(t, indexedRepo, 50, 100, constTxsPerHeight(2), txMap, nil)
+	verifyTxs(t, indexedRepo, txMap)
+
+	// Page through all txs touching [evmAddr]
+	var (
+		entries   []AtomicTxAddressEntry
+		startTxID ids.ID
+		height    uint64
+	)
+	for {
+		page, err := index.GetByEVMAddress(evmAddr, height, startTxID, 7)
+		require.NoError(err)
+		if len(page) == 0 {
+			break
+		}
+		entries = append(entries, page...)
+		last := page[len(page)-1]
+		height, startTxID = last.Height, last.TxID
+	}
+	require.Len(entries, 2*99)
+	for _, entry := range entries {
+		tx, txHeight, err := repo.GetByTxID(entry.TxID)
+		require.NoError(err)
+		require.Equal(txHeight, entry.Height)
+		require.Equal(tx.ID(), entry.TxID)
+	}
+	for i := 1; i < len(entries); i++ {
+		require.LessOrEqual(entries[i-1].Height, entries[i].Height)
+	}
+
+	shortEntries, err := index.GetByShortAddress(shortAddr, 0, ids.Empty, maxGetAtomicTxsByAddressLimit)
+	require.NoError(err)
+	require.Len(shortEntries, shortTxs.Len())
+	for _, entry := range shortEntries {
+		require.True(shortTxs.Contains(entry.TxID))
+	}
+
+	// Re-opening the index should not index any height again
+	indexHeight, err := index.getIndexHeight()
+	require.NoError(err)
+	require.Equal(uint64(99), indexHeight)
+	_, err = newAtomicTxAddressIndex(db, repo, addressesFn)
+	require.NoError(err)
+}
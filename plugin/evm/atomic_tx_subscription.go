@@ -0,0 +1,166 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Juneo-io/jeth/rpc"
+
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/Juneo-io/juneogo/utils/set"
+)
+
+// AtomicTxFilterAPI offers subscriptions to atomic txs accepted by the VM
+type AtomicTxFilterAPI struct{ vm *VM }
+
+// AcceptedAtomicTxsFilter restricts the atomic txs sent to a subscriber.
+// Empty fields match any tx.
+type AcceptedAtomicTxsFilter struct {
+	// SourceChain matches import txs consuming funds from this chain
+	SourceChain string `json:"sourceChain"`
+	// DestinationChain matches export txs sending funds to this chain
+	DestinationChain string `json:"destinationChain"`
+	// Addresses matches txs touching any of these EVM or short addresses
+	Addresses []string `json:"addresses"`
+}
+
+// AcceptedAtomicTx is the notification sent for every matching accepted atomic tx
+type AcceptedAtomicTx struct {
+	TxID        ids.ID         `json:"txID"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	BlockHeight hexutil.Uint64 `json:"blockHeight"`
+	Tx          hexutil.Bytes  `json:"tx"`
+}
+
+// atomicTxFilter is the parsed form of AcceptedAtomicTxsFilter
+type atomicTxFilter struct {
+	sourceChain      ids.ID
+	destinationChain ids.ID
+	evmAddrs         set.Set[common.Address]
+	shortAddrs       set.Set[ids.ShortID]
+}
+
+// AcceptedAtomicTxs creates a subscription that is triggered each time an
+// atomic tx matching [filter] is accepted. Notifications are dropped if the
+// subscriber falls behind block acceptance.
+func (api *AtomicTxFilterAPI) AcceptedAtomicTxs(ctx context.Context, filter *AcceptedAtomicTxsFilter) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	txFilter, err := api.parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan AcceptedAtomicTxsEvent, 128)
+		acceptedSub := api.vm.atomicBackend.SubscribeAcceptedAtomicTxsEvent(events)
+
+		for {
+			select {
+			case ev := <-events:
+				for _, tx := range ev.Txs {
+					match, err := api.matches(txFilter, tx)
+					if err != nil {
+						log.Debug("failed to filter accepted atomic tx", "txID", tx.ID(), "err", err)
+						continue
+					}
+					if !match {
+						continue
+					}
+					notifier.Notify(rpcSub.ID, &AcceptedAtomicTx{
+						TxID:        tx.ID(),
+						BlockHash:   ev.BlockHash,
+						BlockHeight: hexutil.Uint64(ev.BlockHeight),
+						Tx:          tx.SignedBytes(),
+					})
+				}
+			case <-rpcSub.Err():
+				acceptedSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				acceptedSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+func (api *AtomicTxFilterAPI) parseFilter(filter *AcceptedAtomicTxsFilter) (*atomicTxFilter, error) {
+	txFilter := &atomicTxFilter{
+		evmAddrs:   set.Set[common.Address]{},
+		shortAddrs: set.Set[ids.ShortID]{},
+	}
+	if filter == nil {
+		return txFilter, nil
+	}
+
+	var err error
+	if filter.SourceChain != "" {
+		txFilter.sourceChain, err = api.vm.ctx.BCLookup.Lookup(filter.SourceChain)
+		if err != nil {
+			return nil, fmt.Errorf("problem parsing source chainID %q: %w", filter.SourceChain, err)
+		}
+	}
+	if filter.DestinationChain != "" {
+		txFilter.destinationChain, err = api.vm.ctx.BCLookup.Lookup(filter.DestinationChain)
+		if err != nil {
+			return nil, fmt.Errorf("problem parsing destination chainID %q: %w", filter.DestinationChain, err)
+		}
+	}
+	for _, addrStr := range filter.Addresses {
+		if common.IsHexAddress(addrStr) {
+			txFilter.evmAddrs.Add(common.HexToAddress(addrStr))
+			continue
+		}
+		addr, err := ids.ShortFromString(addrStr)
+		if err != nil {
+			_, addr, err = api.vm.ParseAddress(addrStr)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+			}
+		}
+		txFilter.shortAddrs.Add(addr)
+	}
+	return txFilter, nil
+}
+
+// matches returns true if [tx] is an import from the filtered source chain
+// or an export to the filtered destination chain, and touches one of the
+// filtered addresses.
+func (api *AtomicTxFilterAPI) matches(filter *atomicTxFilter, tx *Tx) (bool, error) {
+	if filter.sourceChain != ids.Empty || filter.destinationChain != ids.Empty {
+		var chainMatch bool
+		switch utx := tx.UnsignedAtomicTx.(type) {
+		case *UnsignedImportTx:
+			chainMatch = filter.sourceChain != ids.Empty && utx.SourceChain == filter.sourceChain
+		case *UnsignedExportTx:
+			chainMatch = filter.destinationChain != ids.Empty && utx.DestinationChain == filter.destinationChain
+		}
+		if !chainMatch {
+			return false, nil
+		}
+	}
+
+	if filter.evmAddrs.Len() == 0 && filter.shortAddrs.Len() == 0 {
+		return true, nil
+	}
+	evmAddrs, shortAddrs, err := api.vm.atomicTxAddresses(tx)
+	if err != nil {
+		return false, err
+	}
+	return evmAddrs.Overlaps(filter.evmAddrs) || shortAddrs.Overlaps(filter.shortAddrs), nil
+}
@@ -0,0 +1,89 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Juneo-io/jeth/rpc"
+
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/Juneo-io/juneogo/utils/crypto/secp256k1"
+)
+
+func TestAcceptedAtomicTxsSubscription(t *testing.T) {
+	require := require.New(t)
+
+	importAmount := uint64(50000000)
+	issuer, vm, _, _, _ := GenesisVMWithUTXOs(t, true, genesisJSONApricotPhase2, "", "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+	})
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	server := rpc.NewServer(0)
+	defer server.Stop()
+	require.NoError(server.RegisterName("june", &AtomicTxFilterAPI{vm}))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	subscribe := func(addrs ...string) (chan *AcceptedAtomicTx, *rpc.ClientSubscription) {
+		ch := make(chan *AcceptedAtomicTx, 1)
+		sub, err := client.Subscribe(context.Background(), "june", ch, "acceptedAtomicTxs", &AcceptedAtomicTxsFilter{Addresses: addrs})
+		require.NoError(err)
+		return ch, sub
+	}
+	evmCh, evmSub := subscribe(testEthAddrs[0].Hex())
+	defer evmSub.Unsubscribe()
+	shortCh, shortSub := subscribe(testShortIDAddrs[0].String())
+	defer shortSub.Unsubscribe()
+	otherCh, otherSub := subscribe(testEthAddrs[1].Hex())
+	defer otherSub.Unsubscribe()
+
+	importTx, err := vm.newImportTx(vm.ctx.JVMChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(err)
+	require.NoError(vm.mempool.AddLocalTx(importTx))
+	<-issuer
+
+	blk, err := vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+	require.NoError(blk.Accept(context.Background()))
+
+	for _, ch := range []chan *AcceptedAtomicTx{evmCh, shortCh} {
+		select {
+		case tx := <-ch:
+			require.Equal(importTx.ID(), tx.TxID)
+			require.Equal(common.Hash(blk.ID()), tx.BlockHash)
+			require.Equal(uint64(1), uint64(tx.BlockHeight))
+			require.Equal(importTx.SignedBytes(), []byte(tx.Tx))
+		case <-time.After(5 * time.Second):
+			require.FailNow("timed out waiting for accepted atomic tx")
+		}
+	}
+
+	// The tx does not touch the address of the third subscription.
+	select {
+	case tx := <-otherCh:
+		require.FailNow("unexpected notification", "txID", tx.TxID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestAcceptedAtomicTxsFilterInvalidAddress(t *testing.T) {
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONApricotPhase2, "", "")
+	defer func() {
+		require.NoError(t, vm.Shutdown(context.Background()))
+	}()
+
+	_, err := (&AtomicTxFilterAPI{vm}).parseFilter(&AcceptedAtomicTxsFilter{Addresses: []string{"not an address"}})
+	require.ErrorContains(t, err, "couldn't parse address")
+}
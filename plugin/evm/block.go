@@ -182,6 +182,7 @@ func (b *Block) Accept(context.Context) error {
 	defer vm.db.Abort()
 
 	b.status = choices.Accepted
+	delete(vm.processingBlocks, b.id)
 	log.Debug(fmt.Sprintf("Accepting block %s (%s) at height %d", b.ID().Hex(), b.ID(), b.Height()))
 
 	// Call Accept for relevant precompile logs. Note we do this prior to
@@ -268,6 +269,7 @@ func (b *Block) handlePrecompileAccept(rules params.Rules, sharedMemoryWriter *s
 // If [b] contains an atomic transaction, attempt to re-issue it
 func (b *Block) Reject(context.Context) error {
 	b.status = choices.Rejected
+	delete(b.vm.processingBlocks, b.id)
 	log.Debug(fmt.Sprintf("Rejecting block %s (%s) at height %d", b.ID().Hex(), b.ID(), b.Height()))
 	for _, tx := range b.atomicTxs {
 		b.vm.mempool.RemoveTx(tx)
@@ -398,6 +400,9 @@ func (b *Block) verify(predicateContext *precompileconfig.PredicateContext, writ
 	}
 
 	err := b.vm.blockChain.InsertBlockManual(b.ethBlock, writes)
+	if err == nil && writes {
+		b.vm.processingBlocks[b.id] = b.ethBlock
+	}
 	if err != nil || !writes {
 		// if an error occurred inserting the block into the chain
 		// or if we are not pinning to memory, unpin the atomic trie
@@ -0,0 +1,246 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/Juneo-io/juneogo/database"
+	"github.com/Juneo-io/juneogo/utils/wrappers"
+
+	"github.com/Juneo-io/jeth/core"
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/core/types"
+	syncclient "github.com/Juneo-io/jeth/sync/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// backfillBlocksPerRequest is the number of blocks, and of their receipts,
+	// requested from peers at once by the block backfiller.
+	backfillBlocksPerRequest = 32
+
+	// backfillLogInterval is the number of blocks between progress logs.
+	backfillLogInterval = 10_000
+)
+
+// blockBackfillKey stores the hash and height of the next block to backfill.
+// It is set to the block synced to at the end of state sync. It is stored
+// outside of the VM's versiondb, so the backfiller never commits the versiondb
+// while blocks are accepted.
+var blockBackfillKey = []byte("blockBackfill")
+
+// blockBackfiller downloads the blocks below the block the chain was state
+// synced to, with their receipts, from peers. The blocks are fetched backwards,
+// so the hash of each block is verified by its child, and the receipts are
+// verified against the receipt root of their block. The blocks are indexed
+// like accepted blocks (canonical hash, receipts, tx lookup entries and bloom
+// bits), so they can be served by the APIs. Progress is persisted after each
+// batch of blocks so that backfilling resumes where it stopped after a restart.
+//
+// The synthetic logs of the atomic txs are derived from the blocks and indexed
+// with them. The synthetic logs of the native asset calls made by eth txs can
+// only be recorded by executing the block, so they are missing from the
+// backfilled blocks.
+type blockBackfiller struct {
+	client     syncclient.Client
+	chaindb    ethdb.Database
+	backfillDB database.Database
+	// syntheticLogs returns the synthetic logs of a block which can be derived
+	// without executing it. If nil, no synthetic logs are written.
+	syntheticLogs func(*types.Block) ([]*types.Log, error)
+
+	// Sections of the bloombits index below [bloomSections] are indexed as
+	// they are backfilled, since the chain indexer assumes them indexed after
+	// state sync.
+	bloomSectionSize uint64
+	bloomSections    uint64
+}
+
+// readBlockBackfillMarker returns the hash and height of the next block to
+// backfill, or [database.ErrNotFound] if there is nothing to backfill.
+func readBlockBackfillMarker(db database.KeyValueReader) (common.Hash, uint64, error) {
+	marker, err := db.Get(blockBackfillKey)
+	if err != nil {
+		return common.Hash{}, 0, err
+	}
+	if len(marker) != common.HashLength+wrappers.LongLen {
+		return common.Hash{}, 0, fmt.Errorf("invalid block backfill marker length %d", len(marker))
+	}
+	return common.BytesToHash(marker[:common.HashLength]), binary.BigEndian.Uint64(marker[common.HashLength:]), nil
+}
+
+// writeBlockBackfillMarker sets the next block to backfill to [hash] at [height].
+func writeBlockBackfillMarker(db database.KeyValueWriter, hash common.Hash, height uint64) error {
+	marker := make([]byte, common.HashLength+wrappers.LongLen)
+	copy(marker, hash[:])
+	binary.BigEndian.PutUint64(marker[common.HashLength:], height)
+	return db.Put(blockBackfillKey, marker)
+}
+
+// backfill fetches and writes blocks from the persisted marker down to the
+// genesis block, or until [ctx] is cancelled.
+func (b *blockBackfiller) backfill(ctx context.Context) error {
+	nextHash, nextHeight, err := readBlockBackfillMarker(b.backfillDB)
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if nextHeight == 0 {
+		return nil
+	}
+	log.Info("Backfilling blocks", "hash", nextHash, "height", nextHeight)
+
+	lastLogged := nextHeight
+	for nextHeight > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		blocks, err := b.nextBlocks(ctx, nextHash, nextHeight)
+		if err != nil {
+			return fmt.Errorf("could not get blocks below %s at height %d: %w", nextHash, nextHeight, err)
+		}
+		receipts, err := b.receipts(ctx, blocks)
+		if err != nil {
+			return fmt.Errorf("could not get receipts of blocks below %s at height %d: %w", nextHash, nextHeight, err)
+		}
+		if err := b.writeBlocks(blocks, receipts); err != nil {
+			return err
+		}
+
+		last := blocks[len(blocks)-1]
+		nextHash, nextHeight = last.ParentHash(), last.NumberU64()-1
+		if err := writeBlockBackfillMarker(b.backfillDB, nextHash, nextHeight); err != nil {
+			return err
+		}
+		if lastLogged-nextHeight >= backfillLogInterval {
+			log.Info("Backfilling blocks", "height", nextHeight)
+			lastLogged = nextHeight
+		}
+	}
+	log.Info("Backfilled blocks")
+	return nil
+}
+
+// nextBlocks returns up to [backfillBlocksPerRequest] consecutive blocks
+// starting at [hash] and going backwards, from disk if the first block is
+// available or from peers otherwise. The genesis block is never returned.
+func (b *blockBackfiller) nextBlocks(ctx context.Context, hash common.Hash, height uint64) ([]*types.Block, error) {
+	blocks := make([]*types.Block, 0, backfillBlocksPerRequest)
+	for len(blocks) < backfillBlocksPerRequest && height > 0 {
+		block := rawdb.ReadBlock(b.chaindb, hash, height)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+		hash, height = block.ParentHash(), height-1
+	}
+	if len(blocks) > 0 {
+		return blocks, nil
+	}
+
+	// The client verifies that the blocks are the requested block and its
+	// parents.
+	fetched, err := b.client.GetBlocks(ctx, hash, height, backfillBlocksPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range fetched {
+		if block.NumberU64() != height {
+			return nil, fmt.Errorf("unexpected block %s at height %d, expected height %d", block.Hash(), block.NumberU64(), height)
+		}
+		if height == 0 {
+			break
+		}
+		blocks = append(blocks, block)
+		height--
+	}
+	return blocks, nil
+}
+
+// receipts returns the receipts of [blocks], fetched from peers unless none of
+// the blocks has transactions.
+func (b *blockBackfiller) receipts(ctx context.Context, blocks []*types.Block) ([]types.Receipts, error) {
+	receipts := make([]types.Receipts, 0, len(blocks))
+	hasTxs := false
+	for _, block := range blocks {
+		if len(block.Transactions()) > 0 {
+			hasTxs = true
+			break
+		}
+	}
+	if !hasTxs {
+		return make([]types.Receipts, len(blocks)), nil
+	}
+
+	// The client verifies the receipts against the receipt roots of the blocks
+	// and returns the receipts of at least one block.
+	for len(receipts) < len(blocks) {
+		fetched, err := b.client.GetReceipts(ctx, blocks[len(receipts):])
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, fetched...)
+	}
+	return receipts, nil
+}
+
+// writeBlocks writes [blocks], their [receipts] and their synthetic logs to
+// disk and indexes them.
+func (b *blockBackfiller) writeBlocks(blocks []*types.Block, receipts []types.Receipts) error {
+	batch := b.chaindb.NewBatch()
+	for i, block := range blocks {
+		rawdb.WriteBlock(batch, block)
+		rawdb.WriteCanonicalHash(batch, block.Hash(), block.NumberU64())
+		rawdb.WriteReceipts(batch, block.Hash(), block.NumberU64(), receipts[i])
+		rawdb.WriteTxLookupEntriesByBlock(batch, block)
+		if b.syntheticLogs != nil {
+			logs, err := b.syntheticLogs(block)
+			if err != nil {
+				return fmt.Errorf("could not derive synthetic logs of block %s: %w", block.Hash(), err)
+			}
+			rawdb.WriteSyntheticLogs(batch, block.Hash(), block.NumberU64(), logs)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	// Index the bloombits sections completed by [blocks], now that the headers
+	// and synthetic logs of the section are on disk. The first section is completed by block 1,
+	// since the genesis block is not backfilled.
+	for _, block := range blocks {
+		number := block.NumberU64()
+		if number%b.bloomSectionSize != 0 && number != 1 {
+			continue
+		}
+		if section := number / b.bloomSectionSize; section < b.bloomSections {
+			if err := b.writeBloomSection(section); err != nil {
+				return fmt.Errorf("could not index bloombits section %d: %w", section, err)
+			}
+		}
+	}
+	return nil
+}
+
+// writeBloomSection writes the bloombits index of [section] from the headers
+// of its blocks.
+func (b *blockBackfiller) writeBloomSection(section uint64) error {
+	headers := make([]*types.Header, 0, b.bloomSectionSize)
+	for number := section * b.bloomSectionSize; number < (section+1)*b.bloomSectionSize; number++ {
+		hash := rawdb.ReadCanonicalHash(b.chaindb, number)
+		header := rawdb.ReadHeader(b.chaindb, hash, number)
+		if header == nil {
+			return fmt.Errorf("missing header at height %d", number)
+		}
+		headers = append(headers, header)
+	}
+	return core.WriteBloomSection(b.chaindb, b.bloomSectionSize, section, headers)
+}
@@ -0,0 +1,152 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Juneo-io/juneogo/database/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Juneo-io/jeth/consensus/dummy"
+	"github.com/Juneo-io/jeth/core"
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/core/types"
+	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/plugin/evm/message"
+	syncclient "github.com/Juneo-io/jeth/sync/client"
+	"github.com/Juneo-io/jeth/sync/handlers"
+	handlerstats "github.com/Juneo-io/jeth/sync/handlers/stats"
+	"github.com/Juneo-io/jeth/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestBlockBackfill(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{addr: {Balance: big.NewInt(1000000000000000000)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	serverDB := rawdb.NewMemoryDatabase()
+	genesis := gspec.MustCommit(serverDB, trie.NewDatabase(serverDB, nil))
+	blocks, receipts, err := core.GenerateChain(gspec.Config, genesis, dummy.NewETHFaker(), serverDB, 40, 0, func(i int, b *core.BlockGen) {
+		if i%3 == 0 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(b.TxNonce(addr), addr, big.NewInt(10000), params.TxGas, b.BaseFee(), nil), signer, key)
+		require.NoError(err)
+		b.AddTx(tx)
+	})
+	require.NoError(err)
+
+	// Serve the blocks and receipts as a peer would.
+	serverBlocks := map[common.Hash]*types.Block{genesis.Hash(): genesis}
+	serverReceipts := make(map[common.Hash]types.Receipts)
+	for i, block := range blocks {
+		serverBlocks[block.Hash()] = block
+		serverReceipts[block.Hash()] = receipts[i]
+	}
+	blocksHandler := handlers.NewBlockRequestHandler(
+		&handlers.TestBlockProvider{
+			GetBlockFn: func(hash common.Hash, height uint64) *types.Block {
+				block, ok := serverBlocks[hash]
+				if !ok || block.NumberU64() != height {
+					return nil
+				}
+				return block
+			},
+		},
+		&handlers.TestReceiptProvider{
+			GetReceiptsByHashFn: func(hash common.Hash) types.Receipts { return serverReceipts[hash] },
+		},
+		message.Codec,
+		handlerstats.NewNoopHandlerStats(),
+		nil,
+	)
+	client := syncclient.NewMockClient(message.Codec, nil, nil, blocksHandler)
+
+	// The client has the genesis and the block it state synced to, without
+	// its receipts.
+	clientDB := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(clientDB, trie.NewDatabase(clientDB, nil))
+	syncedBlock := blocks[len(blocks)-1]
+	rawdb.WriteBlock(clientDB, syncedBlock)
+	rawdb.WriteCanonicalHash(clientDB, syncedBlock.Hash(), syncedBlock.NumberU64())
+
+	db := memdb.New()
+	require.NoError(writeBlockBackfillMarker(db, syncedBlock.Hash(), syncedBlock.NumberU64()))
+	syntheticLog := func(block *types.Block) *types.Log {
+		return &types.Log{Address: common.Address{byte(block.NumberU64())}, Topics: []common.Hash{{1}}, Data: []byte{1}}
+	}
+	backfiller := &blockBackfiller{
+		client:     client,
+		chaindb:    clientDB,
+		backfillDB: db,
+		syntheticLogs: func(block *types.Block) ([]*types.Log, error) {
+			return []*types.Log{syntheticLog(block)}, nil
+		},
+		bloomSectionSize: 16,
+		bloomSections:    2,
+	}
+
+	// Fail the second request for blocks, after the synced block and 32 of
+	// its parents are backfilled.
+	errTest := errors.New("test error")
+	requests := 0
+	client.GetBlocksIntercept = func(_ message.BlockRequest, blocks types.Blocks) (types.Blocks, error) {
+		requests++
+		if requests == 2 {
+			return nil, errTest
+		}
+		return blocks, nil
+	}
+	require.ErrorIs(backfiller.backfill(context.Background()), errTest)
+	hash, height, err := readBlockBackfillMarker(db)
+	require.NoError(err)
+	require.Equal(blocks[6].Hash(), hash)
+	require.Equal(uint64(7), height)
+
+	// Backfilling resumes from the marker.
+	client.GetBlocksIntercept = nil
+	require.NoError(backfiller.backfill(context.Background()))
+	_, height, err = readBlockBackfillMarker(db)
+	require.NoError(err)
+	require.Zero(height)
+
+	for _, block := range blocks {
+		require.Equal(block.Hash(), rawdb.ReadCanonicalHash(clientDB, block.NumberU64()))
+		require.NotNil(rawdb.ReadBlock(clientDB, block.Hash(), block.NumberU64()))
+
+		blockReceipts := rawdb.ReadReceipts(clientDB, block.Hash(), block.NumberU64(), block.Time(), gspec.Config)
+		require.Len(blockReceipts, len(block.Transactions()))
+		require.Equal(block.ReceiptHash(), types.DeriveSha(blockReceipts, trie.NewStackTrie(nil)))
+		for _, tx := range block.Transactions() {
+			number := rawdb.ReadTxLookupEntry(clientDB, tx.Hash())
+			require.NotNil(number)
+			require.Equal(block.NumberU64(), *number)
+		}
+		require.Equal([]*types.Log{syntheticLog(block)}, rawdb.ReadSyntheticLogs(clientDB, block.Hash(), block.NumberU64()))
+	}
+
+	// The sections below [bloomSections] are indexed.
+	for section := uint64(0); section < 3; section++ {
+		head := rawdb.ReadCanonicalHash(clientDB, (section+1)*16-1)
+		_, err := rawdb.ReadBloomBits(clientDB, 0, section, head)
+		if section < 2 {
+			require.NoError(err)
+		} else {
+			require.Error(err)
+		}
+	}
+}
@@ -10,6 +10,10 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/Juneo-io/jeth/core/state/pruner"
+	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/warp"
+
 	"github.com/Juneo-io/juneogo/api"
 	"github.com/Juneo-io/juneogo/ids"
 	"github.com/Juneo-io/juneogo/utils/crypto/secp256k1"
@@ -25,9 +29,16 @@ var _ Client = (*client)(nil)
 // Client interface for interacting with EVM [chain]
 type Client interface {
 	IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error)
+	IssueSignedTx(ctx context.Context, unsignedTxBytes []byte, sigs [][][secp256k1.SignatureLen]byte, options ...rpc.Option) (ids.ID, error)
+	BuildUnsignedImportTx(ctx context.Context, args *BuildUnsignedImportTxArgs, options ...rpc.Option) (*BuildUnsignedTxReply, error)
+	BuildUnsignedExportTx(ctx context.Context, args *BuildUnsignedExportTxArgs, options ...rpc.Option) (*BuildUnsignedTxReply, error)
 	GetAtomicTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (Status, error)
 	GetAtomicTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	GetAtomicUTXOs(ctx context.Context, addrs []ids.ShortID, sourceChain string, limit uint32, startAddress ids.ShortID, startUTXOID ids.ID, options ...rpc.Option) ([][]byte, ids.ShortID, ids.ID, error)
+	GetAtomicTxsByAddress(ctx context.Context, addr string, limit uint32, startHeight uint64, startTxID ids.ID, options ...rpc.Option) ([][]byte, uint64, ids.ID, error)
+	GetMempoolTxs(ctx context.Context, options ...rpc.Option) (*GetMempoolTxsReply, error)
+	GetMempoolStats(ctx context.Context, options ...rpc.Option) (*GetMempoolStatsReply, error)
+	EstimateAtomicTxFee(ctx context.Context, args *EstimateAtomicTxFeeArgs, options ...rpc.Option) (*EstimateAtomicTxFeeReply, error)
 	ExportKey(ctx context.Context, userPass api.UserPass, addr common.Address, options ...rpc.Option) (*secp256k1.PrivateKey, string, error)
 	ImportKey(ctx context.Context, userPass api.UserPass, privateKey *secp256k1.PrivateKey, options ...rpc.Option) (common.Address, error)
 	Import(ctx context.Context, userPass api.UserPass, to common.Address, sourceChain string, options ...rpc.Option) (ids.ID, error)
@@ -39,6 +50,15 @@ type Client interface {
 	LockProfile(ctx context.Context, options ...rpc.Option) error
 	SetLogLevel(ctx context.Context, level log.Lvl, options ...rpc.Option) error
 	GetVMConfig(ctx context.Context, options ...rpc.Option) (*Config, error)
+	DropMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error
+	AddWarpOffChainMessage(ctx context.Context, unsignedMessageBytes []byte, options ...rpc.Option) (ids.ID, error)
+	ListWarpOffChainMessages(ctx context.Context, options ...rpc.Option) ([]warp.OffChainMessage, error)
+	RevokeWarpOffChainMessage(ctx context.Context, messageID ids.ID, options ...rpc.Option) error
+	ReloadUpgradeConfig(ctx context.Context, options ...rpc.Option) (*params.UpgradeConfig, error)
+	StartOnlinePruning(ctx context.Context, options ...rpc.Option) error
+	PauseOnlinePruning(ctx context.Context, options ...rpc.Option) error
+	OnlinePruningStatus(ctx context.Context, options ...rpc.Option) (*pruner.OnlineStatus, error)
+	ExportStateFile(ctx context.Context, path string, height uint64, options ...rpc.Option) (*ExportStateFileReply, error)
 }
 
 // Client implementation for interacting with EVM [chain]
@@ -69,6 +89,50 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+// IssueSignedTx issues the unsigned tx [unsignedTxBytes] with the signatures
+// [sigs] of each of its inputs, and returns the TxID
+func (c *client) IssueSignedTx(ctx context.Context, unsignedTxBytes []byte, sigs [][][secp256k1.SignatureLen]byte, options ...rpc.Option) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	unsignedTxStr, err := formatting.Encode(formatting.Hex, unsignedTxBytes)
+	if err != nil {
+		return res.TxID, fmt.Errorf("problem hex encoding bytes: %w", err)
+	}
+	sigStrs := make([][]string, len(sigs))
+	for i, inputSigs := range sigs {
+		sigStrs[i] = make([]string, len(inputSigs))
+		for j, sig := range inputSigs {
+			sigStrs[i][j], err = formatting.Encode(formatting.Hex, sig[:])
+			if err != nil {
+				return res.TxID, fmt.Errorf("problem hex encoding signature: %w", err)
+			}
+		}
+	}
+	err = c.requester.SendRequest(ctx, "june.issueTx", &IssueTxArgs{
+		FormattedTx: api.FormattedTx{
+			Encoding: formatting.Hex,
+		},
+		UnsignedTx: unsignedTxStr,
+		Signatures: sigStrs,
+	}, res, options...)
+	return res.TxID, err
+}
+
+// BuildUnsignedImportTx returns the unsigned import tx described by [args]
+// and the addresses that must sign it
+func (c *client) BuildUnsignedImportTx(ctx context.Context, args *BuildUnsignedImportTxArgs, options ...rpc.Option) (*BuildUnsignedTxReply, error) {
+	res := &BuildUnsignedTxReply{}
+	err := c.requester.SendRequest(ctx, "june.buildUnsignedImportTx", args, res, options...)
+	return res, err
+}
+
+// BuildUnsignedExportTx returns the unsigned export tx described by [args]
+// and the addresses that must sign it
+func (c *client) BuildUnsignedExportTx(ctx context.Context, args *BuildUnsignedExportTxArgs, options ...rpc.Option) (*BuildUnsignedTxReply, error) {
+	res := &BuildUnsignedTxReply{}
+	err := c.requester.SendRequest(ctx, "june.buildUnsignedExportTx", args, res, options...)
+	return res, err
+}
+
 // GetAtomicTxStatus returns the status of [txID]
 func (c *client) GetAtomicTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (Status, error) {
 	res := &GetAtomicTxStatusReply{}
@@ -126,6 +190,56 @@ func (c *client) GetAtomicUTXOs(ctx context.Context, addrs []ids.ShortID, source
 	return utxos, endAddr, endUTXOID, err
 }
 
+// GetAtomicTxsByAddress returns the byte representation of the accepted atomic txs
+// touching [addr], starting after the position [startHeight]+[startTxID]
+func (c *client) GetAtomicTxsByAddress(ctx context.Context, addr string, limit uint32, startHeight uint64, startTxID ids.ID, options ...rpc.Option) ([][]byte, uint64, ids.ID, error) {
+	res := &GetAtomicTxsByAddressReply{}
+	err := c.requester.SendRequest(ctx, "june.getAtomicTxsByAddress", &GetAtomicTxsByAddressArgs{
+		Address: addr,
+		StartIndex: AtomicTxIndex{
+			Height: json.Uint64(startHeight),
+			TxID:   startTxID,
+		},
+		Limit:    json.Uint32(limit),
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, 0, ids.Empty, err
+	}
+
+	txs := make([][]byte, len(res.Txs))
+	for i, tx := range res.Txs {
+		txBytes, err := formatting.Decode(res.Encoding, tx.Tx)
+		if err != nil {
+			return nil, 0, ids.Empty, err
+		}
+		txs[i] = txBytes
+	}
+	return txs, uint64(res.EndIndex.Height), res.EndIndex.TxID, nil
+}
+
+// GetMempoolTxs returns the atomic txs held by the mempool
+func (c *client) GetMempoolTxs(ctx context.Context, options ...rpc.Option) (*GetMempoolTxsReply, error) {
+	res := &GetMempoolTxsReply{}
+	err := c.requester.SendRequest(ctx, "june.getMempoolTxs", struct{}{}, res, options...)
+	return res, err
+}
+
+// GetMempoolStats returns statistics about the atomic mempool
+func (c *client) GetMempoolStats(ctx context.Context, options ...rpc.Option) (*GetMempoolStatsReply, error) {
+	res := &GetMempoolStatsReply{}
+	err := c.requester.SendRequest(ctx, "june.getMempoolStats", struct{}{}, res, options...)
+	return res, err
+}
+
+// EstimateAtomicTxFee returns the fee of the import or export tx described
+// by [args] without issuing it
+func (c *client) EstimateAtomicTxFee(ctx context.Context, args *EstimateAtomicTxFeeArgs, options ...rpc.Option) (*EstimateAtomicTxFeeReply, error) {
+	res := &EstimateAtomicTxFeeReply{}
+	err := c.requester.SendRequest(ctx, "june.estimateAtomicTxFee", args, res, options...)
+	return res, err
+}
+
 // ListAddresses returns all addresses on this chain controlled by [user]
 func (c *client) ListAddresses(ctx context.Context, user api.UserPass) ([]string, error) {
 	res := &api.JSONAddresses{}
@@ -236,3 +350,68 @@ func (c *client) GetVMConfig(ctx context.Context, options ...rpc.Option) (*Confi
 	err := c.adminRequester.SendRequest(ctx, "admin.getVMConfig", struct{}{}, res, options...)
 	return res.Config, err
 }
+
+// DropMempoolTx removes the pending atomic tx [txID] from the mempool
+func (c *client) DropMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error {
+	return c.adminRequester.SendRequest(ctx, "admin.dropMempoolTx", &api.JSONTxID{
+		TxID: txID,
+	}, &api.EmptyReply{}, options...)
+}
+
+// AddWarpOffChainMessage adds an off-chain warp message the node is willing to sign
+func (c *client) AddWarpOffChainMessage(ctx context.Context, unsignedMessageBytes []byte, options ...rpc.Option) (ids.ID, error) {
+	res := &AddWarpOffChainMessageReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.addWarpOffChainMessage", &AddWarpOffChainMessageArgs{
+		Message: unsignedMessageBytes,
+	}, res, options...)
+	return res.MessageID, err
+}
+
+// ListWarpOffChainMessages returns the off-chain warp messages the node is willing to sign
+func (c *client) ListWarpOffChainMessages(ctx context.Context, options ...rpc.Option) ([]warp.OffChainMessage, error) {
+	res := &ListWarpOffChainMessagesReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.listWarpOffChainMessages", struct{}{}, res, options...)
+	return res.Messages, err
+}
+
+// RevokeWarpOffChainMessage revokes the off-chain warp message [messageID]
+func (c *client) RevokeWarpOffChainMessage(ctx context.Context, messageID ids.ID, options ...rpc.Option) error {
+	return c.adminRequester.SendRequest(ctx, "admin.revokeWarpOffChainMessage", &RevokeWarpOffChainMessageArgs{
+		MessageID: messageID,
+	}, &api.EmptyReply{}, options...)
+}
+
+// ReloadUpgradeConfig applies the precompile upgrade file again and returns the upgrade config applied
+func (c *client) ReloadUpgradeConfig(ctx context.Context, options ...rpc.Option) (*params.UpgradeConfig, error) {
+	res := &ReloadUpgradeConfigReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.reloadUpgradeConfig", struct{}{}, res, options...)
+	return res.UpgradeConfig, err
+}
+
+// StartOnlinePruning starts, or resumes, deleting the stale state in the background
+func (c *client) StartOnlinePruning(ctx context.Context, options ...rpc.Option) error {
+	return c.adminRequester.SendRequest(ctx, "admin.startOnlinePruning", struct{}{}, &api.EmptyReply{}, options...)
+}
+
+// PauseOnlinePruning pauses the background deletion of the stale state
+func (c *client) PauseOnlinePruning(ctx context.Context, options ...rpc.Option) error {
+	return c.adminRequester.SendRequest(ctx, "admin.pauseOnlinePruning", struct{}{}, &api.EmptyReply{}, options...)
+}
+
+// OnlinePruningStatus returns the progress of the online pruner
+func (c *client) OnlinePruningStatus(ctx context.Context, options ...rpc.Option) (*pruner.OnlineStatus, error) {
+	res := &OnlinePruningStatusReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.onlinePruningStatus", struct{}{}, res, options...)
+	return &res.OnlineStatus, err
+}
+
+// ExportStateFile writes the state at the state summary at [height], or at the
+// last state summary if [height] is 0, to a state file at [path] on the node
+func (c *client) ExportStateFile(ctx context.Context, path string, height uint64, options ...rpc.Option) (*ExportStateFileReply, error) {
+	res := &ExportStateFileReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.exportStateFile", &ExportStateFileArgs{
+		Path:   path,
+		Height: json.Uint64(height),
+	}, res, options...)
+	return res, err
+}
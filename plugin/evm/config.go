@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Juneo-io/jeth/core/rawdb"
 	"github.com/Juneo-io/jeth/core/txpool/legacypool"
 	"github.com/Juneo-io/jeth/eth"
+	warpPrecompile "github.com/Juneo-io/jeth/precompile/contracts/warp"
+	"github.com/Juneo-io/juneogo/ids"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/spf13/cast"
@@ -44,6 +47,8 @@ const (
 	defaultPullGossipFrequency                        = 1 * time.Second
 	defaultTxRegossipFrequency                        = 30 * time.Second
 	defaultOfflinePruningBloomFilterSize       uint64 = 512 // Default size (MB) for the offline pruner to use
+	defaultOnlinePruningBloomFilterSize        uint64 = 512 // Default size (MB) for the online pruner to use
+	defaultOnlinePruningBatchDelay                    = 100 * time.Millisecond
 	defaultLogLevel                                   = "info"
 	defaultLogJSONFormat                              = false
 	defaultMaxOutboundActiveRequests                  = 16
@@ -51,6 +56,11 @@ const (
 	defaultPopulateMissingTriesParallelism            = 1024
 	defaultStateSyncServerTrieCache                   = 64 // MB
 	defaultAcceptedCacheSize                          = 32 // blocks
+	defaultWarpRelayerQuorumNumerator                 = warpPrecompile.WarpDefaultQuorumNumerator
+	defaultWarpRelayerGasLimit                        = 500_000
+	defaultWarpRelayerRetryInterval                   = 30 * time.Second
+	defaultWarpRelayerMaxAttempts                     = 20
+	defaultStateScheme                                = rawdb.HashScheme
 
 	// defaultStateSyncMinBlocks is the minimum number of blocks the blockchain
 	// should be ahead of local last accepted to perform state sync.
@@ -91,6 +101,7 @@ type Config struct {
 	CorethAdminAPIEnabled bool   `json:"coreth-admin-api-enabled"` // Deprecated: use AdminAPIEnabled instead
 	CorethAdminAPIDir     string `json:"coreth-admin-api-dir"`     // Deprecated: use AdminAPIDir instead
 	WarpAPIEnabled        bool   `json:"warp-api-enabled"`
+	StateSyncAPIEnabled   bool   `json:"state-sync-api-enabled"`
 
 	// EnabledEthAPIs is a list of Ethereum services that should be enabled
 	// If none is specified, then we use the default list [defaultEnabledAPIs]
@@ -125,6 +136,7 @@ type Config struct {
 	PopulateMissingTries            *uint64 `json:"populate-missing-tries,omitempty"`   // Sets the starting point for re-populating missing tries. Disables re-generation if nil.
 	PopulateMissingTriesParallelism int     `json:"populate-missing-tries-parallelism"` // Number of concurrent readers to use when re-populating missing tries on startup.
 	PruneWarpDB                     bool    `json:"prune-warp-db-enabled"`              // Determines if the warpDB should be cleared on startup
+	StateScheme                     string  `json:"state-scheme"`                       // Scheme used to store the state trie nodes on disk (hash or path)
 
 	// Metric Settings
 	MetricsExpensiveEnabled bool `json:"metrics-expensive-enabled"` // Debug-level metrics that might impact runtime performance
@@ -173,28 +185,50 @@ type Config struct {
 	OfflinePruningBloomFilterSize uint64 `json:"offline-pruning-bloom-filter-size"`
 	OfflinePruningDataDirectory   string `json:"offline-pruning-data-directory"`
 
+	// Online Pruning Settings
+	OnlinePruningBloomFilterSize uint64   `json:"online-pruning-bloom-filter-size"`
+	OnlinePruningBatchDelay      Duration `json:"online-pruning-batch-delay"`
+
 	// VM2VM network
 	MaxOutboundActiveRequests           int64 `json:"max-outbound-active-requests"`
 	MaxOutboundActiveCrossChainRequests int64 `json:"max-outbound-active-cross-chain-requests"`
 
 	// Sync settings
-	StateSyncEnabled         *bool  `json:"state-sync-enabled"`     // Pointer distinguishes false (no state sync) and not set (state sync only at genesis).
-	StateSyncSkipResume      bool   `json:"state-sync-skip-resume"` // Forces state sync to use the highest available summary block
-	StateSyncServerTrieCache int    `json:"state-sync-server-trie-cache"`
-	StateSyncIDs             string `json:"state-sync-ids"`
-	StateSyncCommitInterval  uint64 `json:"state-sync-commit-interval"`
-	StateSyncMinBlocks       uint64 `json:"state-sync-min-blocks"`
-	StateSyncRequestSize     uint16 `json:"state-sync-request-size"`
+	StateSyncEnabled         *bool       `json:"state-sync-enabled"`     // Pointer distinguishes false (no state sync) and not set (state sync only at genesis).
+	StateSyncSkipResume      bool        `json:"state-sync-skip-resume"` // Forces state sync to use the highest available summary block
+	StateSyncServerTrieCache int         `json:"state-sync-server-trie-cache"`
+	StateSyncIDs             string      `json:"state-sync-ids"`
+	StateSyncCommitInterval  uint64      `json:"state-sync-commit-interval"`
+	StateSyncMinBlocks       uint64      `json:"state-sync-min-blocks"`
+	StateSyncRequestSize     uint16      `json:"state-sync-request-size"`
+	StateSyncFile            string      `json:"state-sync-file"`          // Path of a state file to bootstrap from instead of syncing from peers, used only when the chain is empty.
+	StateSyncFileChecksum    common.Hash `json:"state-sync-file-checksum"` // Checksum of the state file returned by admin.exportStateFile, required with state-sync-file
+	StateSyncBackfill        bool        `json:"state-sync-backfill"`      // Fetches the blocks and receipts below the block synced to from peers in the background
+
+	// Budgets of the state sync requests served to each peer, 0 is unlimited
+	StateSyncServerLeafsPerSecond        uint64 `json:"state-sync-server-leafs-per-second"`
+	StateSyncServerBytesPerSecond        uint64 `json:"state-sync-server-bytes-per-second"`
+	StateSyncServerMaxConcurrentRequests int    `json:"state-sync-server-max-concurrent-requests"`
 
 	// Database Settings
 	InspectDatabase bool `json:"inspect-database"` // Inspects the database on startup if enabled.
 
+	// AtomicTxAddressIndexEnabled indexes accepted atomic txs by the EVM
+	// addresses and short addresses they touch, to serve avax.getAtomicTxsByAddress.
+	AtomicTxAddressIndexEnabled bool `json:"atomic-tx-address-index-enabled"`
+
 	// SkipUpgradeCheck disables checking that upgrades must take place before the last
 	// accepted block. Skipping this check is useful when a node operator does not update
 	// their node before the network upgrade and their node accepts blocks that have
 	// identical state with the pre-upgrade ruleset.
 	SkipUpgradeCheck bool `json:"skip-upgrade-check"`
 
+	// PrecompileUpgradeFile is the path of a JSON file, usually kept in the chain
+	// config directory, with the precompile upgrades and the network upgrade
+	// overrides of the chain. If set, it replaces the upgrade bytes given by the
+	// node and can be reloaded without restarting with admin.reloadUpgradeConfig.
+	PrecompileUpgradeFile string `json:"precompile-upgrade-file"`
+
 	// AcceptedCacheSize is the depth to keep in the accepted headers cache and the
 	// accepted logs cache at the accepted tip.
 	//
@@ -220,6 +254,24 @@ type Config struct {
 	// Note: only supports AddressedCall payloads as defined here:
 	// https://github.com/ava-labs/avalanchego/tree/7623ffd4be915a5185c9ed5e11fa9be15a6e1f00/vms/platformvm/warp/payload#addressedcall
 	WarpOffChainMessages []hexutil.Bytes `json:"warp-off-chain-messages"`
+
+	// Warp Relayer Settings
+	// If enabled, the node relays the warp messages sent by accepted txs to
+	// [WarpRelayerDestinationChainID], calling the destination address of each
+	// message with its payload and paying with the key in [WarpRelayerPrivateKeyFile].
+	// Only the messages with a destination payload addressed to that chain are
+	// relayed. The messages accepted while the relayer was not running are
+	// relayed once it starts, starting from [WarpRelayerStartHeight] the first
+	// time it is enabled, or from the blocks accepted after it is enabled if 0.
+	WarpRelayerEnabled            bool     `json:"warp-relayer-enabled"`
+	WarpRelayerDestinationRPC     string   `json:"warp-relayer-destination-rpc"`      // eth RPC endpoint of the destination chain
+	WarpRelayerDestinationChainID ids.ID   `json:"warp-relayer-destination-chain-id"` // Blockchain ID of the destination chain
+	WarpRelayerStartHeight        uint64   `json:"warp-relayer-start-height"`         // Height of the first block whose messages are relayed (0 for the next accepted block)
+	WarpRelayerPrivateKeyFile     string   `json:"warp-relayer-private-key-file"`     // File holding the hex encoded key funding the relay txs
+	WarpRelayerQuorumNumerator    uint64   `json:"warp-relayer-quorum-numerator"`     // Quorum numerator used to aggregate signatures
+	WarpRelayerGasLimit           uint64   `json:"warp-relayer-gas-limit"`            // Gas limit of the relay txs
+	WarpRelayerRetryInterval      Duration `json:"warp-relayer-retry-interval"`       // Delay before retrying a failed relay, doubled on every failure
+	WarpRelayerMaxAttempts        uint64   `json:"warp-relayer-max-attempts"`         // Relay attempts before dropping a message (0 for no limit)
 }
 
 // EthAPIs returns an array of strings representing the Eth APIs that should be enabled
@@ -252,6 +304,7 @@ func (c *Config) SetDefaults() {
 	c.ContinuousProfilerFrequency.Duration = defaultContinuousProfilerFrequency
 	c.ContinuousProfilerMaxFiles = defaultContinuousProfilerMaxFiles
 	c.Pruning = defaultPruningEnabled
+	c.StateScheme = defaultStateScheme
 	c.TrieCleanCache = defaultTrieCleanCache
 	c.TrieDirtyCache = defaultTrieDirtyCache
 	c.TrieDirtyCommitTarget = defaultTrieDirtyCommitTarget
@@ -269,6 +322,8 @@ func (c *Config) SetDefaults() {
 	c.PullGossipFrequency.Duration = defaultPullGossipFrequency
 	c.RegossipFrequency.Duration = defaultTxRegossipFrequency
 	c.OfflinePruningBloomFilterSize = defaultOfflinePruningBloomFilterSize
+	c.OnlinePruningBloomFilterSize = defaultOnlinePruningBloomFilterSize
+	c.OnlinePruningBatchDelay.Duration = defaultOnlinePruningBatchDelay
 	c.LogLevel = defaultLogLevel
 	c.LogJSONFormat = defaultLogJSONFormat
 	c.MaxOutboundActiveRequests = defaultMaxOutboundActiveRequests
@@ -280,6 +335,10 @@ func (c *Config) SetDefaults() {
 	c.StateSyncRequestSize = defaultStateSyncRequestSize
 	c.AllowUnprotectedTxHashes = defaultAllowUnprotectedTxHashes
 	c.AcceptedCacheSize = defaultAcceptedCacheSize
+	c.WarpRelayerQuorumNumerator = defaultWarpRelayerQuorumNumerator
+	c.WarpRelayerGasLimit = defaultWarpRelayerGasLimit
+	c.WarpRelayerRetryInterval.Duration = defaultWarpRelayerRetryInterval
+	c.WarpRelayerMaxAttempts = defaultWarpRelayerMaxAttempts
 }
 
 func (d *Duration) UnmarshalJSON(data []byte) (err error) {
@@ -318,9 +377,45 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cannot use commit interval of 0 with pruning enabled")
 	}
 
+	switch c.StateScheme {
+	case rawdb.HashScheme:
+	case rawdb.PathScheme:
+		// The path-based scheme only keeps the most recent states, so it cannot
+		// be used to run an archival node.
+		if !c.Pruning {
+			return fmt.Errorf("cannot use the %s state scheme while pruning is disabled", rawdb.PathScheme)
+		}
+		if c.OfflinePruning {
+			return fmt.Errorf("cannot run offline pruning with the %s state scheme", rawdb.PathScheme)
+		}
+	default:
+		return fmt.Errorf("state-scheme is %q but must be %q or %q", c.StateScheme, rawdb.HashScheme, rawdb.PathScheme)
+	}
+
+	// The atomic root of the summary of a state file is not committed to by any
+	// block, so the file must be the one the node operator expects.
+	if c.StateSyncFile != "" && c.StateSyncFileChecksum == (common.Hash{}) {
+		return fmt.Errorf("cannot bootstrap from state-sync-file without state-sync-file-checksum")
+	}
+
 	if c.PushGossipPercentStake < 0 || c.PushGossipPercentStake > 1 {
 		return fmt.Errorf("push-gossip-percent-stake is %f but must be in the range [0, 1]", c.PushGossipPercentStake)
 	}
+
+	if c.WarpRelayerEnabled {
+		if c.WarpRelayerDestinationRPC == "" {
+			return fmt.Errorf("cannot enable the warp relayer without a destination RPC")
+		}
+		if c.WarpRelayerDestinationChainID == ids.Empty {
+			return fmt.Errorf("cannot enable the warp relayer without a destination chain ID")
+		}
+		if c.WarpRelayerPrivateKeyFile == "" {
+			return fmt.Errorf("cannot enable the warp relayer without a private key file")
+		}
+		if c.WarpRelayerQuorumNumerator < warpPrecompile.WarpQuorumNumeratorMinimum || c.WarpRelayerQuorumNumerator > warpPrecompile.WarpQuorumDenominator {
+			return fmt.Errorf("warp-relayer-quorum-numerator is %d but must be in the range [%d, %d]", c.WarpRelayerQuorumNumerator, warpPrecompile.WarpQuorumNumeratorMinimum, warpPrecompile.WarpQuorumDenominator)
+		}
+	}
 	return nil
 }
 
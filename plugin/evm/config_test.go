@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Juneo-io/jeth/core/rawdb"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
@@ -77,6 +78,18 @@ func TestUnmarshalConfig(t *testing.T) {
 			Config{StateSyncIDs: "NodeID-CaBYJ9kzHvrQFiYWowMkJGAQKGMJqZoat"},
 			false,
 		},
+		{
+			"state sync file",
+			[]byte(`{"state-sync-file": "state", "state-sync-file-checksum": "0x0100000000000000000000000000000000000000000000000000000000000000"}`),
+			Config{StateSyncFile: "state", StateSyncFileChecksum: common.Hash{1}},
+			false,
+		},
+		{
+			"path state scheme",
+			[]byte(`{"state-scheme": "path"}`),
+			Config{StateScheme: rawdb.PathScheme},
+			false,
+		},
 		{
 			"empty tx lookup limit",
 			[]byte(`{}`),
@@ -10,6 +10,8 @@ import (
 	"math/big"
 
 	"github.com/Juneo-io/jeth/core/state"
+	"github.com/Juneo-io/jeth/core/types"
+	"github.com/Juneo-io/jeth/core/vm"
 	"github.com/Juneo-io/jeth/params"
 
 	"github.com/Juneo-io/juneogo/chains/atomic"
@@ -295,6 +297,29 @@ func (vm *VM) newExportTx(
 	baseFee *big.Int, // fee to use post-AP3
 	keys []*secp256k1.PrivateKey, // Pay the fee and provide the tokens
 ) (*Tx, error) {
+	addrs, keysByAddr := ethAddressesOfKeys(keys)
+	utx, err := vm.newUnsignedExportTx(assetID, amount, chainID, to, baseFee, addrs)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Tx{UnsignedAtomicTx: utx}
+	if err := tx.Sign(vm.codec, inputSigners(utx.Ins, keysByAddr)); err != nil {
+		return nil, err
+	}
+	return tx, utx.Verify(vm.ctx, vm.currentRules())
+}
+
+// newUnsignedExportTx returns a new unsigned ExportTx funded by [addrs]. Each
+// of its inputs must be signed by the key of the input's address.
+func (vm *VM) newUnsignedExportTx(
+	assetID ids.ID, // AssetID of the tokens to export
+	amount uint64, // Amount of tokens to export
+	chainID ids.ID, // Chain to send the UTXOs to
+	to ids.ShortID, // Address of chain recipient
+	baseFee *big.Int, // fee to use post-AP3
+	addrs []common.Address, // Pay the fee and provide the tokens
+) (*UnsignedExportTx, error) {
 	outs := []*avax.TransferableOutput{{
 		Asset: avax.Asset{ID: assetID},
 		Out: &secp256k1fx.TransferOutput{
@@ -308,17 +333,16 @@ func (vm *VM) newExportTx(
 	}}
 
 	var (
-		avaxNeeded           uint64 = 0
-		ins, avaxIns         []EVMInput
-		signers, avaxSigners [][]*secp256k1.PrivateKey
-		err                  error
+		avaxNeeded   uint64 = 0
+		ins, avaxIns []EVMInput
+		err          error
 	)
 
 	// consume non-AVAX
 	if assetID != vm.ctx.ChainAssetID {
-		ins, signers, err = vm.GetSpendableFunds(keys, assetID, amount)
+		ins, err = vm.getSpendableFunds(addrs, assetID, amount)
 		if err != nil {
-			return nil, fmt.Errorf("couldn't generate tx inputs/signers: %w", err)
+			return nil, fmt.Errorf("couldn't generate tx inputs: %w", err)
 		}
 	} else {
 		avaxNeeded = amount
@@ -345,37 +369,30 @@ func (vm *VM) newExportTx(
 			return nil, err
 		}
 
-		avaxIns, avaxSigners, err = vm.GetSpendableAVAXWithFee(keys, avaxNeeded, cost, baseFee)
+		avaxIns, err = vm.getSpendableAVAXWithFee(addrs, avaxNeeded, cost, baseFee)
 	default:
 		var newAvaxNeeded uint64
 		newAvaxNeeded, err = math.Add64(avaxNeeded, params.AvalancheAtomicTxFee)
 		if err != nil {
 			return nil, errOverflowExport
 		}
-		avaxIns, avaxSigners, err = vm.GetSpendableFunds(keys, vm.ctx.ChainAssetID, newAvaxNeeded)
+		avaxIns, err = vm.getSpendableFunds(addrs, vm.ctx.ChainAssetID, newAvaxNeeded)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/signers: %w", err)
+		return nil, fmt.Errorf("couldn't generate tx inputs: %w", err)
 	}
 	ins = append(ins, avaxIns...)
-	signers = append(signers, avaxSigners...)
 
 	avax.SortTransferableOutputs(outs, vm.codec)
-	SortEVMInputsAndSigners(ins, signers)
+	utils.Sort(ins)
 
-	// Create the transaction
-	utx := &UnsignedExportTx{
+	return &UnsignedExportTx{
 		NetworkID:        vm.ctx.NetworkID,
 		BlockchainID:     vm.ctx.ChainID,
 		DestinationChain: chainID,
 		Ins:              ins,
 		ExportedOutputs:  outs,
-	}
-	tx := &Tx{UnsignedAtomicTx: utx}
-	if err := tx.Sign(vm.codec, signers); err != nil {
-		return nil, err
-	}
-	return tx, utx.Verify(vm.ctx, vm.currentRules())
+	}, nil
 }
 
 // EVMStateTransfer executes the state update from the atomic export transaction
@@ -399,6 +416,7 @@ func (utx *UnsignedExportTx) EVMStateTransfer(ctx *snow.Context, state *state.St
 				return errInsufficientFunds
 			}
 			state.SubBalanceMultiCoin(from.Address, common.Hash(from.AssetID), amount)
+			vm.AddNativeAssetTransferLog(state, from.Address, common.Address{}, common.Hash(from.AssetID), amount)
 		}
 		if state.GetNonce(from.Address) != from.Nonce {
 			return errInvalidNonce
@@ -410,3 +428,15 @@ func (utx *UnsignedExportTx) EVMStateTransfer(ctx *snow.Context, state *state.St
 	}
 	return nil
 }
+
+// syntheticLogs returns the synthetic logs recorded by EVMStateTransfer, whose
+// tx fields are not set.
+func (utx *UnsignedExportTx) syntheticLogs(ctx *snow.Context) []*types.Log {
+	var logs []*types.Log
+	for _, from := range utx.Ins {
+		if from.AssetID != ctx.ChainAssetID {
+			logs = append(logs, vm.NativeAssetTransferLog(from.Address, common.Address{}, common.Hash(from.AssetID), new(big.Int).SetUint64(from.Amount)))
+		}
+	}
+	return logs
+}
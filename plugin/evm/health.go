@@ -3,12 +3,24 @@
 
 package evm
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // Health returns nil if this chain is healthy.
-// Also returns details, which should be one of:
-// string, []byte, map[string]string
+// Also returns details, which are marshalled to JSON. The details
+// report the progress of state sync, and the check fails if state
+// sync failed.
 func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 	// TODO perform actual health check
-	return nil, nil
+	if vm.StateSyncClient == nil {
+		return nil, nil
+	}
+	status := vm.StateSyncClient.StateSyncStatus()
+	details := map[string]interface{}{"stateSync": status}
+	if status.Phase == stateSyncPhaseFailed {
+		return details, fmt.Errorf("state sync failed: %s", status.Error)
+	}
+	return details, nil
 }
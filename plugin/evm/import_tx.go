@@ -11,6 +11,8 @@ import (
 	"slices"
 
 	"github.com/Juneo-io/jeth/core/state"
+	"github.com/Juneo-io/jeth/core/types"
+	"github.com/Juneo-io/jeth/core/vm"
 	"github.com/Juneo-io/jeth/params"
 
 	"github.com/Juneo-io/juneogo/chains/atomic"
@@ -339,6 +341,131 @@ func (vm *VM) newImportTxWithUTXOs(
 		signers = append(signers, utxoSigners)
 	}
 	avax.SortTransferableInputsWithSigners(importedInputs, signers)
+
+	outs, err := vm.importTxOutputs(chainID, to, baseFee, importedInputs, importedAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the transaction
+	utx := &UnsignedImportTx{
+		NetworkID:      vm.ctx.NetworkID,
+		BlockchainID:   vm.ctx.ChainID,
+		Outs:           outs,
+		ImportedInputs: importedInputs,
+		SourceChain:    chainID,
+	}
+	tx := &Tx{UnsignedAtomicTx: utx}
+	if err := tx.Sign(vm.codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.Verify(vm.ctx, vm.currentRules())
+}
+
+// newUnsignedImportTxWithUTXOs returns a new unsigned ImportTx spending the
+// [atomicUTXOs] that can be spent by [addrs], along with the addresses that
+// must sign each of its inputs.
+func (vm *VM) newUnsignedImportTxWithUTXOs(
+	chainID ids.ID, // chain to import from
+	to common.Address, // Address of recipient
+	baseFee *big.Int, // fee to use post-AP3
+	addrs set.Set[ids.ShortID], // Addresses that will sign the atomic UTXOs
+	atomicUTXOs []*avax.UTXO, // UTXOs to spend
+) (*UnsignedImportTx, [][]ids.ShortID, error) {
+	inputs := []importInputWithSigners{}
+
+	importedAmount := make(map[ids.ID]uint64)
+	now := vm.clock.Unix()
+	for _, utxo := range atomicUTXOs {
+		input, utxoSigners, ok := spendWithAddresses(utxo.Out, addrs, now)
+		if !ok {
+			continue
+		}
+		aid := utxo.AssetID()
+		var err error
+		importedAmount[aid], err = math.Add64(importedAmount[aid], input.Amount())
+		if err != nil {
+			return nil, nil, err
+		}
+		inputs = append(inputs, importInputWithSigners{
+			input: &avax.TransferableInput{
+				UTXOID: utxo.UTXOID,
+				Asset:  utxo.Asset,
+				In:     input,
+			},
+			signers: utxoSigners,
+		})
+	}
+	slices.SortFunc(inputs, func(a, b importInputWithSigners) int {
+		return a.input.Compare(b.input)
+	})
+	importedInputs := make([]*avax.TransferableInput, len(inputs))
+	signers := make([][]ids.ShortID, len(inputs))
+	for i, input := range inputs {
+		importedInputs[i] = input.input
+		signers[i] = input.signers
+	}
+
+	outs, err := vm.importTxOutputs(chainID, to, baseFee, importedInputs, importedAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	utx := &UnsignedImportTx{
+		NetworkID:      vm.ctx.NetworkID,
+		BlockchainID:   vm.ctx.ChainID,
+		Outs:           outs,
+		ImportedInputs: importedInputs,
+		SourceChain:    chainID,
+	}
+	return utx, signers, nil
+}
+
+// importInputWithSigners pairs an imported input with the addresses that
+// must sign it, so they can be sorted together.
+type importInputWithSigners struct {
+	input   *avax.TransferableInput
+	signers []ids.ShortID
+}
+
+// spendWithAddresses returns the input spending [out] with signatures from
+// [addrs] at time [now], along with the addresses that must sign it, in
+// signature index order. Returns false if [addrs] cannot spend [out].
+func spendWithAddresses(out verify.Verifiable, addrs set.Set[ids.ShortID], now uint64) (avax.TransferableIn, []ids.ShortID, bool) {
+	transferOut, ok := out.(*secp256k1fx.TransferOutput)
+	if !ok || now < transferOut.Locktime {
+		return nil, nil, false
+	}
+
+	owners := transferOut.OutputOwners
+	sigIndices := make([]uint32, 0, owners.Threshold)
+	signers := make([]ids.ShortID, 0, owners.Threshold)
+	for i := 0; i < len(owners.Addrs) && uint32(len(signers)) < owners.Threshold; i++ {
+		if addrs.Contains(owners.Addrs[i]) {
+			sigIndices = append(sigIndices, uint32(i))
+			signers = append(signers, owners.Addrs[i])
+		}
+	}
+	if uint32(len(signers)) != owners.Threshold {
+		return nil, nil, false
+	}
+	return &secp256k1fx.TransferInput{
+		Amt: transferOut.Amt,
+		Input: secp256k1fx.Input{
+			SigIndices: sigIndices,
+		},
+	}, signers, true
+}
+
+// importTxOutputs returns the sorted EVMOutputs crediting [to] with
+// [importedAmount] once the fee to import [importedInputs] is deducted.
+func (vm *VM) importTxOutputs(
+	chainID ids.ID,
+	to common.Address,
+	baseFee *big.Int,
+	importedInputs []*avax.TransferableInput,
+	importedAmount map[ids.ID]uint64,
+) ([]EVMOutput, error) {
 	importedAVAXAmount := importedAmount[vm.ctx.ChainAssetID]
 
 	outs := make([]EVMOutput, 0, len(importedAmount))
@@ -420,20 +547,7 @@ func (vm *VM) newImportTxWithUTXOs(
 	}
 
 	utils.Sort(outs)
-
-	// Create the transaction
-	utx := &UnsignedImportTx{
-		NetworkID:      vm.ctx.NetworkID,
-		BlockchainID:   vm.ctx.ChainID,
-		Outs:           outs,
-		ImportedInputs: importedInputs,
-		SourceChain:    chainID,
-	}
-	tx := &Tx{UnsignedAtomicTx: utx}
-	if err := tx.Sign(vm.codec, signers); err != nil {
-		return nil, err
-	}
-	return tx, utx.Verify(vm.ctx, vm.currentRules())
+	return outs, nil
 }
 
 // EVMStateTransfer performs the state transfer to increase the balances of
@@ -451,7 +565,20 @@ func (utx *UnsignedImportTx) EVMStateTransfer(ctx *snow.Context, state *state.St
 			log.Debug("crosschain", "src", utx.SourceChain, "addr", to.Address, "amount", to.Amount, "assetID", to.AssetID)
 			amount := new(big.Int).SetUint64(to.Amount)
 			state.AddBalanceMultiCoin(to.Address, common.Hash(to.AssetID), amount)
+			vm.AddNativeAssetTransferLog(state, common.Address{}, to.Address, common.Hash(to.AssetID), amount)
 		}
 	}
 	return nil
 }
+
+// syntheticLogs returns the synthetic logs recorded by EVMStateTransfer, whose
+// tx fields are not set.
+func (utx *UnsignedImportTx) syntheticLogs(ctx *snow.Context) []*types.Log {
+	var logs []*types.Log
+	for _, to := range utx.Outs {
+		if to.AssetID != ctx.ChainAssetID {
+			logs = append(logs, vm.NativeAssetTransferLog(common.Address{}, to.Address, common.Hash(to.AssetID), new(big.Int).SetUint64(to.Amount)))
+		}
+	}
+	return logs
+}
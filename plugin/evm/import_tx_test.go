@@ -4,17 +4,24 @@
 package evm
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
+	"github.com/Juneo-io/jeth/core/rawdb"
 	"github.com/Juneo-io/jeth/params"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
 
+	"github.com/Juneo-io/juneogo/api"
 	"github.com/Juneo-io/juneogo/chains/atomic"
 	"github.com/Juneo-io/juneogo/ids"
 	"github.com/Juneo-io/juneogo/utils"
 	"github.com/Juneo-io/juneogo/utils/constants"
 	"github.com/Juneo-io/juneogo/utils/crypto/secp256k1"
+	"github.com/Juneo-io/juneogo/utils/formatting"
+	"github.com/Juneo-io/juneogo/utils/json"
 	"github.com/Juneo-io/juneogo/utils/set"
 	"github.com/Juneo-io/juneogo/vms/components/avax"
 	"github.com/Juneo-io/juneogo/vms/secp256k1fx"
@@ -530,6 +537,103 @@ func TestNewImportTx(t *testing.T) {
 	}
 }
 
+func TestEstimateAtomicTxFee(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, sharedMemory, _ := GenesisVM(t, true, genesisJSONApricotPhase5, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	importAmount := uint64(5000000)
+	_, err := addUTXO(sharedMemory, vm.ctx, ids.GenerateTestID(), 0, vm.ctx.JUNEAssetID, importAmount, testShortIDAddrs[0])
+	require.NoError(err)
+
+	service := &AvaxAPI{vm}
+	importArgs := &ImportArgs{
+		BaseFee:     (*hexutil.Big)(initialBaseFee),
+		SourceChain: "X",
+		To:          testEthAddrs[0],
+	}
+	reply := &EstimateAtomicTxFeeReply{}
+	require.NoError(service.EstimateAtomicTxFee(nil, &EstimateAtomicTxFeeArgs{
+		Import: importArgs,
+		From:   []string{testShortIDAddrs[0].String()},
+	}, reply))
+
+	// The estimate should match the tx built with the keys of [From]
+	tx, err := vm.newImportTx(vm.ctx.JVMChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(err)
+	gasUsed, err := tx.GasUsed(true)
+	require.NoError(err)
+	burned, err := tx.Burned(vm.ctx.JUNEAssetID)
+	require.NoError(err)
+	require.Equal(json.Uint64(gasUsed), reply.GasUsed)
+	require.Equal(initialBaseFee, reply.BaseFee.ToInt())
+	require.Equal(map[string]json.Uint64{vm.ctx.JUNEAssetID.String(): json.Uint64(burned)}, reply.Burned)
+
+	// Addresses that do not own any UTXO cannot fund the import
+	err = service.EstimateAtomicTxFee(nil, &EstimateAtomicTxFeeArgs{
+		Import: importArgs,
+		From:   []string{testShortIDAddrs[1].String()},
+	}, &EstimateAtomicTxFeeReply{})
+	require.ErrorIs(err, errInsufficientFundsForFee)
+
+	err = service.EstimateAtomicTxFee(nil, &EstimateAtomicTxFeeArgs{
+		From: []string{testShortIDAddrs[0].String()},
+	}, &EstimateAtomicTxFeeReply{})
+	require.ErrorIs(err, errEstimateAtomicTxType)
+}
+
+func TestBuildUnsignedImportTx(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, sharedMemory, _ := GenesisVM(t, true, genesisJSONApricotPhase5, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	_, err := addUTXO(sharedMemory, vm.ctx, ids.GenerateTestID(), 0, vm.ctx.JUNEAssetID, 5000000, testShortIDAddrs[0])
+	require.NoError(err)
+
+	service := &AvaxAPI{vm}
+	reply := &BuildUnsignedTxReply{}
+	require.NoError(service.BuildUnsignedImportTx(nil, &BuildUnsignedImportTxArgs{
+		ImportArgs: ImportArgs{
+			BaseFee:     (*hexutil.Big)(initialBaseFee),
+			SourceChain: "X",
+			To:          testEthAddrs[0],
+		},
+		From:     []string{testShortIDAddrs[0].String()},
+		Encoding: formatting.Hex,
+	}, reply))
+	require.Len(reply.Signers, 1)
+	require.Len(reply.Signers[0], 1)
+	expectedSigner, err := vm.FormatAddress(vm.ctx.JVMChainID, testShortIDAddrs[0])
+	require.NoError(err)
+	require.Equal(expectedSigner, reply.Signers[0][0].Address)
+
+	// Sign the tx outside of the node
+	sig, err := testKeys[0].SignHash(reply.Signers[0][0].SigHash)
+	require.NoError(err)
+	sigStr, err := formatting.Encode(formatting.Hex, sig)
+	require.NoError(err)
+
+	issueReply := &api.JSONTxID{}
+	require.NoError(service.IssueTx(nil, &IssueTxArgs{
+		FormattedTx: api.FormattedTx{Encoding: formatting.Hex},
+		UnsignedTx:  reply.UnsignedTx,
+		Signatures:  [][]string{{sigStr}},
+	}, issueReply))
+
+	// Signatures are deterministic, so the issued tx is the one built with
+	// the keys of the signers
+	tx, err := vm.newImportTx(vm.ctx.JVMChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(err)
+	require.Equal(tx.ID(), issueReply.TxID)
+	require.True(vm.mempool.Has(tx.ID()))
+}
+
 // Note: this is a brittle test to ensure that the gas cost of a transaction does
 // not change
 func TestImportTxGasCost(t *testing.T) {
@@ -1277,6 +1381,14 @@ func TestImportTxEVMStateTransfer(t *testing.T) {
 				if avaxBalance.Cmp(common.Big0) != 0 {
 					t.Fatalf("Expected AVAX balance to be 0, found balance: %d", avaxBalance)
 				}
+
+				// The synthetic logs derived for backfilled blocks are the ones
+				// recorded when the block was accepted.
+				ethBlock := lastAcceptedBlock.ethBlock
+				syntheticLogs, err := vm.atomicSyntheticLogs(ethBlock)
+				require.NoError(t, err)
+				require.Len(t, syntheticLogs, 1)
+				require.Equal(t, rawdb.ReadSyntheticLogs(vm.chaindb, ethBlock.Hash(), ethBlock.NumberU64()), syntheticLogs)
 			},
 		},
 	}
@@ -12,7 +12,9 @@ import (
 	"github.com/Juneo-io/juneogo/ids"
 	"github.com/Juneo-io/juneogo/network/p2p/gossip"
 	"github.com/Juneo-io/juneogo/snow"
+	"github.com/Juneo-io/juneogo/utils/linkedhashmap"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/maps"
 
 	"github.com/Juneo-io/jeth/metrics"
 	"github.com/ethereum/go-ethereum/log"
@@ -25,6 +27,7 @@ const (
 var (
 	errTxAlreadyKnown = errors.New("tx already known")
 	errNoGasUsed      = errors.New("no gas used")
+	errTxNotPending   = errors.New("tx is not pending")
 
 	_ gossip.Set[*GossipAtomicTx] = (*Mempool)(nil)
 )
@@ -64,6 +67,9 @@ type Mempool struct {
 	// discardedTxs is an LRU Cache of transactions that have been discarded after failing
 	// verification.
 	discardedTxs *cache.LRU[ids.ID, *Tx]
+	// discardReasons records why the most recently discarded transactions
+	// were discarded, in the order they were discarded.
+	discardReasons linkedhashmap.LinkedHashmap[ids.ID, string]
 	// Pending is a channel of length one, which the mempool ensures has an item on
 	// it as long as there is an unissued transaction remaining in [txs]
 	Pending chan struct{}
@@ -88,17 +94,18 @@ func NewMempool(ctx *snow.Context, registerer prometheus.Registerer, maxSize int
 	}
 
 	return &Mempool{
-		ctx:          ctx,
-		issuedTxs:    make(map[ids.ID]*Tx),
-		discardedTxs: &cache.LRU[ids.ID, *Tx]{Size: discardedTxsCacheSize},
-		currentTxs:   make(map[ids.ID]*Tx),
-		Pending:      make(chan struct{}, 1),
-		txHeap:       newTxHeap(maxSize),
-		maxSize:      maxSize,
-		utxoSpenders: make(map[ids.ID]*Tx),
-		bloom:        bloom,
-		metrics:      newMempoolMetrics(),
-		verify:       verify,
+		ctx:            ctx,
+		issuedTxs:      make(map[ids.ID]*Tx),
+		discardedTxs:   &cache.LRU[ids.ID, *Tx]{Size: discardedTxsCacheSize},
+		discardReasons: linkedhashmap.New[ids.ID, string](),
+		currentTxs:     make(map[ids.ID]*Tx),
+		Pending:        make(chan struct{}, 1),
+		txHeap:         newTxHeap(maxSize),
+		maxSize:        maxSize,
+		utxoSpenders:   make(map[ids.ID]*Tx),
+		bloom:          bloom,
+		metrics:        newMempoolMetrics(),
+		verify:         verify,
 	}, nil
 }
 
@@ -153,7 +160,7 @@ func (m *Mempool) Add(tx *GossipAtomicTx) error {
 
 	if err != nil {
 		txID := tx.Tx.ID()
-		m.discardedTxs.Put(txID, tx.Tx)
+		m.discardTx(tx.Tx, err.Error())
 		log.Debug("failed to issue remote tx to mempool",
 			"txID", txID,
 			"err", err,
@@ -178,7 +185,7 @@ func (m *Mempool) AddTx(tx *Tx) error {
 		// unlike local txs, invalid remote txs are recorded as discarded
 		// so that they won't be requested again
 		txID := tx.ID()
-		m.discardedTxs.Put(tx.ID(), tx)
+		m.discardTx(tx, err.Error())
 		log.Debug("failed to issue remote tx to mempool",
 			"txID", txID,
 			"err", err,
@@ -286,8 +293,9 @@ func (m *Mempool) addTx(tx *Tx, force bool) error {
 			)
 		}
 		// Remove any conflicting transactions from the mempool
+		reason := fmt.Sprintf("replaced by conflicting tx %s with gas price %d", txID, gasPrice)
 		for _, conflictTx := range conflictingTxs {
-			m.removeTx(conflictTx, true)
+			m.removeTx(conflictTx, reason)
 		}
 	}
 	// If adding this transaction would exceed the mempool's size, check if there is a lower priced
@@ -308,7 +316,7 @@ func (m *Mempool) addTx(tx *Tx, force bool) error {
 				)
 			}
 
-			m.removeTx(minTx, true)
+			m.removeTx(minTx, fmt.Sprintf("evicted by tx %s with gas price %d", txID, gasPrice))
 		} else {
 			// This could occur if we have used our entire size allowance on
 			// transactions that are currently processing.
@@ -323,6 +331,7 @@ func (m *Mempool) addTx(tx *Tx, force bool) error {
 	if _, has := m.discardedTxs.Get(txID); has {
 		log.Debug("Adding recently discarded transaction %s back to the mempool", txID)
 		m.discardedTxs.Evict(txID)
+		m.discardReasons.Delete(txID)
 	}
 
 	// Add the transaction to the [txHeap] so we can evaluate new entries based
@@ -504,7 +513,7 @@ func (m *Mempool) cancelTx(tx *Tx) {
 		// invalid. This should never happen but we guard against the case it does.
 		log.Error("failed to calculate atomic tx gas price while canceling current tx", "err", err)
 		m.removeSpenders(tx)
-		m.discardedTxs.Put(tx.ID(), tx)
+		m.discardTx(tx, fmt.Sprintf("failed to calculate gas price: %s", err))
 		m.metrics.discardedTxs.Inc(1)
 	}
 
@@ -513,43 +522,44 @@ func (m *Mempool) cancelTx(tx *Tx) {
 }
 
 // DiscardCurrentTx marks a [tx] in the [currentTxs] map as invalid and aborts the attempt
-// to issue it since it failed verification.
-func (m *Mempool) DiscardCurrentTx(txID ids.ID) {
+// to issue it since it failed verification with [reason].
+func (m *Mempool) DiscardCurrentTx(txID ids.ID, reason error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
 	if tx, ok := m.currentTxs[txID]; ok {
-		m.discardCurrentTx(tx)
+		m.discardCurrentTx(tx, reason)
 	}
 }
 
-// DiscardCurrentTxs marks all txs in [currentTxs] as discarded.
-func (m *Mempool) DiscardCurrentTxs() {
+// DiscardCurrentTxs marks all txs in [currentTxs] as discarded due to [reason].
+func (m *Mempool) DiscardCurrentTxs(reason error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
 	for _, tx := range m.currentTxs {
-		m.discardCurrentTx(tx)
+		m.discardCurrentTx(tx, reason)
 	}
 }
 
 // discardCurrentTx discards [tx] from the set of current transactions.
 // Assumes the lock is held.
-func (m *Mempool) discardCurrentTx(tx *Tx) {
+func (m *Mempool) discardCurrentTx(tx *Tx, reason error) {
 	m.removeSpenders(tx)
-	m.discardedTxs.Put(tx.ID(), tx)
+	m.discardTx(tx, reason.Error())
 	delete(m.currentTxs, tx.ID())
 	m.metrics.currentTxs.Update(int64(len(m.currentTxs)))
 	m.metrics.discardedTxs.Inc(1)
 }
 
 // removeTx removes [txID] from the mempool.
+// If [discardReason] is non-empty, [tx] is marked as discarded for that reason.
 // Note: removeTx will delete all entries from [utxoSpenders] corresponding
 // to input UTXOs of [txID]. This means that when replacing a conflicting tx,
 // removeTx must be called for all conflicts before overwriting the utxoSpenders
 // map.
 // Assumes lock is held.
-func (m *Mempool) removeTx(tx *Tx, discard bool) {
+func (m *Mempool) removeTx(tx *Tx, discardReason string) {
 	txID := tx.ID()
 
 	// Remove from [currentTxs], [txHeap], and [issuedTxs].
@@ -557,11 +567,12 @@ func (m *Mempool) removeTx(tx *Tx, discard bool) {
 	m.txHeap.Remove(txID)
 	delete(m.issuedTxs, txID)
 
-	if discard {
-		m.discardedTxs.Put(txID, tx)
+	if discardReason != "" {
+		m.discardTx(tx, discardReason)
 		m.metrics.discardedTxs.Inc(1)
 	} else {
 		m.discardedTxs.Evict(txID)
+		m.discardReasons.Delete(txID)
 	}
 	m.metrics.pendingTxs.Update(int64(m.txHeap.Len()))
 	m.metrics.currentTxs.Update(int64(len(m.currentTxs)))
@@ -586,7 +597,105 @@ func (m *Mempool) RemoveTx(tx *Tx) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	m.removeTx(tx, false)
+	m.removeTx(tx, "")
+}
+
+// discardTx records [tx] as discarded due to [reason].
+// Assumes the lock is held.
+func (m *Mempool) discardTx(tx *Tx, reason string) {
+	txID := tx.ID()
+	m.discardedTxs.Put(txID, tx)
+
+	// Keep the reason of the most recently discarded txs only, matching
+	// the size of [discardedTxs].
+	m.discardReasons.Delete(txID)
+	m.discardReasons.Put(txID, reason)
+	for m.discardReasons.Len() > discardedTxsCacheSize {
+		oldestTxID, _, _ := m.discardReasons.Oldest()
+		m.discardReasons.Delete(oldestTxID)
+	}
+}
+
+// MempoolPendingTx is a tx waiting to be issued along with the [gasPrice]
+// it pays.
+type MempoolPendingTx struct {
+	Tx       *Tx
+	GasPrice uint64
+}
+
+// MempoolDiscardedTx is a recently discarded tx along with the reason it was
+// discarded. [Tx] is nil if it was evicted from the discarded txs cache.
+type MempoolDiscardedTx struct {
+	TxID   ids.ID
+	Tx     *Tx
+	Reason string
+}
+
+// PendingTxs returns the txs waiting to be issued, sorted by descending
+// [gasPrice] which is the order they will be issued in.
+func (m *Mempool) PendingTxs() []MempoolPendingTx {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	entries := m.txHeap.Entries()
+	txs := make([]MempoolPendingTx, len(entries))
+	for i, entry := range entries {
+		txs[i] = MempoolPendingTx{
+			Tx:       entry.tx,
+			GasPrice: entry.gasPrice,
+		}
+	}
+	return txs
+}
+
+// CurrentTxs returns the txs being built into a block.
+func (m *Mempool) CurrentTxs() []*Tx {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return maps.Values(m.currentTxs)
+}
+
+// IssuedTxs returns the txs issued into a block that was not yet decided.
+func (m *Mempool) IssuedTxs() []*Tx {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return maps.Values(m.issuedTxs)
+}
+
+// DiscardedTxs returns the most recently discarded txs, from oldest to newest.
+func (m *Mempool) DiscardedTxs() []MempoolDiscardedTx {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	txs := make([]MempoolDiscardedTx, 0, m.discardReasons.Len())
+	iter := m.discardReasons.NewIterator()
+	for iter.Next() {
+		txID := iter.Key()
+		tx, _ := m.discardedTxs.Get(txID)
+		txs = append(txs, MempoolDiscardedTx{
+			TxID:   txID,
+			Tx:     tx,
+			Reason: iter.Value(),
+		})
+	}
+	return txs
+}
+
+// DropPendingTx removes the pending tx [txID] from the mempool and marks it
+// as discarded for [reason]. Txs already being built into or issued in a
+// block cannot be dropped.
+func (m *Mempool) DropPendingTx(txID ids.ID, reason string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	tx, ok := m.txHeap.Get(txID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errTxNotPending, txID)
+	}
+	m.removeTx(tx, reason)
+	return nil
 }
 
 // addPending makes sure that an item is in the Pending channel.
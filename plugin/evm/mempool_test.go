@@ -54,3 +54,47 @@ func TestMempoolAdd(t *testing.T) {
 	err = m.Add(tx)
 	require.ErrorIs(err, errTxAlreadyKnown)
 }
+
+func TestMempoolPendingTxsAndDrop(t *testing.T) {
+	require := require.New(t)
+	m, err := NewMempool(&snow.Context{}, prometheus.NewRegistry(), 5_000, nil)
+	require.NoError(err)
+
+	gasPrices := []uint64{5, 1, 10, 3}
+	txs := make([]*Tx, len(gasPrices))
+	for i, gasPrice := range gasPrices {
+		txs[i] = &Tx{
+			UnsignedAtomicTx: &TestUnsignedTx{
+				IDV:      ids.GenerateTestID(),
+				GasUsedV: 1_000,
+				BurnedV:  gasPrice * 1_000,
+			},
+		}
+		require.NoError(m.AddLocalTx(txs[i]))
+	}
+
+	// Pending txs are ranked by descending gas price
+	pendingTxs := m.PendingTxs()
+	require.Len(pendingTxs, len(txs))
+	expectedOrder := []*Tx{txs[2], txs[0], txs[3], txs[1]}
+	for i, pendingTx := range pendingTxs {
+		require.Equal(expectedOrder[i].ID(), pendingTx.Tx.ID())
+	}
+
+	droppedTxID := txs[0].ID()
+	require.NoError(m.DropPendingTx(droppedTxID, "test"))
+	require.ErrorIs(m.DropPendingTx(droppedTxID, "test"), errTxNotPending)
+	require.Len(m.PendingTxs(), len(txs)-1)
+
+	_, dropped, found := m.GetTx(droppedTxID)
+	require.True(found)
+	require.True(dropped)
+	discardedTxs := m.DiscardedTxs()
+	require.Len(discardedTxs, 1)
+	require.Equal(droppedTxID, discardedTxs[0].TxID)
+	require.Equal("test", discardedTxs[0].Reason)
+
+	// Re-adding the tx removes it from the discarded txs
+	require.NoError(m.AddLocalTx(txs[0]))
+	require.Empty(m.DiscardedTxs())
+}
@@ -46,6 +46,10 @@ func init() {
 		c.RegisterType(BlockSignatureRequest{}),
 		c.RegisterType(SignatureResponse{}),
 
+		// Receipts request types, registered last to keep the type IDs above
+		c.RegisterType(ReceiptsRequest{}),
+		c.RegisterType(ReceiptsResponse{}),
+
 		Codec.RegisterCodec(Version, c),
 	)
 
@@ -177,6 +177,7 @@ type mockHandler struct {
 	handleStateTrieCalled,
 	handleAtomicTrieCalled,
 	handleBlockRequestCalled,
+	handleReceiptsRequestCalled,
 	handleCodeRequestCalled,
 	handleMessageSignatureCalled,
 	handleBlockSignatureCalled bool
@@ -197,6 +198,11 @@ func (m *mockHandler) HandleBlockRequest(context.Context, ids.NodeID, uint32, Bl
 	return nil, nil
 }
 
+func (m *mockHandler) HandleReceiptsRequest(context.Context, ids.NodeID, uint32, ReceiptsRequest) ([]byte, error) {
+	m.handleReceiptsRequestCalled = true
+	return nil, nil
+}
+
 func (m *mockHandler) HandleCodeRequest(context.Context, ids.NodeID, uint32, CodeRequest) ([]byte, error) {
 	m.handleCodeRequestCalled = true
 	return nil, nil
@@ -215,5 +221,6 @@ func (m *mockHandler) reset() {
 	m.handleStateTrieCalled = false
 	m.handleAtomicTrieCalled = false
 	m.handleBlockRequestCalled = false
+	m.handleReceiptsRequestCalled = false
 	m.handleCodeRequestCalled = false
 }
@@ -0,0 +1,45 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Juneo-io/juneogo/ids"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	_ Request = ReceiptsRequest{}
+)
+
+// ReceiptsRequest is a request to retrieve the receipts of Parents number of
+// blocks starting from Hash from newest-oldest manner
+type ReceiptsRequest struct {
+	Hash    common.Hash `serialize:"true"`
+	Height  uint64      `serialize:"true"`
+	Parents uint16      `serialize:"true"`
+}
+
+func (r ReceiptsRequest) String() string {
+	return fmt.Sprintf(
+		"ReceiptsRequest(Hash=%s, Height=%d, Parents=%d)",
+		r.Hash, r.Height, r.Parents,
+	)
+}
+
+func (r ReceiptsRequest) Handle(ctx context.Context, nodeID ids.NodeID, requestID uint32, handler RequestHandler) ([]byte, error) {
+	return handler.HandleReceiptsRequest(ctx, nodeID, requestID, r)
+}
+
+// ReceiptsResponse is a response to a ReceiptsRequest
+// Receipts is slice of RLP encoded block receipts, in their consensus encoding,
+// starting with the receipts of the block requested in ReceiptsRequest.Hash.
+// The next receipts are the ones of the parent, etc.
+// handler: handlers.BlockRequestHandler
+type ReceiptsResponse struct {
+	Receipts [][]byte `serialize:"true"`
+}
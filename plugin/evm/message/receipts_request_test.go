@@ -0,0 +1,56 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMarshalReceiptsRequest asserts that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with the network.
+func TestMarshalReceiptsRequest(t *testing.T) {
+	require := require.New(t)
+
+	receiptsRequest := ReceiptsRequest{
+		Hash:    common.BytesToHash([]byte("some hash is here yo")),
+		Height:  1337,
+		Parents: 64,
+	}
+
+	base64ReceiptsRequest := "AAAAAAAAAAAAAAAAAABzb21lIGhhc2ggaXMgaGVyZSB5bwAAAAAAAAU5AEA="
+
+	receiptsRequestBytes, err := Codec.Marshal(Version, receiptsRequest)
+	require.NoError(err)
+	require.Equal(base64ReceiptsRequest, base64.StdEncoding.EncodeToString(receiptsRequestBytes))
+
+	var r ReceiptsRequest
+	_, err = Codec.Unmarshal(receiptsRequestBytes, &r)
+	require.NoError(err)
+	require.Equal(receiptsRequest, r)
+}
+
+// TestMarshalReceiptsResponse asserts that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with the network.
+func TestMarshalReceiptsResponse(t *testing.T) {
+	require := require.New(t)
+
+	receiptsResponse := ReceiptsResponse{
+		Receipts: [][]byte{{0xf9, 0x01, 0x09, 0xa0}, []byte("receipts")},
+	}
+
+	base64ReceiptsResponse := "AAAAAAACAAAABPkBCaAAAAAIcmVjZWlwdHM="
+
+	receiptsResponseBytes, err := Codec.Marshal(Version, receiptsResponse)
+	require.NoError(err)
+	require.Equal(base64ReceiptsResponse, base64.StdEncoding.EncodeToString(receiptsResponseBytes))
+
+	var r ReceiptsResponse
+	_, err = Codec.Unmarshal(receiptsResponseBytes, &r)
+	require.NoError(err)
+	require.Equal(receiptsResponse, r)
+}
@@ -36,13 +36,16 @@ func newNetworkHandler(
 	atomicTrieDB *trie.Database,
 	warpBackend warp.Backend,
 	networkCodec codec.Manager,
+	throttlerConfig syncHandlers.ThrottlerConfig,
 ) message.RequestHandler {
-	syncStats := syncStats.NewHandlerStats(metrics.Enabled)
+	handlerStats := syncStats.NewHandlerStats(metrics.Enabled)
+	// The budgets of each peer are shared by all the sync handlers.
+	throttler := syncHandlers.NewThrottler(throttlerConfig, syncStats.NewThrottlerStats(metrics.Enabled))
 	return &networkHandler{
-		stateTrieLeafsRequestHandler:  syncHandlers.NewLeafsRequestHandler(evmTrieDB, provider, networkCodec, syncStats),
-		atomicTrieLeafsRequestHandler: syncHandlers.NewLeafsRequestHandler(atomicTrieDB, nil, networkCodec, syncStats),
-		blockRequestHandler:           syncHandlers.NewBlockRequestHandler(provider, networkCodec, syncStats),
-		codeRequestHandler:            syncHandlers.NewCodeRequestHandler(diskDB, networkCodec, syncStats),
+		stateTrieLeafsRequestHandler:  syncHandlers.NewLeafsRequestHandler(evmTrieDB, provider, networkCodec, handlerStats, throttler),
+		atomicTrieLeafsRequestHandler: syncHandlers.NewLeafsRequestHandler(atomicTrieDB, nil, networkCodec, handlerStats, throttler),
+		blockRequestHandler:           syncHandlers.NewBlockRequestHandler(provider, provider, networkCodec, handlerStats, throttler),
+		codeRequestHandler:            syncHandlers.NewCodeRequestHandler(diskDB, networkCodec, handlerStats, throttler),
 		signatureRequestHandler:       warpHandlers.NewSignatureRequestHandler(warpBackend, networkCodec),
 	}
 }
@@ -59,6 +62,10 @@ func (n networkHandler) HandleBlockRequest(ctx context.Context, nodeID ids.NodeI
 	return n.blockRequestHandler.OnBlockRequest(ctx, nodeID, requestID, blockRequest)
 }
 
+func (n networkHandler) HandleReceiptsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, receiptsRequest message.ReceiptsRequest) ([]byte, error) {
+	return n.blockRequestHandler.OnReceiptsRequest(ctx, nodeID, requestID, receiptsRequest)
+}
+
 func (n networkHandler) HandleCodeRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, codeRequest message.CodeRequest) ([]byte, error) {
 	return n.codeRequestHandler.OnCodeRequest(ctx, nodeID, requestID, codeRequest)
 }
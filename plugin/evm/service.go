@@ -14,6 +14,7 @@ import (
 	"github.com/Juneo-io/juneogo/ids"
 	"github.com/Juneo-io/juneogo/utils/crypto/secp256k1"
 	"github.com/Juneo-io/juneogo/utils/formatting"
+	"github.com/Juneo-io/juneogo/utils/hashing"
 	"github.com/Juneo-io/juneogo/utils/json"
 	"github.com/Juneo-io/juneogo/utils/set"
 	"github.com/Juneo-io/jeth/params"
@@ -29,6 +30,9 @@ const (
 
 	// Max number of addresses that can be passed in as argument to GetUTXOs
 	maxGetUTXOsAddrs = 1024
+
+	// Max number of atomic txs that can be returned by GetAtomicTxsByAddress
+	maxGetAtomicTxsByAddressLimit = 1024
 )
 
 var (
@@ -37,6 +41,10 @@ var (
 	errNilTxID           = errors.New("nil transaction ID")
 	errMissingPrivateKey = errors.New("argument 'privateKey' not given")
 
+	errAtomicTxAddressIndexDisabled = errors.New("atomic tx address index is not enabled")
+	errEstimateAtomicTxType         = errors.New("exactly one of 'import' and 'export' must be given")
+	errTxAndUnsignedTx              = errors.New("only one of 'tx' and 'unsignedTx' may be given")
+
 	initialBaseFee = big.NewInt(params.ApricotPhase3InitialBaseFee)
 )
 
@@ -67,6 +75,15 @@ func (api *SnowmanAPI) IssueBlock(ctx context.Context) error {
 	return nil
 }
 
+// StateSyncAPI reports the progress of state sync
+type StateSyncAPI struct{ vm *VM }
+
+// Status returns the phase of state sync, the summary being synced to and
+// the progress of syncing the EVM state and the atomic trie
+func (api *StateSyncAPI) Status(ctx context.Context) StateSyncStatus {
+	return api.vm.StateSyncClient.StateSyncStatus()
+}
+
 // AvaxAPI offers Avalanche network related API methods
 type AvaxAPI struct{ vm *VM }
 
@@ -272,28 +289,11 @@ type ExportArgs struct {
 func (service *AvaxAPI) Export(_ *http.Request, args *ExportArgs, response *api.JSONTxID) error {
 	log.Info("EVM: Export called")
 
-	assetID, err := service.parseAssetID(args.AssetID)
+	assetID, chainID, to, err := service.parseExportArgs(args)
 	if err != nil {
 		return err
 	}
 
-	if args.Amount == 0 {
-		return errors.New("argument 'amount' must be > 0")
-	}
-
-	// Get the chainID and parse the to address
-	chainID, to, err := service.vm.ParseAddress(args.To)
-	if err != nil {
-		chainID, err = service.vm.ctx.BCLookup.Lookup(args.TargetChain)
-		if err != nil {
-			return err
-		}
-		to, err = ids.ShortFromString(args.To)
-		if err != nil {
-			return err
-		}
-	}
-
 	service.vm.ctx.Lock.Lock()
 	defer service.vm.ctx.Lock.Unlock()
 
@@ -342,6 +342,314 @@ func (service *AvaxAPI) Export(_ *http.Request, args *ExportArgs, response *api.
 	return nil
 }
 
+// EstimateAtomicTxFeeArgs are the arguments to EstimateAtomicTxFee. Exactly
+// one of Import and Export must be set. The keystore user in them is ignored.
+type EstimateAtomicTxFeeArgs struct {
+	Import *ImportArgs `json:"import"`
+	Export *ExportArgs `json:"export"`
+
+	// From are the addresses whose funds would be spent: the owners of the
+	// atomic UTXOs for an import, or the EVM addresses funding an export.
+	From []string `json:"from"`
+}
+
+// EstimateAtomicTxFeeReply defines the EstimateAtomicTxFee replies returned from the API
+type EstimateAtomicTxFeeReply struct {
+	GasUsed json.Uint64 `json:"gasUsed"`
+	// BaseFee is the base fee the estimate was computed with
+	BaseFee *hexutil.Big `json:"baseFee"`
+	// Burned is the amount of each asset burned by the tx, keyed by assetID
+	Burned map[string]json.Uint64 `json:"burned"`
+}
+
+// EstimateAtomicTxFee builds the tx Import or Export would issue with the
+// given arguments, without signing or issuing it, and returns its fee.
+func (service *AvaxAPI) EstimateAtomicTxFee(_ *http.Request, args *EstimateAtomicTxFeeArgs, reply *EstimateAtomicTxFeeReply) error {
+	log.Info("EVM: EstimateAtomicTxFee called")
+
+	if (args.Import == nil) == (args.Export == nil) {
+		return errEstimateAtomicTxType
+	}
+	if len(args.From) == 0 {
+		return errNoAddresses
+	}
+
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	var (
+		utx      UnsignedAtomicTx
+		assetIDs = set.Set[ids.ID]{}
+		baseFee  *big.Int
+		err      error
+	)
+	if args.Import != nil {
+		baseFee, err = service.baseFee(args.Import.BaseFee)
+		if err != nil {
+			return err
+		}
+		importTx, _, err := service.unsignedImportTx(args.Import, args.From, baseFee)
+		if err != nil {
+			return fmt.Errorf("couldn't create tx: %w", err)
+		}
+		for _, in := range importTx.ImportedInputs {
+			assetIDs.Add(in.AssetID())
+		}
+		utx = importTx
+	} else {
+		baseFee, err = service.baseFee(args.Export.BaseFee)
+		if err != nil {
+			return err
+		}
+		exportTx, err := service.unsignedExportTx(args.Export, args.From, baseFee)
+		if err != nil {
+			return fmt.Errorf("couldn't create tx: %w", err)
+		}
+		for _, in := range exportTx.Ins {
+			assetIDs.Add(in.AssetID)
+		}
+		utx = exportTx
+	}
+
+	tx := &Tx{UnsignedAtomicTx: utx}
+	if err := tx.Sign(service.vm.codec, nil); err != nil {
+		return err
+	}
+	gasUsed, err := tx.GasUsed(service.vm.currentRules().IsApricotPhase5)
+	if err != nil {
+		return err
+	}
+
+	reply.GasUsed = json.Uint64(gasUsed)
+	reply.BaseFee = (*hexutil.Big)(baseFee)
+	reply.Burned = make(map[string]json.Uint64, assetIDs.Len())
+	for assetID := range assetIDs {
+		burned, err := utx.Burned(assetID)
+		if err != nil {
+			return err
+		}
+		reply.Burned[assetID.String()] = json.Uint64(burned)
+	}
+	return nil
+}
+
+// BuildUnsignedImportTxArgs are the arguments to BuildUnsignedImportTx. The
+// keystore user in them is ignored.
+type BuildUnsignedImportTxArgs struct {
+	ImportArgs
+
+	// From are the owners of the atomic UTXOs to import
+	From []string `json:"from"`
+
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// BuildUnsignedExportTxArgs are the arguments to BuildUnsignedExportTx. The
+// keystore user in them is ignored.
+type BuildUnsignedExportTxArgs struct {
+	ExportArgs
+
+	// From are the EVM addresses funding the export
+	From []string `json:"from"`
+
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// TxSigner is an address that must sign an input of an unsigned tx
+type TxSigner struct {
+	Address string `json:"address"`
+	// SigHash is the hash the signature must be produced over
+	SigHash hexutil.Bytes `json:"sigHash"`
+}
+
+// BuildUnsignedTxReply defines the BuildUnsignedImportTx and
+// BuildUnsignedExportTx replies returned from the API
+type BuildUnsignedTxReply struct {
+	// UnsignedTx is the codec serialized unsigned tx
+	UnsignedTx string `json:"unsignedTx"`
+	// Signers are the signers of each input of the tx, in order. Their
+	// signatures must be passed to IssueTx in the same order.
+	Signers  [][]TxSigner        `json:"signers"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// BuildUnsignedImportTx returns the tx Import would issue with the given
+// arguments, for its signers to sign outside of the node.
+func (service *AvaxAPI) BuildUnsignedImportTx(_ *http.Request, args *BuildUnsignedImportTxArgs, reply *BuildUnsignedTxReply) error {
+	log.Info("EVM: BuildUnsignedImportTx called")
+
+	if len(args.From) == 0 {
+		return errNoAddresses
+	}
+
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	baseFee, err := service.baseFee(args.BaseFee)
+	if err != nil {
+		return err
+	}
+	utx, signerAddrs, err := service.unsignedImportTx(&args.ImportArgs, args.From, baseFee)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	signers := make([][]string, len(signerAddrs))
+	for i, addrs := range signerAddrs {
+		signers[i] = make([]string, len(addrs))
+		for j, addr := range addrs {
+			signers[i][j], err = service.vm.FormatAddress(utx.SourceChain, addr)
+			if err != nil {
+				return fmt.Errorf("problem formatting address: %w", err)
+			}
+		}
+	}
+	return service.buildUnsignedTxReply(utx, signers, args.Encoding, reply)
+}
+
+// BuildUnsignedExportTx returns the tx Export would issue with the given
+// arguments, for its signers to sign outside of the node.
+func (service *AvaxAPI) BuildUnsignedExportTx(_ *http.Request, args *BuildUnsignedExportTxArgs, reply *BuildUnsignedTxReply) error {
+	log.Info("EVM: BuildUnsignedExportTx called")
+
+	if len(args.From) == 0 {
+		return errNoAddresses
+	}
+
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	baseFee, err := service.baseFee(args.BaseFee)
+	if err != nil {
+		return err
+	}
+	utx, err := service.unsignedExportTx(&args.ExportArgs, args.From, baseFee)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	// Each input is signed by the key of its address
+	signers := make([][]string, len(utx.Ins))
+	for i, in := range utx.Ins {
+		signers[i] = []string{in.Address.Hex()}
+	}
+	return service.buildUnsignedTxReply(utx, signers, args.Encoding, reply)
+}
+
+func (service *AvaxAPI) buildUnsignedTxReply(utx UnsignedAtomicTx, signers [][]string, encoding formatting.Encoding, reply *BuildUnsignedTxReply) error {
+	tx := &Tx{UnsignedAtomicTx: utx}
+	if err := tx.Sign(service.vm.codec, nil); err != nil {
+		return err
+	}
+	unsignedBytes := tx.Bytes()
+	sigHash := hashing.ComputeHash256(unsignedBytes)
+
+	var err error
+	reply.UnsignedTx, err = formatting.Encode(encoding, unsignedBytes)
+	if err != nil {
+		return fmt.Errorf("problem encoding unsigned tx: %w", err)
+	}
+	reply.Signers = make([][]TxSigner, len(signers))
+	for i, addrs := range signers {
+		reply.Signers[i] = make([]TxSigner, len(addrs))
+		for j, addr := range addrs {
+			reply.Signers[i][j] = TxSigner{
+				Address: addr,
+				SigHash: sigHash,
+			}
+		}
+	}
+	reply.Encoding = encoding
+	return nil
+}
+
+// baseFee returns [baseFee] if it is set, or an estimate of the base fee to
+// use otherwise.
+func (service *AvaxAPI) baseFee(baseFee *hexutil.Big) (*big.Int, error) {
+	if baseFee != nil {
+		return baseFee.ToInt(), nil
+	}
+	return service.vm.estimateBaseFee(context.Background())
+}
+
+// unsignedImportTx builds the import tx described by [args], spending the
+// atomic UTXOs owned by the addresses in [from]. It returns the addresses that
+// must sign each of the tx's inputs.
+func (service *AvaxAPI) unsignedImportTx(args *ImportArgs, from []string, baseFee *big.Int) (*UnsignedImportTx, [][]ids.ShortID, error) {
+	chainID, err := service.vm.ctx.BCLookup.Lookup(args.SourceChain)
+	if err != nil {
+		return nil, nil, fmt.Errorf("problem parsing chainID %q: %w", args.SourceChain, err)
+	}
+
+	addrs := set.NewSet[ids.ShortID](len(from))
+	for _, addrStr := range from {
+		addr, err := service.parseShortAddress(addrStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+		}
+		addrs.Add(addr)
+	}
+
+	atomicUTXOs, _, _, err := service.vm.GetAtomicUTXOs(chainID, addrs, ids.ShortEmpty, ids.Empty, -1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("problem retrieving atomic UTXOs: %w", err)
+	}
+	return service.vm.newUnsignedImportTxWithUTXOs(chainID, args.To, baseFee, addrs, atomicUTXOs)
+}
+
+// unsignedExportTx builds the export tx described by [args], funded by the
+// EVM addresses in [from].
+func (service *AvaxAPI) unsignedExportTx(args *ExportArgs, from []string, baseFee *big.Int) (*UnsignedExportTx, error) {
+	assetID, chainID, to, err := service.parseExportArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]common.Address, 0, len(from))
+	seen := set.NewSet[common.Address](len(from))
+	for _, addrStr := range from {
+		if !common.IsHexAddress(addrStr) {
+			return nil, fmt.Errorf("couldn't parse address %q: expected hex EVM address", addrStr)
+		}
+		addr := common.HexToAddress(addrStr)
+		// Each address may only fund a single input per asset
+		if seen.Contains(addr) {
+			continue
+		}
+		seen.Add(addr)
+		addrs = append(addrs, addr)
+	}
+
+	return service.vm.newUnsignedExportTx(assetID, uint64(args.Amount), chainID, to, baseFee, addrs)
+}
+
+// parseExportArgs returns the asset, destination chain and recipient of the
+// export described by [args].
+func (service *AvaxAPI) parseExportArgs(args *ExportArgs) (ids.ID, ids.ID, ids.ShortID, error) {
+	assetID, err := service.parseAssetID(args.AssetID)
+	if err != nil {
+		return ids.Empty, ids.Empty, ids.ShortEmpty, err
+	}
+
+	if args.Amount == 0 {
+		return ids.Empty, ids.Empty, ids.ShortEmpty, errors.New("argument 'amount' must be > 0")
+	}
+
+	// Get the chainID and parse the to address
+	chainID, to, err := service.vm.ParseAddress(args.To)
+	if err != nil {
+		chainID, err = service.vm.ctx.BCLookup.Lookup(args.TargetChain)
+		if err != nil {
+			return ids.Empty, ids.Empty, ids.ShortEmpty, err
+		}
+		to, err = ids.ShortFromString(args.To)
+		if err != nil {
+			return ids.Empty, ids.Empty, ids.ShortEmpty, err
+		}
+	}
+	return assetID, chainID, to, nil
+}
+
 // GetUTXOs gets all utxos for passed in addresses
 func (service *AvaxAPI) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply *api.GetUTXOsReply) error {
 	log.Info("EVM: GetUTXOs called", "Addresses", args.Addresses)
@@ -424,20 +732,42 @@ func (service *AvaxAPI) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply
 	return nil
 }
 
-func (service *AvaxAPI) IssueTx(r *http.Request, args *api.FormattedTx, response *api.JSONTxID) error {
+// IssueTxArgs are the arguments to IssueTx
+type IssueTxArgs struct {
+	api.FormattedTx
+
+	// UnsignedTx, returned by BuildUnsignedImportTx or BuildUnsignedExportTx,
+	// may be given instead of Tx along with the signatures of each of its
+	// inputs, in order.
+	UnsignedTx string     `json:"unsignedTx"`
+	Signatures [][]string `json:"signatures"`
+}
+
+func (service *AvaxAPI) IssueTx(r *http.Request, args *IssueTxArgs, response *api.JSONTxID) error {
 	log.Info("EVM: IssueTx called")
 
-	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
-	if err != nil {
-		return fmt.Errorf("problem decoding transaction: %w", err)
-	}
+	var (
+		tx  *Tx
+		err error
+	)
+	if args.UnsignedTx != "" {
+		tx, err = service.parseExternallySignedTx(args)
+		if err != nil {
+			return err
+		}
+	} else {
+		txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+		if err != nil {
+			return fmt.Errorf("problem decoding transaction: %w", err)
+		}
 
-	tx := &Tx{}
-	if _, err := service.vm.codec.Unmarshal(txBytes, tx); err != nil {
-		return fmt.Errorf("problem parsing transaction: %w", err)
-	}
-	if err := tx.Sign(service.vm.codec, nil); err != nil {
-		return fmt.Errorf("problem initializing transaction: %w", err)
+		tx = &Tx{}
+		if _, err := service.vm.codec.Unmarshal(txBytes, tx); err != nil {
+			return fmt.Errorf("problem parsing transaction: %w", err)
+		}
+		if err := tx.Sign(service.vm.codec, nil); err != nil {
+			return fmt.Errorf("problem initializing transaction: %w", err)
+		}
 	}
 
 	response.TxID = tx.ID()
@@ -452,6 +782,39 @@ func (service *AvaxAPI) IssueTx(r *http.Request, args *api.FormattedTx, response
 	return nil
 }
 
+// parseExternallySignedTx returns the tx made of the unsigned tx and
+// signatures in [args]
+func (service *AvaxAPI) parseExternallySignedTx(args *IssueTxArgs) (*Tx, error) {
+	if args.Tx != "" {
+		return nil, errTxAndUnsignedTx
+	}
+	unsignedBytes, err := formatting.Decode(args.Encoding, args.UnsignedTx)
+	if err != nil {
+		return nil, fmt.Errorf("problem decoding unsigned transaction: %w", err)
+	}
+
+	sigs := make([][][secp256k1.SignatureLen]byte, len(args.Signatures))
+	for i, inputSigs := range args.Signatures {
+		sigs[i] = make([][secp256k1.SignatureLen]byte, len(inputSigs))
+		for j, sigStr := range inputSigs {
+			sig, err := formatting.Decode(args.Encoding, sigStr)
+			if err != nil {
+				return nil, fmt.Errorf("problem decoding signature %d of input %d: %w", j, i, err)
+			}
+			if len(sig) != secp256k1.SignatureLen {
+				return nil, fmt.Errorf("signature %d of input %d has length %d, expected %d", j, i, len(sig), secp256k1.SignatureLen)
+			}
+			copy(sigs[i][j][:], sig)
+		}
+	}
+
+	tx, err := newTxWithSignatures(service.vm.codec, unsignedBytes, sigs)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing unsigned transaction: %w", err)
+	}
+	return tx, nil
+}
+
 // GetAtomicTxStatusReply defines the GetAtomicTxStatus replies returned from the API
 type GetAtomicTxStatusReply struct {
 	Status      Status       `json:"status"`
@@ -533,3 +896,205 @@ func (service *AvaxAPI) GetAtomicTx(r *http.Request, args *api.GetTxArgs, reply
 	}
 	return nil
 }
+
+// GetAtomicTxsByAddressArgs are the arguments for GetAtomicTxsByAddress
+type GetAtomicTxsByAddressArgs struct {
+	// Address is either a hex EVM address or a short address of a
+	// source/destination chain, optionally chain-prefixed.
+	Address string `json:"address"`
+	// StartIndex is the position to resume from. When its TxID is set,
+	// the entry it points to is excluded from the reply.
+	StartIndex AtomicTxIndex       `json:"startIndex"`
+	Limit      json.Uint32         `json:"limit"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+// AtomicTxIndex is a position in the atomic tx address index
+type AtomicTxIndex struct {
+	Height json.Uint64 `json:"height"`
+	TxID   ids.ID      `json:"txID"`
+}
+
+// IndexedAtomicTx is an accepted atomic tx returned by GetAtomicTxsByAddress
+type IndexedAtomicTx struct {
+	TxID        ids.ID      `json:"txID"`
+	Tx          string      `json:"tx"`
+	BlockHeight json.Uint64 `json:"blockHeight"`
+}
+
+// GetAtomicTxsByAddressReply defines the GetAtomicTxsByAddress replies returned from the API
+type GetAtomicTxsByAddressReply struct {
+	Txs        []IndexedAtomicTx   `json:"txs"`
+	NumFetched json.Uint64         `json:"numFetched"`
+	EndIndex   AtomicTxIndex       `json:"endIndex"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+// GetAtomicTxsByAddress returns the accepted atomic txs touching the given
+// address, ordered by block height.
+func (service *AvaxAPI) GetAtomicTxsByAddress(r *http.Request, args *GetAtomicTxsByAddressArgs, reply *GetAtomicTxsByAddressReply) error {
+	log.Info("EVM: GetAtomicTxsByAddress called", "address", args.Address)
+
+	if service.vm.atomicTxAddressIndex == nil {
+		return errAtomicTxAddressIndexDisabled
+	}
+	if args.Address == "" {
+		return errNoAddresses
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || limit > maxGetAtomicTxsByAddressLimit {
+		limit = maxGetAtomicTxsByAddressLimit
+	}
+	startHeight := uint64(args.StartIndex.Height)
+	startTxID := args.StartIndex.TxID
+
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	var (
+		entries []AtomicTxAddressEntry
+		err     error
+	)
+	if common.IsHexAddress(args.Address) {
+		entries, err = service.vm.atomicTxAddressIndex.GetByEVMAddress(common.HexToAddress(args.Address), startHeight, startTxID, limit)
+	} else {
+		var addr ids.ShortID
+		addr, err = service.parseShortAddress(args.Address)
+		if err != nil {
+			return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+		}
+		entries, err = service.vm.atomicTxAddressIndex.GetByShortAddress(addr, startHeight, startTxID, limit)
+	}
+	if err != nil {
+		return fmt.Errorf("problem retrieving atomic txs: %w", err)
+	}
+
+	// Since chain state updates run asynchronously with VM block acceptance,
+	// avoid returning txs above the block the chain state has reached.
+	lastAccepted := service.vm.blockChain.LastAcceptedBlock().NumberU64()
+
+	reply.Txs = make([]IndexedAtomicTx, 0, len(entries))
+	reply.EndIndex = args.StartIndex
+	for _, entry := range entries {
+		if entry.Height > lastAccepted {
+			break
+		}
+		tx, _, err := service.vm.atomicTxRepository.GetByTxID(entry.TxID)
+		if err != nil {
+			return fmt.Errorf("problem retrieving atomic tx %s: %w", entry.TxID, err)
+		}
+		txBytes, err := formatting.Encode(args.Encoding, tx.SignedBytes())
+		if err != nil {
+			return fmt.Errorf("problem encoding atomic tx: %w", err)
+		}
+		reply.Txs = append(reply.Txs, IndexedAtomicTx{
+			TxID:        entry.TxID,
+			Tx:          txBytes,
+			BlockHeight: json.Uint64(entry.Height),
+		})
+		reply.EndIndex = AtomicTxIndex{
+			Height: json.Uint64(entry.Height),
+			TxID:   entry.TxID,
+		}
+	}
+	reply.NumFetched = json.Uint64(len(reply.Txs))
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+// parseShortAddress parses a short address, which may be prefixed by the alias
+// of any chain (e.g. the source chain of an import).
+func (service *AvaxAPI) parseShortAddress(addrStr string) (ids.ShortID, error) {
+	if addr, err := ids.ShortFromString(addrStr); err == nil {
+		return addr, nil
+	}
+	_, addr, err := service.vm.ParseAddress(addrStr)
+	return addr, err
+}
+
+// PendingMempoolTx is a pending atomic tx returned by GetMempoolTxs
+type PendingMempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// GasPrice is the fee per gas paid by the tx
+	GasPrice json.Uint64 `json:"gasPrice"`
+	// Rank is the position the tx will be issued in, starting at 0
+	Rank json.Uint64 `json:"rank"`
+}
+
+// DiscardedMempoolTx is a recently discarded atomic tx returned by GetMempoolTxs
+type DiscardedMempoolTx struct {
+	TxID   ids.ID `json:"txID"`
+	Reason string `json:"reason"`
+}
+
+// GetMempoolTxsReply defines the GetMempoolTxs replies returned from the API
+type GetMempoolTxsReply struct {
+	Pending   []PendingMempoolTx   `json:"pending"`
+	Current   []ids.ID             `json:"current"`
+	Issued    []ids.ID             `json:"issued"`
+	Discarded []DiscardedMempoolTx `json:"discarded"`
+}
+
+// GetMempoolTxs returns the atomic txs held by the mempool
+func (service *AvaxAPI) GetMempoolTxs(r *http.Request, _ *struct{}, reply *GetMempoolTxsReply) error {
+	log.Info("EVM: GetMempoolTxs called")
+
+	mempool := service.vm.mempool
+	pendingTxs := mempool.PendingTxs()
+	reply.Pending = make([]PendingMempoolTx, len(pendingTxs))
+	for i, pendingTx := range pendingTxs {
+		reply.Pending[i] = PendingMempoolTx{
+			TxID:     pendingTx.Tx.ID(),
+			GasPrice: json.Uint64(pendingTx.GasPrice),
+			Rank:     json.Uint64(i),
+		}
+	}
+	for _, tx := range mempool.CurrentTxs() {
+		reply.Current = append(reply.Current, tx.ID())
+	}
+	for _, tx := range mempool.IssuedTxs() {
+		reply.Issued = append(reply.Issued, tx.ID())
+	}
+	for _, discardedTx := range mempool.DiscardedTxs() {
+		reply.Discarded = append(reply.Discarded, DiscardedMempoolTx{
+			TxID:   discardedTx.TxID,
+			Reason: discardedTx.Reason,
+		})
+	}
+	return nil
+}
+
+// GetMempoolStatsReply defines the GetMempoolStats replies returned from the API
+type GetMempoolStatsReply struct {
+	NumPending   json.Uint64 `json:"numPending"`
+	NumCurrent   json.Uint64 `json:"numCurrent"`
+	NumIssued    json.Uint64 `json:"numIssued"`
+	NumDiscarded json.Uint64 `json:"numDiscarded"`
+	MaxSize      json.Uint64 `json:"maxSize"`
+	// MinGasPrice and MaxGasPrice are the bounds of the fee per gas paid
+	// by pending txs. They are omitted if there are no pending txs.
+	MinGasPrice *json.Uint64 `json:"minGasPrice,omitempty"`
+	MaxGasPrice *json.Uint64 `json:"maxGasPrice,omitempty"`
+}
+
+// GetMempoolStats returns statistics about the atomic mempool
+func (service *AvaxAPI) GetMempoolStats(r *http.Request, _ *struct{}, reply *GetMempoolStatsReply) error {
+	log.Info("EVM: GetMempoolStats called")
+
+	mempool := service.vm.mempool
+	pendingTxs := mempool.PendingTxs()
+	reply.NumPending = json.Uint64(len(pendingTxs))
+	reply.NumCurrent = json.Uint64(len(mempool.CurrentTxs()))
+	reply.NumIssued = json.Uint64(len(mempool.IssuedTxs()))
+	reply.NumDiscarded = json.Uint64(len(mempool.DiscardedTxs()))
+	reply.MaxSize = json.Uint64(mempool.maxSize)
+	if len(pendingTxs) > 0 {
+		// [pendingTxs] is sorted by descending gas price
+		maxGasPrice := json.Uint64(pendingTxs[0].GasPrice)
+		minGasPrice := json.Uint64(pendingTxs[len(pendingTxs)-1].GasPrice)
+		reply.MaxGasPrice = &maxGasPrice
+		reply.MinGasPrice = &minGasPrice
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Juneo-io/juneogo/snow/engine/snowman/block"
+
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/core/state/pruner"
+	"github.com/Juneo-io/jeth/core/state/snapshot"
+	"github.com/Juneo-io/jeth/core/types"
+	"github.com/Juneo-io/jeth/plugin/evm/message"
+	"github.com/Juneo-io/jeth/sync/statefile"
+	"github.com/Juneo-io/jeth/sync/statesync"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	errStateFileSummaryTooLow = errors.New("state file summary is not above the last accepted block")
+	errStateFileExportPruning = errors.New("cannot export a state file while online pruning is in progress")
+	errOnlinePruningExporting = errors.New("cannot start online pruning while a state file is exported")
+)
+
+// exportStateFile writes the blocks, the EVM state and the atomic trie of the
+// state summary at [height], or of the last state summary if [height] is 0, to
+// a new state file at [path]. It returns the summary and the checksum of the
+// state file.
+func (vm *VM) exportStateFile(ctx context.Context, path string, height uint64) (message.SyncSummary, common.Hash, error) {
+	summary, err := vm.exportableSummary(ctx, height)
+	if err != nil {
+		return message.SyncSummary{}, common.Hash{}, err
+	}
+	defer vm.finishStateFileExport()
+	log.Info("Exporting state file", "path", path, "summary", summary)
+
+	w, err := statefile.Create(path, summary.Bytes())
+	if err != nil {
+		return message.SyncSummary{}, common.Hash{}, err
+	}
+	if err := vm.writeStateFile(w, summary); err != nil {
+		w.Abort()
+		return message.SyncSummary{}, common.Hash{}, err
+	}
+	checksum, err := w.Close()
+	if err != nil {
+		w.Abort()
+		return message.SyncSummary{}, common.Hash{}, err
+	}
+	log.Info("Exported state file", "path", path, "summary", summary, "checksum", checksum)
+	return summary, checksum, nil
+}
+
+// exportableSummary returns the state summary at [height], or the last state
+// summary if [height] is 0, and records the export so online pruning, which
+// could delete the nodes of the summary state, cannot start until
+// [finishStateFileExport] is called.
+func (vm *VM) exportableSummary(ctx context.Context, height uint64) (message.SyncSummary, error) {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	if status, err := vm.blockChain.OnlinePruningStatus(); err == nil && status.Phase != pruner.OnlinePhaseIdle.String() {
+		return message.SyncSummary{}, errStateFileExportPruning
+	}
+	var (
+		stateSummary block.StateSummary
+		err          error
+	)
+	if height == 0 {
+		stateSummary, err = vm.StateSyncServer.GetLastStateSummary(ctx)
+	} else {
+		stateSummary, err = vm.StateSyncServer.GetStateSummary(ctx, height)
+	}
+	if err != nil {
+		return message.SyncSummary{}, fmt.Errorf("no state summary available at height %d: %w", height, err)
+	}
+	summary, ok := stateSummary.(message.SyncSummary)
+	if !ok {
+		return message.SyncSummary{}, fmt.Errorf("unexpected state summary type %T", stateSummary)
+	}
+	vm.stateFileExports++
+	return summary, nil
+}
+
+// finishStateFileExport records the end of an export started by
+// [exportableSummary].
+func (vm *VM) finishStateFileExport() {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	vm.stateFileExports--
+}
+
+// writeStateFile writes the summary block and [parentsToGet] of its parents,
+// the EVM state and the atomic trie of [summary] to [w].
+func (vm *VM) writeStateFile(w *statefile.Writer, summary message.SyncSummary) error {
+	nextHash, nextHeight := summary.BlockHash, summary.BlockNumber
+	for i := 0; i <= parentsToGet && nextHash != (common.Hash{}); i++ {
+		block := vm.blockChain.GetBlock(nextHash, nextHeight)
+		if block == nil {
+			return fmt.Errorf("missing block %s at height %d", nextHash, nextHeight)
+		}
+		blockBytes, err := rlp.EncodeToBytes(block)
+		if err != nil {
+			return err
+		}
+		if err := w.WriteBlock(blockBytes); err != nil {
+			return err
+		}
+		nextHash, nextHeight = block.ParentHash(), nextHeight-1
+	}
+
+	if err := statesync.ExportState(w, vm.chaindb, vm.blockChain.TrieDB(), summary.BlockRoot); err != nil {
+		return fmt.Errorf("failed to export state at root %s: %w", summary.BlockRoot, err)
+	}
+
+	it, err := vm.atomicTrie.Iterator(summary.AtomicRoot, nil)
+	if err != nil {
+		return err
+	}
+	for it.Next() {
+		if err := w.WriteAtomic(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return fmt.Errorf("failed to export atomic trie at root %s: %w", summary.AtomicRoot, err)
+	}
+	return nil
+}
+
+// ImportStateFile bootstraps the chain from the state file at [path]. The
+// checksum of the file is verified to be [checksum] before anything is written,
+// since the atomic root of the summary is not committed to by any block. Then
+// the imported state and atomic trie are verified against the roots of the
+// summary before the summary block is set as the last accepted block, as done
+// at the end of state sync.
+func (client *stateSyncerClient) ImportStateFile(path string, checksum common.Hash) error {
+	log.Info("Verifying state file", "path", path, "checksum", checksum)
+	if err := statefile.Verify(path, checksum); err != nil {
+		return fmt.Errorf("invalid state file %s: %w", path, err)
+	}
+	r, err := statefile.Open(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	summary, err := message.NewSyncSummaryFromBytes(r.Summary(), client.acceptSyncSummary)
+	if err != nil {
+		return fmt.Errorf("failed to parse state file summary: %w", err)
+	}
+	if summary.BlockNumber <= client.lastAcceptedHeight {
+		return fmt.Errorf("%w: (%d <= %d)", errStateFileSummaryTooLow, summary.BlockNumber, client.lastAcceptedHeight)
+	}
+	log.Info("Importing state file", "path", path, "summary", summary)
+
+	// Wipe the snapshot and reset its generator, as when starting state sync.
+	<-snapshot.WipeSnapshot(client.chaindb, true)
+	snapshot.ResetSnapshotGeneration(client.chaindb)
+	client.setSummary(summary, stateSyncPhaseImporting)
+
+	if err := client.importStateFile(r, checksum); err != nil {
+		return err
+	}
+	if err := client.finishSync(); err != nil {
+		return err
+	}
+
+	// The chain is now past the summary, so state sync must not run.
+	client.lastAcceptedHeight = summary.BlockNumber
+	client.enabled = false
+	client.setDone(nil)
+	log.Info("Imported state file", "path", path, "summary", summary)
+	return nil
+}
+
+// importStateFile writes the records of [r] to disk and verifies them against
+// [client.syncSummary] and [checksum].
+func (client *stateSyncerClient) importStateFile(r *statefile.Reader, checksum common.Hash) error {
+	summary := client.syncSummary
+	stateImporter := statesync.NewStateImporter(client.chaindb, client.chain.BlockChain().TrieDB().Scheme(), ethdb.IdealBatchSize)
+	atomicImporter, err := client.atomicBackend.Importer(summary.AtomicRoot, summary.BlockNumber)
+	if err != nil {
+		return err
+	}
+
+	var (
+		batch      = client.chaindb.NewBatch()
+		nextHash   = summary.BlockHash
+		nextHeight = summary.BlockNumber
+		blocks     int
+		accounts   int
+	)
+	for {
+		record, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch record.Kind {
+		case statefile.BlockKind:
+			block := new(types.Block)
+			if err := rlp.DecodeBytes(record.Value, block); err != nil {
+				return fmt.Errorf("could not decode state file block: %w", err)
+			}
+			if block.Hash() != nextHash || block.NumberU64() != nextHeight {
+				return fmt.Errorf("unexpected state file block %s at height %d, expected %s at height %d", block.Hash(), block.NumberU64(), nextHash, nextHeight)
+			}
+			if blocks == 0 && block.Root() != summary.BlockRoot {
+				return fmt.Errorf("summary block root mismatch: (%s != %s)", block.Root(), summary.BlockRoot)
+			}
+			rawdb.WriteBlock(batch, block)
+			rawdb.WriteCanonicalHash(batch, block.Hash(), block.NumberU64())
+			nextHash, nextHeight = block.ParentHash(), nextHeight-1
+			blocks++
+		case statefile.AccountKind:
+			err = stateImporter.AddAccount(common.BytesToHash(record.Key), record.Value)
+			accounts++
+			if accounts%100_000 == 0 {
+				log.Info("Importing state file", "accounts", accounts)
+			}
+		case statefile.StorageKind:
+			err = stateImporter.AddStorage(common.BytesToHash(record.Key), record.Value)
+		case statefile.CodeKind:
+			err = stateImporter.AddCode(common.BytesToHash(record.Key), record.Value)
+		case statefile.AtomicKind:
+			err = atomicImporter.AddLeafs([][]byte{record.Key}, [][]byte{record.Value})
+		default:
+			err = fmt.Errorf("unexpected state file record kind %s", record.Kind)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// The file may have been replaced since it was verified.
+	if r.Checksum() != checksum {
+		return fmt.Errorf("%w: (%s != %s)", statefile.ErrUnexpectedChecksum, r.Checksum(), checksum)
+	}
+	if blocks == 0 {
+		return fmt.Errorf("state file has no block for summary %s", summary.BlockHash)
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	if err := stateImporter.Finish(summary.BlockRoot); err != nil {
+		return err
+	}
+	log.Info("Imported EVM state", "root", summary.BlockRoot, "accounts", accounts, "blocks", blocks)
+	return atomicImporter.Finish()
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/Juneo-io/juneogo/vms/components/chain"
 	"github.com/Juneo-io/jeth/core/rawdb"
 	"github.com/Juneo-io/jeth/core/state/snapshot"
+	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/eth"
 	"github.com/Juneo-io/jeth/params"
 	"github.com/Juneo-io/jeth/plugin/evm/message"
@@ -33,6 +34,20 @@ const (
 	parentsToGet = 256
 )
 
+// State sync phases reported by [StateSyncStatus].
+const (
+	stateSyncPhaseDisabled   = "disabled"
+	stateSyncPhaseWaiting    = "waitingForSummary"
+	stateSyncPhaseSkipped    = "skipped"
+	stateSyncPhaseBlocks     = "syncingBlocks"
+	stateSyncPhaseState      = "syncingState"
+	stateSyncPhaseAtomicTrie = "syncingAtomicTrie"
+	stateSyncPhaseFinishing  = "finishing"
+	stateSyncPhaseImporting  = "importingStateFile"
+	stateSyncPhaseDone       = "done"
+	stateSyncPhaseFailed     = "failed"
+)
+
 var stateSyncSummaryKey = []byte("stateSyncSummary")
 
 // stateSyncClientConfig defines the options and dependencies needed to construct a StateSyncerClient
@@ -44,6 +59,12 @@ type stateSyncClientConfig struct {
 	// algorithm.
 	stateSyncMinBlocks   uint64
 	stateSyncRequestSize uint16 // number of key/value pairs to ask peers for per request
+	// Specifies whether the blocks below the block synced to, and their receipts, are
+	// fetched from peers in the background once the node is in normal operation.
+	backfillEnabled bool
+	// Returns the synthetic logs of a backfilled block which can be derived
+	// without executing it.
+	syntheticLogs func(*types.Block) ([]*types.Log, error)
 
 	lastAcceptedHeight uint64
 
@@ -52,6 +73,7 @@ type stateSyncClientConfig struct {
 	chaindb         ethdb.Database
 	metadataDB      database.Database
 	acceptedBlockDB database.Database
+	backfillDB      database.Database // Not part of [db], so it can be written by the backfiller without committing [db]
 	db              *versiondb.Database
 	atomicBackend   AtomicBackend
 
@@ -71,11 +93,37 @@ type stateSyncerClient struct {
 	// State Sync results
 	syncSummary  message.SyncSummary
 	stateSyncErr error
+
+	// Progress reported by [StateSyncStatus]. [syncSummary] is written
+	// while holding [statusLock].
+	statusLock     sync.RWMutex
+	phase          string
+	phaseErr       error
+	stateProgress  func() statesync.Progress
+	atomicProgress func() AtomicSyncProgress
+}
+
+// StateSyncStatus is a snapshot of the progress of state sync.
+type StateSyncStatus struct {
+	Phase   string               `json:"phase"`
+	Summary *message.SyncSummary `json:"summary,omitempty"`
+	State   *statesync.Progress  `json:"state,omitempty"`
+	Atomic  *AtomicSyncProgress  `json:"atomic,omitempty"`
+
+	// ETASeconds is the estimated time left to sync the EVM state or the
+	// atomic trie, whichever is syncing, or 0 if unknown.
+	ETASeconds uint64 `json:"etaSeconds"`
+	Error      string `json:"error,omitempty"`
 }
 
 func NewStateSyncClient(config *stateSyncClientConfig) StateSyncClient {
+	phase := stateSyncPhaseWaiting
+	if !config.enabled {
+		phase = stateSyncPhaseDisabled
+	}
 	return &stateSyncerClient{
 		stateSyncClientConfig: config,
+		phase:                 phase,
 	}
 }
 
@@ -87,6 +135,10 @@ type StateSyncClient interface {
 
 	// additional methods required by the evm package
 	StateSyncClearOngoingSummary() error
+	ImportStateFile(path string, checksum common.Hash) error
+	StartBlockBackfill()
+	SkipStateSyncIfWaiting()
+	StateSyncStatus() StateSyncStatus
 	Shutdown() error
 	Error() error
 }
@@ -179,6 +231,7 @@ func (client *stateSyncerClient) acceptSyncSummary(proposedSummary message.SyncS
 			// Initialize snapshots if we're skipping state sync, since it will not have been initialized on
 			// startup.
 			client.chain.BlockChain().InitializeSnapshots()
+			client.setPhase(stateSyncPhaseSkipped)
 			return block.StateSyncSkipped, nil
 		}
 
@@ -194,7 +247,7 @@ func (client *stateSyncerClient) acceptSyncSummary(proposedSummary message.SyncS
 		// Note: this must be called after WipeSnapshot is called so that we do not invalidate a partially generated snapshot.
 		snapshot.ResetSnapshotGeneration(client.chaindb)
 	}
-	client.syncSummary = proposedSummary
+	client.setSummary(proposedSummary, stateSyncPhaseBlocks)
 
 	// Update the current state sync summary key in the database
 	// Note: this must be performed after WipeSnapshot finishes so that we do not start a state sync
@@ -219,8 +272,10 @@ func (client *stateSyncerClient) acceptSyncSummary(proposedSummary message.SyncS
 		if err := client.stateSync(ctx); err != nil {
 			client.stateSyncErr = err
 		} else {
+			client.setPhase(stateSyncPhaseFinishing)
 			client.stateSyncErr = client.finishSync()
 		}
+		client.setDone(client.stateSyncErr)
 		// notify engine regardless of whether err == nil,
 		// this error will be propagated to the engine when it calls
 		// vm.SetState(snow.Bootstrapping)
@@ -290,6 +345,10 @@ func (client *stateSyncerClient) syncAtomicTrie(ctx context.Context) error {
 	if err := atomicSyncer.Start(ctx); err != nil {
 		return err
 	}
+	client.statusLock.Lock()
+	client.phase = stateSyncPhaseAtomicTrie
+	client.atomicProgress = atomicSyncer.Progress
+	client.statusLock.Unlock()
 	err = <-atomicSyncer.Done()
 	log.Info("atomic tx: sync finished", "root", client.syncSummary.AtomicRoot, "err", err)
 	return err
@@ -305,6 +364,7 @@ func (client *stateSyncerClient) syncStateTrie(ctx context.Context) error {
 		MaxOutstandingCodeHashes: statesync.DefaultMaxOutstandingCodeHashes,
 		NumCodeFetchingWorkers:   statesync.DefaultNumCodeFetchingWorkers,
 		RequestSize:              client.stateSyncRequestSize,
+		Scheme:                   client.chain.BlockChain().TrieDB().Scheme(),
 	})
 	if err != nil {
 		return err
@@ -312,6 +372,10 @@ func (client *stateSyncerClient) syncStateTrie(ctx context.Context) error {
 	if err := evmSyncer.Start(ctx); err != nil {
 		return err
 	}
+	client.statusLock.Lock()
+	client.phase = stateSyncPhaseState
+	client.stateProgress = evmSyncer.Progress
+	client.statusLock.Unlock()
 	err = <-evmSyncer.Done()
 	log.Info("state sync: sync finished", "root", client.syncSummary.BlockRoot, "err", err)
 	return err
@@ -391,7 +455,12 @@ func (client *stateSyncerClient) finishSync() error {
 // - updates atomic trie so it will resume applying operations to shared memory on initialize
 // - updates lastAcceptedKey
 // - removes state sync progress markers
+// The block backfill marker is set to the block synced to beforehand, outside of
+// the VM's database.
 func (client *stateSyncerClient) updateVMMarkers() error {
+	if err := writeBlockBackfillMarker(client.backfillDB, client.syncSummary.BlockHash, client.syncSummary.BlockNumber); err != nil {
+		return err
+	}
 	// Mark the previously last accepted block for the shared memory cursor, so that we will execute shared
 	// memory operations from the previously last accepted block to [vm.syncSummary] when ApplyToSharedMemory
 	// is called.
@@ -408,5 +477,110 @@ func (client *stateSyncerClient) updateVMMarkers() error {
 	return client.db.Commit()
 }
 
+// StartBlockBackfill starts fetching the blocks below the block the chain was
+// state synced to, and their receipts, in the background if enabled.
+// Backfilling stops on [Shutdown] and resumes when it is started again.
+func (client *stateSyncerClient) StartBlockBackfill() {
+	if !client.backfillEnabled {
+		return
+	}
+	bloomSections, _, _ := client.chain.BloomIndexer().Sections()
+	backfiller := &blockBackfiller{
+		client:           client.client,
+		chaindb:          client.chaindb,
+		backfillDB:       client.backfillDB,
+		syntheticLogs:    client.syntheticLogs,
+		bloomSectionSize: params.BloomBitsBlocks,
+		bloomSections:    bloomSections,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client.cancel = cancel
+	client.wg.Add(1)
+	go func() {
+		defer client.wg.Done()
+		defer cancel()
+
+		if err := backfiller.backfill(ctx); err != nil && ctx.Err() == nil {
+			log.Error("block backfill failed", "err", err)
+		}
+	}()
+}
+
+// SkipStateSyncIfWaiting reports state sync as skipped if no summary was
+// accepted yet. It is called once the engine moves on to bootstrapping or
+// normal operation, which it does without accepting a summary if no peer
+// offered one.
+func (client *stateSyncerClient) SkipStateSyncIfWaiting() {
+	client.statusLock.Lock()
+	defer client.statusLock.Unlock()
+
+	if client.phase == stateSyncPhaseWaiting {
+		client.phase = stateSyncPhaseSkipped
+	}
+}
+
+// setSummary sets the summary being synced to and the phase reported by
+// [StateSyncStatus].
+func (client *stateSyncerClient) setSummary(summary message.SyncSummary, phase string) {
+	client.statusLock.Lock()
+	defer client.statusLock.Unlock()
+
+	client.syncSummary = summary
+	client.phase = phase
+}
+
+// setPhase sets the phase reported by [StateSyncStatus].
+func (client *stateSyncerClient) setPhase(phase string) {
+	client.statusLock.Lock()
+	defer client.statusLock.Unlock()
+
+	client.phase = phase
+}
+
+// setDone sets the phase reported by [StateSyncStatus] to done, or to failed
+// if [err] is non-nil.
+func (client *stateSyncerClient) setDone(err error) {
+	client.statusLock.Lock()
+	defer client.statusLock.Unlock()
+
+	client.phase, client.phaseErr = stateSyncPhaseDone, err
+	if err != nil {
+		client.phase = stateSyncPhaseFailed
+	}
+}
+
+// StateSyncStatus returns a snapshot of the progress of state sync. The
+// progress of the EVM state and of the atomic trie is kept once they are
+// synced.
+func (client *stateSyncerClient) StateSyncStatus() StateSyncStatus {
+	client.statusLock.RLock()
+	defer client.statusLock.RUnlock()
+
+	status := StateSyncStatus{Phase: client.phase}
+	if client.syncSummary.BlockHash != (common.Hash{}) {
+		summary := client.syncSummary
+		status.Summary = &summary
+	}
+	if client.stateProgress != nil {
+		progress := client.stateProgress()
+		status.State = &progress
+	}
+	if client.atomicProgress != nil {
+		progress := client.atomicProgress()
+		status.Atomic = &progress
+	}
+	switch client.phase {
+	case stateSyncPhaseState:
+		status.ETASeconds = status.State.ETASeconds
+	case stateSyncPhaseAtomicTrie:
+		status.ETASeconds = status.Atomic.ETASeconds
+	}
+	if client.phaseErr != nil {
+		status.Error = client.phaseErr.Error()
+	}
+	return status
+}
+
 // Error returns a non-nil error if one occurred during the sync.
 func (client *stateSyncerClient) Error() error { return client.stateSyncErr }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -37,6 +38,7 @@ import (
 	"github.com/Juneo-io/jeth/params"
 	"github.com/Juneo-io/jeth/predicate"
 	statesyncclient "github.com/Juneo-io/jeth/sync/client"
+	"github.com/Juneo-io/jeth/sync/statefile"
 	"github.com/Juneo-io/jeth/sync/statesync"
 	"github.com/Juneo-io/jeth/trie"
 	"github.com/ethereum/go-ethereum/common"
@@ -57,6 +59,26 @@ func TestSkipStateSync(t *testing.T) {
 	testSyncerVM(t, vmSetup, test)
 }
 
+func TestStateSyncStatusWithoutSummary(t *testing.T) {
+	require := require.New(t)
+
+	// The engine moves on to bootstrapping without accepting a summary if no
+	// peer offered one.
+	client := NewStateSyncClient(&stateSyncClientConfig{enabled: true})
+	require.Equal(stateSyncPhaseWaiting, client.StateSyncStatus().Phase)
+	client.SkipStateSyncIfWaiting()
+	require.Equal(stateSyncPhaseSkipped, client.StateSyncStatus().Phase)
+
+	// The phase of a disabled or completed state sync is kept.
+	client = NewStateSyncClient(&stateSyncClientConfig{enabled: false})
+	client.SkipStateSyncIfWaiting()
+	require.Equal(stateSyncPhaseDisabled, client.StateSyncStatus().Phase)
+	client = NewStateSyncClient(&stateSyncClientConfig{enabled: true})
+	client.(*stateSyncerClient).setDone(nil)
+	client.SkipStateSyncIfWaiting()
+	require.Equal(stateSyncPhaseDone, client.StateSyncStatus().Phase)
+}
+
 func TestStateSyncFromScratch(t *testing.T) {
 	rand.Seed(1)
 	test := syncTest{
@@ -82,6 +104,50 @@ func TestStateSyncFromScratchExceedParent(t *testing.T) {
 	testSyncerVM(t, vmSetup, test)
 }
 
+func TestStateSyncFromFile(t *testing.T) {
+	rand.Seed(1)
+	require := require.New(t)
+	test := syncTest{
+		syncableInterval:   256,
+		stateSyncMinBlocks: 50,
+		syncMode:           block.StateSyncStatic,
+	}
+	vmSetup := createSyncServerAndClientVMs(t, test, parentsToGet)
+	serverVM, syncerVM := vmSetup.serverVM, vmSetup.syncerVM
+
+	path := filepath.Join(t.TempDir(), "state")
+	summary, checksum, err := serverVM.exportStateFile(context.Background(), path, 0)
+	require.NoError(err)
+	require.Equal(serverVM.LastAcceptedBlock().Height(), summary.Height())
+	require.NotEqual(common.Hash{}, checksum)
+	require.Zero(serverVM.stateFileExports)
+
+	// A state file other than the expected one is rejected before anything is
+	// written.
+	require.ErrorIs(syncerVM.StateSyncClient.ImportStateFile(path, common.Hash{1}), statefile.ErrUnexpectedChecksum)
+	require.Zero(syncerVM.LastAcceptedBlock().Height())
+	require.Nil(rawdb.ReadBlock(syncerVM.chaindb, summary.BlockHash, summary.BlockNumber))
+
+	// Bootstrap [syncerVM] from the state file instead of syncing from the server.
+	require.NoError(syncerVM.StateSyncClient.ImportStateFile(path, checksum))
+	enabled, err := syncerVM.StateSyncEnabled(context.Background())
+	require.NoError(err)
+	require.False(enabled)
+
+	require.NoError(syncerVM.SetState(context.Background(), snow.Bootstrapping))
+	require.Equal(serverVM.LastAcceptedBlock().ID(), syncerVM.LastAcceptedBlock().ID())
+	require.True(syncerVM.blockChain.HasState(syncerVM.blockChain.LastAcceptedBlock().Root()))
+	assertSyncPerformedHeights(t, syncerVM.chaindb, map[uint64]struct{}{summary.Height(): {}})
+
+	syncerSharedMemories := newSharedMemories(vmSetup.syncerAtomicMemory, syncerVM.ctx.ChainID, syncerVM.ctx.JVMChainID)
+	for _, tx := range vmSetup.includedAtomicTxs {
+		syncerSharedMemories.assertOpsApplied(t, tx.mustAtomicOps())
+	}
+
+	// A state file cannot be imported below the last accepted block.
+	require.ErrorIs(syncerVM.StateSyncClient.ImportStateFile(path, checksum), errStateFileSummaryTooLow)
+}
+
 func TestStateSyncToggleEnabledToDisabled(t *testing.T) {
 	rand.Seed(1)
 	// Hack: registering metrics uses global variables, so we need to disable metrics here so that we can initialize the VM twice.
@@ -489,6 +555,7 @@ func testSyncerVM(t *testing.T, vmSetup *syncVMSetup, test syncTest) {
 	err = syncerVM.StateSyncClient.Error()
 	if test.expectedErr != nil {
 		require.ErrorIs(err, test.expectedErr)
+		require.Equal(stateSyncPhaseFailed, syncerVM.StateSyncStatus().Phase)
 		// Note we re-open the database here to avoid a closed error when the test is for a shutdown VM.
 		chaindb := Database{prefixdb.NewNested(ethDBPrefix, syncerVM.db)}
 		assertSyncPerformedHeights(t, chaindb, map[uint64]struct{}{})
@@ -496,6 +563,18 @@ func testSyncerVM(t *testing.T, vmSetup *syncVMSetup, test syncTest) {
 	}
 	require.NoError(err, "state sync failed")
 
+	// the status reports the summary synced to and the progress of each step
+	status := syncerVM.StateSyncStatus()
+	require.Equal(stateSyncPhaseDone, status.Phase)
+	require.Equal(parsedSummary.Height(), status.Summary.Height())
+	require.True(status.State.MainTrieDone)
+	require.Empty(status.State.TriesInProgress)
+	require.Zero(status.State.CodeHashesPending)
+	require.Equal(parsedSummary.Height(), status.Atomic.Height)
+	require.Equal(parsedSummary.Height(), status.Atomic.TargetHeight)
+	_, err = syncerVM.HealthCheck(context.Background())
+	require.NoError(err)
+
 	// set [syncerVM] to bootstrapping and verify the last accepted block has been updated correctly
 	// and that we can bootstrap and process some blocks.
 	require.NoError(syncerVM.SetState(context.Background(), snow.Bootstrapping))
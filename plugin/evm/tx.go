@@ -39,6 +39,8 @@ var (
 	errEmptyAssetID      = errors.New("empty asset ID is not valid")
 	errNilBaseFee        = errors.New("cannot calculate dynamic fee with nil baseFee")
 	errFeeOverflow       = errors.New("overflow occurred while calculating the fee")
+
+	errUnsignedTxNotCanonical = errors.New("unsigned tx bytes are not canonically encoded")
 )
 
 // Constants for calculating the gas consumed by atomic transactions
@@ -186,6 +188,25 @@ func (tx *Tx) Sign(c codec.Manager, signers [][]*secp256k1.PrivateKey) error {
 	return nil
 }
 
+// newTxWithSignatures returns the tx made of the codec serialized
+// [unsignedBytes] and one credential per input holding the input's [sigs].
+func newTxWithSignatures(c codec.Manager, unsignedBytes []byte, sigs [][][secp256k1.SignatureLen]byte) (*Tx, error) {
+	tx := &Tx{}
+	if _, err := c.Unmarshal(unsignedBytes, &tx.UnsignedAtomicTx); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal UnsignedAtomicTx: %w", err)
+	}
+	for _, inputSigs := range sigs {
+		tx.Creds = append(tx.Creds, &secp256k1fx.Credential{Sigs: inputSigs})
+	}
+	if err := tx.Sign(c, nil); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(tx.Bytes(), unsignedBytes) {
+		return nil, errUnsignedTxNotCanonical
+	}
+	return tx, nil
+}
+
 // BlockFeeContribution calculates how much AVAX towards the block fee contribution was paid
 // for via this transaction denominated in [juneAssetID] with [baseFee] used to calculate the
 // cost of this transaction. This function also returns the [gasUsed] by the
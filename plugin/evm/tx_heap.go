@@ -5,6 +5,7 @@ package evm
 
 import (
 	"container/heap"
+	"sort"
 
 	"github.com/Juneo-io/juneogo/ids"
 )
@@ -161,3 +162,13 @@ func (th *txHeap) Get(id ids.ID) (*Tx, bool) {
 func (th *txHeap) Has(id ids.ID) bool {
 	return th.maxHeap.Has(id)
 }
+
+// Entries returns the entries of [txHeap] sorted by descending [gasPrice]
+func (th *txHeap) Entries() []*txEntry {
+	entries := make([]*txEntry, len(th.maxHeap.items))
+	copy(entries, th.maxHeap.items)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].gasPrice > entries[j].gasPrice
+	})
+	return entries
+}
@@ -0,0 +1,107 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/params"
+	warpPrecompile "github.com/Juneo-io/jeth/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var errNoPrecompileUpgradeFile = errors.New("precompile-upgrade-file is not set")
+
+// readUpgradeConfig returns the upgrade config of the precompile upgrade file
+// if one is configured, or the one of [upgradeBytes] otherwise.
+func (vm *VM) readUpgradeConfig(upgradeBytes []byte) (*params.UpgradeConfig, error) {
+	if len(vm.config.PrecompileUpgradeFile) != 0 {
+		var err error
+		upgradeBytes, err = os.ReadFile(vm.config.PrecompileUpgradeFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read precompile upgrade file: %w", err)
+		}
+	}
+	upgradeConfig := new(params.UpgradeConfig)
+	if len(upgradeBytes) == 0 {
+		return upgradeConfig, nil
+	}
+	if err := json.Unmarshal(upgradeBytes, upgradeConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse upgrade bytes: %w", err)
+	}
+	return upgradeConfig, nil
+}
+
+// applyUpgradeConfig applies the network upgrade overrides and the precompile
+// upgrades of [upgradeConfig] to [config].
+func applyUpgradeConfig(config *params.ChainConfig, upgradeConfig *params.UpgradeConfig) {
+	config.ApplyNetworkUpgradeOverrides(upgradeConfig.NetworkUpgradeOverrides)
+	config.NetworkUpgradeOverrides = upgradeConfig.NetworkUpgradeOverrides
+
+	config.PrecompileUpgrades = nil
+	// If the Durango is activated, activate the Warp Precompile at the same time
+	if config.DurangoBlockTimestamp != nil {
+		config.PrecompileUpgrades = append(config.PrecompileUpgrades, params.PrecompileUpgrade{
+			Config: warpPrecompile.NewDefaultConfig(config.DurangoBlockTimestamp),
+		})
+	}
+	// Precompiles other than warp require Durango, so they are scheduled after
+	// the warp upgrade.
+	config.PrecompileUpgrades = append(config.PrecompileUpgrades, upgradeConfig.PrecompileUpgrades...)
+}
+
+// reloadUpgradeConfig reads the precompile upgrade file again and applies it to
+// the chain config if it is compatible with the accepted and processing blocks.
+// Upgrades that activated at or before the last accepted block, or at or before
+// any processing block, cannot be changed.
+// Assumes the context lock is held.
+func (vm *VM) reloadUpgradeConfig() (*params.UpgradeConfig, error) {
+	if len(vm.config.PrecompileUpgradeFile) == 0 {
+		return nil, errNoPrecompileUpgradeFile
+	}
+	upgradeConfig, err := vm.readUpgradeConfig(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	newConfig := vm.baseChainConfig
+	newConfig.AvalancheContext = vm.chainConfig.AvalancheContext
+	applyUpgradeConfig(&newConfig, upgradeConfig)
+	if err := newConfig.CheckConfigForkOrder(); err != nil {
+		return nil, err
+	}
+	if err := newConfig.Verify(); err != nil {
+		return nil, fmt.Errorf("failed to verify chain config: %w", err)
+	}
+
+	// Processing blocks were verified with the current chain config, so the
+	// upgrades they activated cannot be changed either.
+	lastAccepted := vm.blockChain.LastAcceptedBlock()
+	height, timestamp := lastAccepted.NumberU64(), lastAccepted.Time()
+	for _, blk := range vm.processingBlocks {
+		height = max(height, blk.NumberU64())
+		timestamp = max(timestamp, blk.Time())
+	}
+	compatErr := vm.chainConfig.CheckCompatible(&newConfig, height, timestamp)
+	if compatErr != nil && ((height != 0 && compatErr.RewindToBlock != 0) || (timestamp != 0 && compatErr.RewindToTime != 0)) {
+		return nil, fmt.Errorf("upgrade config is incompatible with the accepted and processing blocks: %w", compatErr)
+	}
+
+	// The chain config is shared with the blockchain, the tx pool and the APIs,
+	// so it is updated in place. Blocks are only built, verified and accepted
+	// under the context lock, so the config changes between two blocks, once
+	// the accepted blocks queued for processing are done with it. Only upgrades
+	// after the processing blocks can change, so the rules of the blocks already
+	// built are unchanged.
+	vm.blockChain.DrainAcceptorQueue()
+	*vm.chainConfig = newConfig
+	rawdb.WriteChainConfig(vm.chaindb, vm.genesisHash, vm.chainConfig)
+	rawdb.WriteUpgradeConfig(vm.chaindb, vm.genesisHash, &vm.chainConfig.UpgradeConfig)
+	log.Info("Reloaded upgrade config", "file", vm.config.PrecompileUpgradeFile, "precompileUpgrades", len(upgradeConfig.PrecompileUpgrades))
+	return upgradeConfig, nil
+}
@@ -0,0 +1,121 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/core/types"
+	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/allowlist"
+	"github.com/Juneo-io/jeth/precompile/contracts/deployerallowlist"
+	"github.com/Juneo-io/jeth/precompile/contracts/warp"
+	"github.com/Juneo-io/jeth/utils"
+	"github.com/Juneo-io/juneogo/snow/consensus/snowman"
+	commonEng "github.com/Juneo-io/juneogo/snow/engine/common"
+	"github.com/Juneo-io/juneogo/vms/components/chain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadUpgradeConfig(t *testing.T) {
+	require := require.New(t)
+
+	upgradeFile := filepath.Join(t.TempDir(), "upgrade.json")
+	writeUpgrade := func(timestamp uint64) {
+		upgradeJSON := fmt.Sprintf(`{"precompileUpgrades":[{"contractDeployerAllowListConfig":{"blockTimestamp":%d,"adminAddresses":["0x0000000000000000000000000000000000000011"]}}]}`, timestamp)
+		require.NoError(os.WriteFile(upgradeFile, []byte(upgradeJSON), 0o600))
+	}
+	firstTimestamp := uint64(time.Now().Add(time.Hour).Unix())
+	writeUpgrade(firstTimestamp)
+
+	configJSON := fmt.Sprintf(`{"precompile-upgrade-file": %q}`, upgradeFile)
+	issuer, vm, db, _, appSender := GenesisVM(t, true, genesisJSONDurango, configJSON, "")
+
+	// Warp is activated with Durango, before the upgrades of the file
+	require.Len(vm.chainConfig.PrecompileUpgrades, 2)
+	require.Equal(warp.ConfigKey, vm.chainConfig.PrecompileUpgrades[0].Key())
+	require.True(vm.chainConfig.PrecompileUpgrades[1].Equal(
+		deployerallowlist.NewConfig(utils.NewUint64(firstTimestamp), []common.Address{common.HexToAddress("0x0000000000000000000000000000000000000011")}, nil, nil),
+	))
+
+	// Upgrades that have not activated yet can be rescheduled without
+	// restarting.
+	secondTimestamp := firstTimestamp + 1000
+	writeUpgrade(secondTimestamp)
+	upgradeConfig, err := vm.reloadUpgradeConfig()
+	require.NoError(err)
+	require.Len(upgradeConfig.PrecompileUpgrades, 1)
+	require.Equal(utils.NewUint64(secondTimestamp), upgradeConfig.PrecompileUpgrades[0].Timestamp())
+	require.Len(vm.chainConfig.PrecompileUpgrades, 2)
+	require.Equal(utils.NewUint64(secondTimestamp), vm.chainConfig.PrecompileUpgrades[1].Timestamp())
+	require.False(vm.chainConfig.IsPrecompileEnabled(deployerallowlist.ContractAddress, firstTimestamp))
+	require.True(vm.chainConfig.IsPrecompileEnabled(deployerallowlist.ContractAddress, secondTimestamp))
+	storedUpgradeConfig := rawdb.ReadUpgradeConfig(vm.chaindb, vm.genesisHash)
+	require.NotNil(storedUpgradeConfig)
+	require.Equal(utils.NewUint64(secondTimestamp), storedUpgradeConfig.PrecompileUpgrades[1].Timestamp())
+
+	issueTx := func(nonce uint64) snowman.Block {
+		tx := types.NewTransaction(nonce, testEthAddrs[1], big.NewInt(1), params.TxGas, big.NewInt(params.LaunchMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(vm.chainConfig.ChainID), testKeys[0].ToECDSA())
+		require.NoError(err)
+		errs := vm.txPool.AddRemotesSync([]*types.Transaction{signedTx})
+		require.NoError(errs[0])
+		<-issuer
+		blk, err := vm.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+		return blk
+	}
+
+	// Upgrades cannot be scheduled at or before a processing block
+	blk := issueTx(0)
+	writeUpgrade(uint64(blk.Timestamp().Unix()))
+	_, err = vm.reloadUpgradeConfig()
+	require.ErrorContains(err, "incompatible with the accepted and processing blocks")
+	require.Equal(utils.NewUint64(secondTimestamp), vm.chainConfig.PrecompileUpgrades[1].Timestamp())
+
+	// Invalid upgrade files are rejected
+	require.NoError(os.WriteFile(upgradeFile, []byte(`{"precompileUpgrades":[{"unknownConfig":{}}]}`), 0o600))
+	_, err = vm.reloadUpgradeConfig()
+	require.ErrorContains(err, "unknown precompile config")
+	require.NoError(blk.Accept(context.Background()))
+
+	// The reloaded upgrade activates with the first block at its timestamp
+	vm.clock.Set(time.Unix(int64(secondTimestamp), 0))
+	blk = issueTx(1)
+	require.Equal(secondTimestamp, uint64(blk.Timestamp().Unix()))
+	require.NoError(blk.Accept(context.Background()))
+	state, err := vm.blockChain.StateAt(blk.(*chain.BlockWrapper).Block.(*Block).ethBlock.Root())
+	require.NoError(err)
+	require.Equal(allowlist.AdminRole, deployerallowlist.GetContractDeployerAllowListStatus(state, common.HexToAddress("0x0000000000000000000000000000000000000011")))
+
+	// The reloaded upgrade config is kept on restart
+	writeUpgrade(secondTimestamp)
+	require.NoError(vm.Shutdown(context.Background()))
+	restartedVM := &VM{}
+	require.NoError(restartedVM.Initialize(context.Background(), vm.ctx, db, []byte(genesisJSONDurango), nil, []byte(configJSON), issuer, []*commonEng.Fx{}, appSender))
+	defer func() {
+		require.NoError(restartedVM.Shutdown(context.Background()))
+	}()
+	require.Equal(utils.NewUint64(secondTimestamp), restartedVM.chainConfig.PrecompileUpgrades[1].Timestamp())
+}
+
+func TestReloadUpgradeConfigWithoutFile(t *testing.T) {
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONDurango, "", "")
+	defer func() {
+		require.NoError(t, vm.Shutdown(context.Background()))
+	}()
+
+	_, err := vm.reloadUpgradeConfig()
+	require.ErrorIs(t, err, errNoPrecompileUpgradeFile)
+}
@@ -41,10 +41,10 @@ import (
 	"github.com/Juneo-io/jeth/plugin/evm/message"
 	"github.com/Juneo-io/jeth/trie/triedb/hashdb"
 
-	warpPrecompile "github.com/Juneo-io/jeth/precompile/contracts/warp"
 	"github.com/Juneo-io/jeth/rpc"
 	statesyncclient "github.com/Juneo-io/jeth/sync/client"
 	"github.com/Juneo-io/jeth/sync/client/stats"
+	syncHandlers "github.com/Juneo-io/jeth/sync/handlers"
 	"github.com/Juneo-io/jeth/trie"
 	"github.com/Juneo-io/jeth/utils"
 	"github.com/Juneo-io/jeth/warp"
@@ -63,6 +63,7 @@ import (
 	_ "github.com/Juneo-io/jeth/precompile/registry"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -174,6 +175,8 @@ var (
 	acceptedPrefix  = []byte("snowman_accepted")
 	metadataPrefix  = []byte("metadata")
 	warpPrefix      = []byte("warp")
+	warpIndexPrefix = []byte("warpIndex")
+	backfillPrefix  = []byte("backfill")
 	ethDBPrefix     = []byte("ethdb")
 
 	// Prefixes for atomic trie
@@ -257,6 +260,13 @@ type VM struct {
 	chainConfig *params.ChainConfig
 	ethConfig   ethconfig.Config
 
+	// baseChainConfig is the chain config before the upgrade config is applied.
+	// It is kept to apply the upgrade config again when it is reloaded.
+	baseChainConfig params.ChainConfig
+	// processingBlocks are the verified blocks that are not accepted or
+	// rejected yet. The upgrades they activated cannot be rescheduled.
+	processingBlocks map[ids.ID]*types.Block
+
 	// pointers to eth constructs
 	eth        *eth.Ethereum
 	txPool     *txpool.TxPool
@@ -280,6 +290,15 @@ type VM struct {
 	// set to a prefixDB with the prefix [warpPrefix]
 	warpDB database.Database
 
+	// [warpIndexDB] is used to store the index of the sent warp messages and
+	// the state of the warp relayer, which are not pruned with the warp
+	// signatures. It is set to a prefixDB with the prefix [warpIndexPrefix]
+	warpIndexDB database.Database
+
+	// [backfillDB] is used to store the progress of the block backfiller
+	// set to a prefixDB with the prefix [backfillPrefix]
+	backfillDB database.Database
+
 	toEngine chan<- commonEng.Message
 
 	syntacticBlockValidator BlockValidator
@@ -288,6 +307,9 @@ type VM struct {
 	// - txID to accepted atomic tx
 	// - block height to list of atomic txs accepted on block at that height
 	atomicTxRepository AtomicTxRepository
+	// [atomicTxAddressIndex] optionally indexes accepted atomic txs by the
+	// addresses they touch. It is nil unless enabled in the config.
+	atomicTxAddressIndex *atomicTxAddressIndex
 	// [atomicTrie] maintains a merkle forest of [height]=>[atomic txs].
 	atomicTrie AtomicTrie
 	// [atomicBackend] abstracts verification and processing of atomic transactions
@@ -326,6 +348,9 @@ type VM struct {
 	// State sync server and client
 	StateSyncServer
 	StateSyncClient
+	// stateFileExports is the number of state files being exported, online
+	// pruning cannot start while it's not 0.
+	stateFileExports int
 
 	// Avalanche Warp Messaging backend
 	// Used to serve BLS signatures of warp messages over RPC
@@ -435,6 +460,10 @@ func (vm *VM) Initialize(
 	// that warp signatures are committed to the database atomically with
 	// the last accepted block.
 	vm.warpDB = prefixdb.New(warpPrefix, db)
+	vm.warpIndexDB = prefixdb.New(warpIndexPrefix, db)
+	// backfillDB is not part of versiondb either, so that the block backfiller
+	// never commits versiondb from its goroutine.
+	vm.backfillDB = prefixdb.New(backfillPrefix, db)
 
 	if vm.config.InspectDatabase {
 		start := time.Now()
@@ -454,12 +483,16 @@ func (vm *VM) Initialize(
 	// Set the chain config for mainnet/testnet chain IDs
 	vm.setConfig(g, extDataHashes)
 
-	// If the Durango is activated, activate the Warp Precompile at the same time
-	if g.Config.DurangoBlockTimestamp != nil {
-		g.Config.PrecompileUpgrades = append(g.Config.PrecompileUpgrades, params.PrecompileUpgrade{
-			Config: warpPrecompile.NewDefaultConfig(g.Config.DurangoBlockTimestamp),
-		})
+	// Apply the network upgrade overrides and the precompile upgrades of the
+	// upgrade config. They are checked against the accepted blocks when the
+	// blockchain is created.
+	upgradeConfig, err := vm.readUpgradeConfig(upgradeBytes)
+	if err != nil {
+		return err
 	}
+	vm.baseChainConfig = *g.Config
+	vm.processingBlocks = make(map[ids.ID]*types.Block)
+	applyUpgradeConfig(g.Config, upgradeConfig)
 	// Set the Avalanche Context on the ChainConfig
 	g.Config.AvalancheContext = params.AvalancheContext{
 		SnowCtx: chainCtx,
@@ -522,18 +555,27 @@ func (vm *VM) Initialize(
 	vm.ethConfig.PopulateMissingTries = vm.config.PopulateMissingTries
 	vm.ethConfig.PopulateMissingTriesParallelism = vm.config.PopulateMissingTriesParallelism
 	vm.ethConfig.AllowMissingTries = vm.config.AllowMissingTries
-	vm.ethConfig.SnapshotDelayInit = vm.stateSyncEnabled(lastAcceptedHeight)
+	vm.ethConfig.SnapshotDelayInit = vm.stateSyncEnabled(lastAcceptedHeight) || vm.stateFileEnabled(lastAcceptedHeight)
 	vm.ethConfig.SnapshotWait = vm.config.SnapshotWait
 	vm.ethConfig.SnapshotVerify = vm.config.SnapshotVerify
 	vm.ethConfig.OfflinePruning = vm.config.OfflinePruning
 	vm.ethConfig.OfflinePruningBloomFilterSize = vm.config.OfflinePruningBloomFilterSize
 	vm.ethConfig.OfflinePruningDataDirectory = vm.config.OfflinePruningDataDirectory
+	vm.ethConfig.OnlinePruningBloomFilterSize = vm.config.OnlinePruningBloomFilterSize
+	vm.ethConfig.OnlinePruningBatchDelay = vm.config.OnlinePruningBatchDelay.Duration
 	vm.ethConfig.CommitInterval = vm.config.CommitInterval
 	vm.ethConfig.SkipUpgradeCheck = vm.config.SkipUpgradeCheck
 	vm.ethConfig.AcceptedCacheSize = vm.config.AcceptedCacheSize
 	vm.ethConfig.TxLookupLimit = vm.config.TxLookupLimit
 	vm.ethConfig.SkipTxIndexing = vm.config.SkipTxIndexing
 
+	// Refuse to start with a state scheme different from the one of the state
+	// already on disk, since the state cannot be read with the other scheme.
+	vm.ethConfig.StateScheme, err = rawdb.ParseStateScheme(vm.config.StateScheme, vm.chaindb)
+	if err != nil {
+		return err
+	}
+
 	// Create directory for offline pruning
 	if len(vm.ethConfig.OfflinePruningDataDirectory) != 0 {
 		if err := os.MkdirAll(vm.ethConfig.OfflinePruningDataDirectory, perms.ReadWriteExecute); err != nil {
@@ -585,7 +627,7 @@ func (vm *VM) Initialize(
 	for i, hexMsg := range vm.config.WarpOffChainMessages {
 		offchainWarpMessages[i] = []byte(hexMsg)
 	}
-	vm.warpBackend, err = warp.NewBackend(vm.ctx.NetworkID, vm.ctx.ChainID, vm.ctx.WarpSigner, vm, vm.warpDB, warpSignatureCacheSize, offchainWarpMessages)
+	vm.warpBackend, err = warp.NewBackend(vm.ctx.NetworkID, vm.ctx.ChainID, vm.ctx.WarpSigner, vm, vm.warpDB, vm.warpIndexDB, warpSignatureCacheSize, offchainWarpMessages)
 	if err != nil {
 		return err
 	}
@@ -620,6 +662,16 @@ func (vm *VM) Initialize(
 	if err != nil {
 		return fmt.Errorf("failed to create atomic repository: %w", err)
 	}
+	if vm.config.AtomicTxAddressIndexEnabled {
+		vm.atomicTxAddressIndex, err = newAtomicTxAddressIndex(vm.db, vm.atomicTxRepository, vm.atomicTxAddresses)
+		if err != nil {
+			return fmt.Errorf("failed to create atomic tx address index: %w", err)
+		}
+		vm.atomicTxRepository = &addressIndexedAtomicTxRepository{
+			AtomicTxRepository: vm.atomicTxRepository,
+			addressIndex:       vm.atomicTxAddressIndex,
+		}
+	}
 	vm.atomicBackend, _, err = NewAtomicBackendWithBonusBlockRepair(
 		vm.db, vm.ctx.SharedMemory, bonusBlockHeights, bonusBlockRepair,
 		vm.atomicTxRepository, lastAcceptedHeight, lastAcceptedHash,
@@ -651,7 +703,16 @@ func (vm *VM) Initialize(
 	}
 
 	vm.initializeStateSyncServer()
-	return vm.initializeStateSyncClient(lastAcceptedHeight)
+	if err := vm.initializeStateSyncClient(lastAcceptedHeight); err != nil {
+		return err
+	}
+	if !vm.stateFileEnabled(lastAcceptedHeight) {
+		return nil
+	}
+	if err := vm.StateSyncClient.ImportStateFile(vm.config.StateSyncFile, vm.config.StateSyncFileChecksum); err != nil {
+		return fmt.Errorf("failed to import state file %s: %w", vm.config.StateSyncFile, err)
+	}
+	return nil
 }
 
 func (vm *VM) initializeMetrics() error {
@@ -749,10 +810,13 @@ func (vm *VM) initializeStateSyncClient(lastAcceptedHeight uint64) error {
 		skipResume:           vm.config.StateSyncSkipResume,
 		stateSyncMinBlocks:   vm.config.StateSyncMinBlocks,
 		stateSyncRequestSize: vm.config.StateSyncRequestSize,
+		backfillEnabled:      vm.config.StateSyncBackfill,
+		syntheticLogs:        vm.atomicSyntheticLogs,
 		lastAcceptedHeight:   lastAcceptedHeight, // TODO clean up how this is passed around
 		chaindb:              vm.chaindb,
 		metadataDB:           vm.metadataDB,
 		acceptedBlockDB:      vm.acceptedBlockDB,
+		backfillDB:           vm.backfillDB,
 		db:                   vm.db,
 		atomicBackend:        vm.atomicBackend,
 		toEngine:             vm.toEngine,
@@ -832,7 +896,7 @@ func (vm *VM) preBatchOnFinalizeAndAssemble(header *types.Header, state *state.S
 		if err := vm.verifyTx(tx, header.ParentHash, header.BaseFee, state, rules); err != nil {
 			// Discard the transaction from the mempool on failed verification.
 			log.Debug("discarding tx from mempool on failed verification", "txID", tx.ID(), "err", err)
-			vm.mempool.DiscardCurrentTx(tx.ID())
+			vm.mempool.DiscardCurrentTx(tx.ID(), err)
 			state.RevertToSnapshot(snapshot)
 			continue
 		}
@@ -842,7 +906,7 @@ func (vm *VM) preBatchOnFinalizeAndAssemble(header *types.Header, state *state.S
 			// Discard the transaction from the mempool and error if the transaction
 			// cannot be marshalled. This should never happen.
 			log.Debug("discarding tx due to unmarshal err", "txID", tx.ID(), "err", err)
-			vm.mempool.DiscardCurrentTx(tx.ID())
+			vm.mempool.DiscardCurrentTx(tx.ID(), err)
 			return nil, nil, nil, fmt.Errorf("failed to marshal atomic transaction %s due to %w", tx.ID(), err)
 		}
 		var contribution, gasUsed *big.Int
@@ -914,7 +978,7 @@ func (vm *VM) postBatchOnFinalizeAndAssemble(header *types.Header, state *state.
 			// block will most likely be accepted.
 			// Discard the transaction from the mempool on failed verification.
 			log.Debug("discarding tx due to overlapping input utxos", "txID", tx.ID())
-			vm.mempool.DiscardCurrentTx(tx.ID())
+			vm.mempool.DiscardCurrentTx(tx.ID(), errConflictingAtomicInputs)
 			continue
 		}
 
@@ -925,7 +989,7 @@ func (vm *VM) postBatchOnFinalizeAndAssemble(header *types.Header, state *state.
 			// Note: prior to this point, we have not modified [state] so there is no need to
 			// revert to a snapshot if we discard the transaction prior to this point.
 			log.Debug("discarding tx from mempool due to failed verification", "txID", tx.ID(), "err", err)
-			vm.mempool.DiscardCurrentTx(tx.ID())
+			vm.mempool.DiscardCurrentTx(tx.ID(), err)
 			state.RevertToSnapshot(snapshot)
 			continue
 		}
@@ -946,7 +1010,7 @@ func (vm *VM) postBatchOnFinalizeAndAssemble(header *types.Header, state *state.
 			// If we fail to marshal the batch of atomic transactions for any reason,
 			// discard the entire set of current transactions.
 			log.Debug("discarding txs due to error marshaling atomic transactions", "err", err)
-			vm.mempool.DiscardCurrentTxs()
+			vm.mempool.DiscardCurrentTxs(err)
 			return nil, nil, nil, fmt.Errorf("failed to marshal batch of atomic transactions due to %w", err)
 		}
 		return atomicTxBytes, batchContribution, batchGasUsed, nil
@@ -1009,7 +1073,11 @@ func (vm *VM) onExtraStateChange(block *types.Block, state *state.StateDB) (*big
 		return nil, nil, nil
 	}
 
-	for _, tx := range txs {
+	for i, tx := range txs {
+		// Attribute the synthetic logs of the atomic tx to its ID, indexed
+		// after the block's eth txs. Neither resolves to an eth tx, see
+		// [vm.NativeAssetTransferTopic].
+		state.SetTxContext(common.Hash(tx.ID()), len(block.Transactions())+i)
 		if err := tx.UnsignedAtomicTx.EVMStateTransfer(vm.ctx, state); err != nil {
 			return nil, nil, err
 		}
@@ -1036,6 +1104,34 @@ func (vm *VM) onExtraStateChange(block *types.Block, state *state.StateDB) (*big
 	return batchContribution, batchGasUsed, nil
 }
 
+// atomicSyntheticLogs returns the synthetic logs recorded by the atomic txs of
+// [block] when it was accepted, as onExtraStateChange records them. They only
+// depend on the txs, so they are derived without executing the block.
+func (vm *VM) atomicSyntheticLogs(block *types.Block) ([]*types.Log, error) {
+	rules := vm.chainConfig.Rules(block.Number(), block.Time())
+	txs, err := ExtractAtomicTxs(block.ExtData(), rules.IsApricotPhase5, vm.codec)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []*types.Log
+	for i, tx := range txs {
+		var txLogs []*types.Log
+		switch utx := tx.UnsignedAtomicTx.(type) {
+		case *UnsignedImportTx:
+			txLogs = utx.syntheticLogs(vm.ctx)
+		case *UnsignedExportTx:
+			txLogs = utx.syntheticLogs(vm.ctx)
+		}
+		for _, l := range txLogs {
+			l.TxHash = common.Hash(tx.ID())
+			l.TxIndex = uint(len(block.Transactions()) + i)
+		}
+		logs = append(logs, txLogs...)
+	}
+	return logs, nil
+}
+
 func (vm *VM) SetState(_ context.Context, state snow.State) error {
 	switch state {
 	case snow.StateSyncing:
@@ -1046,12 +1142,16 @@ func (vm *VM) SetState(_ context.Context, state snow.State) error {
 		if err := vm.StateSyncClient.Error(); err != nil {
 			return err
 		}
+		vm.StateSyncClient.SkipStateSyncIfWaiting()
 		return vm.fx.Bootstrapping()
 	case snow.NormalOp:
 		// Initialize goroutines related to block building once we enter normal operation as there is no need to handle mempool gossip before this point.
 		if err := vm.initBlockBuilding(); err != nil {
 			return fmt.Errorf("failed to initialize block building: %w", err)
 		}
+		vm.StateSyncClient.SkipStateSyncIfWaiting()
+		// Backfill the blocks skipped by state sync once peers can serve them.
+		vm.StateSyncClient.StartBlockBackfill()
 		vm.bootstrapped = true
 		return vm.fx.Bootstrapped()
 	default:
@@ -1229,6 +1329,45 @@ func (vm *VM) initBlockBuilding() error {
 		vm.shutdownWg.Done()
 	}()
 
+	// The warp relayer shares the lifetime of the gossipers, so that it only
+	// starts relaying messages once the chain is in normal operation.
+	if vm.config.WarpRelayerEnabled {
+		if err := vm.initWarpRelayer(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initWarpRelayer starts relaying the warp messages sent by accepted txs to
+// the configured destination chain until [ctx] is cancelled.
+func (vm *VM) initWarpRelayer(ctx context.Context) error {
+	privateKey, err := crypto.LoadECDSA(vm.config.WarpRelayerPrivateKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load warp relayer private key: %w", err)
+	}
+	api := warp.NewAPI(vm.ctx.NetworkID, vm.ctx.SupernetID, vm.ctx.ChainID, warpValidators.NewState(vm.ctx), vm.warpBackend, vm.client)
+	relayer, err := warp.NewRelayer(warp.RelayerConfig{
+		DestinationRPC:     vm.config.WarpRelayerDestinationRPC,
+		DestinationChainID: vm.config.WarpRelayerDestinationChainID,
+		StartHeight:        vm.config.WarpRelayerStartHeight,
+		PrivateKey:         privateKey,
+		QuorumNum:          vm.config.WarpRelayerQuorumNumerator,
+		GasLimit:           vm.config.WarpRelayerGasLimit,
+		RetryInterval:      vm.config.WarpRelayerRetryInterval.Duration,
+		MaxAttempts:        vm.config.WarpRelayerMaxAttempts,
+	}, api, vm.warpIndexDB)
+	if err != nil {
+		return fmt.Errorf("failed to initialize warp relayer: %w", err)
+	}
+
+	vm.shutdownWg.Add(1)
+	go func() {
+		defer vm.shutdownWg.Done()
+		relayer.Run(ctx, func() uint64 {
+			return vm.blockChain.LastAcceptedBlock().NumberU64()
+		})
+	}()
 	return nil
 }
 
@@ -1238,14 +1377,19 @@ func (vm *VM) setAppRequestHandlers() {
 	// Create separate EVM TrieDB (read only) for serving leafs requests.
 	// We create a separate TrieDB here, so that it has a separate cache from the one
 	// used by the node when processing blocks.
-	evmTrieDB := trie.NewDatabase(
-		vm.chaindb,
-		&trie.Config{
-			HashDB: &hashdb.Config{
-				CleanCacheSize: vm.config.StateSyncServerTrieCache * units.MiB,
+	// With the path scheme, recent states are only held by the layers of the
+	// blockchain's TrieDB, so leafs requests are served from it instead.
+	evmTrieDB := vm.blockChain.TrieDB()
+	if vm.ethConfig.StateScheme == rawdb.HashScheme {
+		evmTrieDB = trie.NewDatabase(
+			vm.chaindb,
+			&trie.Config{
+				HashDB: &hashdb.Config{
+					CleanCacheSize: vm.config.StateSyncServerTrieCache * units.MiB,
+				},
 			},
-		},
-	)
+		)
+	}
 	networkHandler := newNetworkHandler(
 		vm.blockChain,
 		vm.chaindb,
@@ -1253,6 +1397,11 @@ func (vm *VM) setAppRequestHandlers() {
 		vm.atomicTrie.TrieDB(),
 		vm.warpBackend,
 		vm.networkCodec,
+		syncHandlers.ThrottlerConfig{
+			LeafsPerSecond:        vm.config.StateSyncServerLeafsPerSecond,
+			BytesPerSecond:        vm.config.StateSyncServerBytesPerSecond,
+			MaxConcurrentRequests: vm.config.StateSyncServerMaxConcurrentRequests,
+		},
 	)
 	vm.Network.SetRequestHandler(networkHandler)
 }
@@ -1309,7 +1458,7 @@ func (vm *VM) buildBlockWithContext(ctx context.Context, proposerVMBlockCtx *blo
 	blk, err := vm.newBlock(block)
 	if err != nil {
 		log.Debug("discarding txs due to error making new block", "err", err)
-		vm.mempool.DiscardCurrentTxs()
+		vm.mempool.DiscardCurrentTxs(err)
 		return nil, err
 	}
 
@@ -1449,6 +1598,12 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 	enabledAPIs = append(enabledAPIs, "june")
 	apis[avaxEndpoint] = avaxAPI
 
+	// Atomic tx subscriptions are served through the eth RPC handler, as
+	// notifications are only supported over websockets.
+	if err := handler.RegisterName("june", &AtomicTxFilterAPI{vm}); err != nil {
+		return nil, err
+	}
+
 	if vm.config.AdminAPIEnabled {
 		adminAPI, err := newHandler("admin", NewAdminService(vm, os.ExpandEnv(fmt.Sprintf("%s_coreth_performance_%s", vm.config.AdminAPIDir, primaryAlias))))
 		if err != nil {
@@ -1465,6 +1620,13 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 		enabledAPIs = append(enabledAPIs, "snowman")
 	}
 
+	if vm.config.StateSyncAPIEnabled {
+		if err := handler.RegisterName("statesync", &StateSyncAPI{vm}); err != nil {
+			return nil, err
+		}
+		enabledAPIs = append(enabledAPIs, "statesync")
+	}
+
 	if vm.config.WarpAPIEnabled {
 		validatorsState := warpValidators.NewState(vm.ctx)
 		if err := handler.RegisterName("warp", warp.NewAPI(vm.ctx.NetworkID, vm.ctx.SupernetID, vm.ctx.ChainID, validatorsState, vm.warpBackend, vm.client)); err != nil {
@@ -1614,7 +1776,11 @@ func (vm *VM) verifyTxAtTip(tx *Tx) error {
 	var nextBaseFee *big.Int
 	timestamp := uint64(vm.clock.Time().Unix())
 	if vm.chainConfig.IsApricotPhase3(timestamp) {
-		_, nextBaseFee, err = dummy.EstimateNextBaseFee(vm.chainConfig, parentHeader, timestamp)
+		feeConfig, err := vm.blockChain.GetFeeConfigAt(parentHeader)
+		if err != nil {
+			return fmt.Errorf("failed to get fee config at parent %s: %w", parentHeader.Hash(), err)
+		}
+		_, nextBaseFee, err = dummy.EstimateNextBaseFee(vm.chainConfig, feeConfig, parentHeader, timestamp)
 		if err != nil {
 			// Return extremely detailed error since CalcBaseFee should never encounter an issue here
 			return fmt.Errorf("failed to calculate base fee with parent timestamp (%d), parent ExtraData: (0x%x), and current timestamp (%d): %w", parentHeader.Time, parentHeader.Extra, timestamp, err)
@@ -1747,20 +1913,33 @@ func (vm *VM) GetSpendableFunds(
 	assetID ids.ID,
 	amount uint64,
 ) ([]EVMInput, [][]*secp256k1.PrivateKey, error) {
+	addrs, keysByAddr := ethAddressesOfKeys(keys)
+	inputs, err := vm.getSpendableFunds(addrs, assetID, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputs, inputSigners(inputs, keysByAddr), nil
+}
+
+// getSpendableFunds returns a list of EVMInputs to total [amount] of
+// [assetID] owned by [addrs].
+func (vm *VM) getSpendableFunds(
+	addrs []common.Address,
+	assetID ids.ID,
+	amount uint64,
+) ([]EVMInput, error) {
 	// Note: current state uses the state of the preferred block.
 	state, err := vm.blockChain.State()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	inputs := []EVMInput{}
-	signers := [][]*secp256k1.PrivateKey{}
-	// Note: we assume that each key in [keys] is unique, so that iterating over
-	// the keys will not produce duplicated nonces in the returned EVMInput slice.
-	for _, key := range keys {
+	// Note: we assume that each address in [addrs] is unique, so that iterating over
+	// the addresses will not produce duplicated nonces in the returned EVMInput slice.
+	for _, addr := range addrs {
 		if amount == 0 {
 			break
 		}
-		addr := GetEthAddress(key)
 		var balance uint64
 		if assetID == vm.ctx.ChainAssetID {
 			// If the asset is AVAX, we divide by the x2cRate to convert back to the correct
@@ -1777,7 +1956,7 @@ func (vm *VM) GetSpendableFunds(
 		}
 		nonce, err := vm.GetCurrentNonce(addr)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		inputs = append(inputs, EVMInput{
 			Address: addr,
@@ -1785,15 +1964,14 @@ func (vm *VM) GetSpendableFunds(
 			AssetID: assetID,
 			Nonce:   nonce,
 		})
-		signers = append(signers, []*secp256k1.PrivateKey{key})
 		amount -= balance
 	}
 
 	if amount > 0 {
-		return nil, nil, errInsufficientFunds
+		return nil, errInsufficientFunds
 	}
 
-	return inputs, signers, nil
+	return inputs, nil
 }
 
 // GetSpendableAVAXWithFee returns a list of EVMInputs and keys (in corresponding
@@ -1810,46 +1988,61 @@ func (vm *VM) GetSpendableAVAXWithFee(
 	cost uint64,
 	baseFee *big.Int,
 ) ([]EVMInput, [][]*secp256k1.PrivateKey, error) {
+	addrs, keysByAddr := ethAddressesOfKeys(keys)
+	inputs, err := vm.getSpendableAVAXWithFee(addrs, amount, cost, baseFee)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputs, inputSigners(inputs, keysByAddr), nil
+}
+
+// getSpendableAVAXWithFee returns a list of EVMInputs to total [amount] +
+// [fee] of [AVAX] owned by [addrs], skipping any address with a balance that
+// is insufficient to cover the additional fee of its input.
+func (vm *VM) getSpendableAVAXWithFee(
+	addrs []common.Address,
+	amount uint64,
+	cost uint64,
+	baseFee *big.Int,
+) ([]EVMInput, error) {
 	// Note: current state uses the state of the preferred block.
 	state, err := vm.blockChain.State()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	initialFee, err := CalculateDynamicFee(cost, baseFee)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	newAmount, err := math.Add64(amount, initialFee)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	amount = newAmount
 
 	inputs := []EVMInput{}
-	signers := [][]*secp256k1.PrivateKey{}
-	// Note: we assume that each key in [keys] is unique, so that iterating over
-	// the keys will not produce duplicated nonces in the returned EVMInput slice.
-	for _, key := range keys {
+	// Note: we assume that each address in [addrs] is unique, so that iterating over
+	// the addresses will not produce duplicated nonces in the returned EVMInput slice.
+	for _, addr := range addrs {
 		if amount == 0 {
 			break
 		}
 
 		prevFee, err := CalculateDynamicFee(cost, baseFee)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		newCost := cost + EVMInputGas
 		newFee, err := CalculateDynamicFee(newCost, baseFee)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		additionalFee := newFee - prevFee
 
-		addr := GetEthAddress(key)
 		// Since the asset is AVAX, we divide by the x2cRate to convert back to
 		// the correct denomination of AVAX that can be exported.
 		balance := new(big.Int).Div(state.GetBalance(addr), x2cRate).Uint64()
@@ -1864,7 +2057,7 @@ func (vm *VM) GetSpendableAVAXWithFee(
 
 		newAmount, err := math.Add64(amount, additionalFee)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		amount = newAmount
 
@@ -1877,7 +2070,7 @@ func (vm *VM) GetSpendableAVAXWithFee(
 		}
 		nonce, err := vm.GetCurrentNonce(addr)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		inputs = append(inputs, EVMInput{
 			Address: addr,
@@ -1885,15 +2078,35 @@ func (vm *VM) GetSpendableAVAXWithFee(
 			AssetID: vm.ctx.ChainAssetID,
 			Nonce:   nonce,
 		})
-		signers = append(signers, []*secp256k1.PrivateKey{key})
 		amount -= inputAmount
 	}
 
 	if amount > 0 {
-		return nil, nil, errInsufficientFunds
+		return nil, errInsufficientFunds
+	}
+
+	return inputs, nil
+}
+
+// ethAddressesOfKeys returns the EVM addresses of [keys], in order, and the
+// key controlling each of them.
+func ethAddressesOfKeys(keys []*secp256k1.PrivateKey) ([]common.Address, map[common.Address]*secp256k1.PrivateKey) {
+	addrs := make([]common.Address, len(keys))
+	keysByAddr := make(map[common.Address]*secp256k1.PrivateKey, len(keys))
+	for i, key := range keys {
+		addrs[i] = GetEthAddress(key)
+		keysByAddr[addrs[i]] = key
 	}
+	return addrs, keysByAddr
+}
 
-	return inputs, signers, nil
+// inputSigners returns the keys (in corresponding order) signing [inputs].
+func inputSigners(inputs []EVMInput, keysByAddr map[common.Address]*secp256k1.PrivateKey) [][]*secp256k1.PrivateKey {
+	signers := make([][]*secp256k1.PrivateKey, len(inputs))
+	for i, input := range inputs {
+		signers[i] = []*secp256k1.PrivateKey{keysByAddr[input.Address]}
+	}
+	return signers
 }
 
 // GetCurrentNonce returns the nonce associated with the address at the
@@ -2037,6 +2250,12 @@ func (vm *VM) stateSyncEnabled(lastAcceptedHeight uint64) bool {
 	return lastAcceptedHeight == 0
 }
 
+// stateFileEnabled returns true if the chain should be bootstrapped from the
+// state file of the config, which is only done if the chain is empty.
+func (vm *VM) stateFileEnabled(lastAcceptedHeight uint64) bool {
+	return vm.config.StateSyncFile != "" && lastAcceptedHeight == 0
+}
+
 func (vm *VM) setConfig(genesis *core.Genesis, extDataHashes map[common.Hash]common.Hash) {
 	switch {
 	case genesis.Config.ChainID.Cmp(params.JuneJUNEChainID) == 0:
@@ -58,6 +58,7 @@ import (
 
 	"github.com/Juneo-io/jeth/consensus/dummy"
 	"github.com/Juneo-io/jeth/core"
+	"github.com/Juneo-io/jeth/core/rawdb"
 	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/eth"
 	"github.com/Juneo-io/jeth/params"
@@ -4097,6 +4098,46 @@ func TestSkipChainConfigCheckCompatible(t *testing.T) {
 	require.NoError(t, reinitVM.Shutdown(context.Background()))
 }
 
+func TestStateSchemeMismatch(t *testing.T) {
+	// Hack: registering metrics uses global variables, so we need to disable metrics here so that we can initialize the VM twice.
+	metrics.Enabled = false
+	defer func() { metrics.Enabled = true }()
+
+	importAmount := uint64(50000000)
+	issuer, vm, dbManager, _, appSender := GenesisVMWithUTXOs(t, true, genesisJSONApricotPhase1, `{"state-scheme": "path"}`, "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+	})
+	require.Equal(t, rawdb.PathScheme, vm.blockChain.TrieDB().Scheme())
+
+	// Accept a block to make sure blocks are processed with the path scheme.
+	importTx, err := vm.newImportTx(vm.ctx.JVMChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(t, err)
+	require.NoError(t, vm.mempool.AddLocalTx(importTx))
+	<-issuer
+
+	blk, err := vm.BuildBlock(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, blk.Verify(context.Background()))
+	require.NoError(t, vm.SetPreference(context.Background(), blk.ID()))
+	require.NoError(t, blk.Accept(context.Background()))
+	require.NoError(t, vm.Shutdown(context.Background()))
+
+	// The state on disk cannot be read with the hash scheme
+	reinitVM := &VM{}
+	err = reinitVM.Initialize(context.Background(), vm.ctx, dbManager, []byte(genesisJSONApricotPhase1), []byte{}, []byte{}, issuer, []*commonEng.Fx{}, appSender)
+	require.ErrorContains(t, err, "incompatible state scheme, stored: path, provided: hash")
+
+	// Restarting with the path scheme recovers the state of the accepted block
+	reinitVM = &VM{}
+	config := []byte(`{"state-scheme": "path"}`)
+	require.NoError(t, reinitVM.Initialize(context.Background(), vm.ctx, dbManager, []byte(genesisJSONApricotPhase1), []byte{}, config, issuer, []*commonEng.Fx{}, appSender))
+	lastAcceptedID, err := reinitVM.LastAccepted(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, blk.ID(), lastAcceptedID)
+	require.True(t, reinitVM.blockChain.HasState(reinitVM.blockChain.LastAcceptedBlock().Root()))
+	require.NoError(t, reinitVM.Shutdown(context.Background()))
+}
+
 func TestParentBeaconRootBlock(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,197 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package allowlist implements a role based allow list that other stateful
+// precompiles embed to restrict which addresses can use them.
+// The role of each address is kept in the storage of the precompile that
+// embeds the allow list.
+package allowlist
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Juneo-io/jeth/accounts/abi"
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/jeth/vmerrs"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	ModifyAllowListGasCost = contract.WriteGasCostPerSlot
+	ReadAllowListGasCost   = contract.ReadGasCostPerSlot
+	// AllowListEventGasCost is the cost of emitting a RoleSet event: the base
+	// log cost, 4 topics and the 32 byte oldRole data.
+	AllowListEventGasCost = contract.LogGas + 4*contract.LogTopicGas + common.HashLength*contract.LogDataGas
+)
+
+var (
+	// AllowListRawABI contains the raw ABI of the allow list interface.
+	//go:embed allowlist.abi
+	AllowListRawABI string
+
+	AllowListABI = contract.ParseABI(AllowListRawABI)
+
+	ErrCannotModifyAllowList = errors.New("cannot modify allow list")
+)
+
+// GetAllowListStatus returns the allow list role of [address] for the precompile at [precompileAddr].
+func GetAllowListStatus(state contract.StateDB, precompileAddr common.Address, address common.Address) Role {
+	// Generate the state key for [address]
+	addressKey := common.BytesToHash(address.Bytes())
+	return Role(state.GetState(precompileAddr, addressKey))
+}
+
+// SetAllowListRole sets the role of [address] to [role] for the precompile at [precompileAddr].
+// Assumes [role] has already been verified as valid.
+// The role is stored under the key of [address] in the storage of [precompileAddr], so precompiles
+// embedding the allow list must not use these keys for their own state.
+func SetAllowListRole(stateDB contract.StateDB, precompileAddr, address common.Address, role Role) {
+	// Generate the state key for [address]
+	addressKey := common.BytesToHash(address.Bytes())
+	stateDB.SetState(precompileAddr, addressKey, common.Hash(role))
+}
+
+// PackModifyAllowList packs the call setting the role of [address] to [role].
+func PackModifyAllowList(address common.Address, role Role) ([]byte, error) {
+	funcName, err := role.GetSetterFunctionName()
+	if err != nil {
+		return nil, err
+	}
+	return AllowListABI.Pack(funcName, address)
+}
+
+// UnpackModifyAllowListInput attempts to unpack [input] as the address argument of the setter of [role].
+// Assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackModifyAllowListInput(input []byte, role Role) (common.Address, error) {
+	funcName, err := role.GetSetterFunctionName()
+	if err != nil {
+		return common.Address{}, err
+	}
+	// The allow list is deployed after Durango, so strict mode is not used.
+	res, err := AllowListABI.UnpackInput(funcName, input, false)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(res[0], new(common.Address)).(*common.Address), nil
+}
+
+// PackReadAllowList packs the call reading the role of [address].
+func PackReadAllowList(address common.Address) ([]byte, error) {
+	return AllowListABI.Pack("readAllowList", address)
+}
+
+// UnpackReadAllowListInput attempts to unpack [input] as the address argument of readAllowList.
+// Assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackReadAllowListInput(input []byte) (common.Address, error) {
+	res, err := AllowListABI.UnpackInput("readAllowList", input, false)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(res[0], new(common.Address)).(*common.Address), nil
+}
+
+// PackRoleSetEvent packs the RoleSet event emitted when [sender] changes the role of
+// [account] from [oldRole] to [role].
+func PackRoleSetEvent(role Role, account common.Address, sender common.Address, oldRole Role) ([]common.Hash, []byte, error) {
+	return AllowListABI.PackEvent("RoleSet", role.Big(), account, sender, oldRole.Big())
+}
+
+// createAllowListRoleSetter returns an execution function for setting the allow list role of the input address argument to [role].
+// This execution function is specific to [precompileAddr].
+func createAllowListRoleSetter(precompileAddr common.Address, role Role) contract.RunStatefulPrecompileFunc {
+	return func(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ModifyAllowListGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		modifyAddress, err := UnpackModifyAllowListInput(input, role)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+
+		stateDB := accessibleState.GetStateDB()
+
+		// Verify that the caller has the permission to move [modifyAddress] from its current role to [role]
+		callerRole := GetAllowListStatus(stateDB, precompileAddr, caller)
+		modifyRole := GetAllowListStatus(stateDB, precompileAddr, modifyAddress)
+		if !callerRole.CanModify(modifyRole, role) {
+			return nil, remainingGas, fmt.Errorf("%w: modify address: %s, from role: %s, to role: %s", ErrCannotModifyAllowList, modifyAddress, modifyRole, role)
+		}
+
+		if remainingGas, err = contract.DeductGas(remainingGas, AllowListEventGasCost); err != nil {
+			return nil, 0, err
+		}
+		topics, data, err := PackRoleSetEvent(role, modifyAddress, caller, modifyRole)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		stateDB.AddLog(precompileAddr, topics, data, accessibleState.GetBlockContext().Number().Uint64())
+
+		SetAllowListRole(stateDB, precompileAddr, modifyAddress, role)
+
+		return []byte{}, remainingGas, nil
+	}
+}
+
+// createReadAllowList returns an execution function that reads the allow list role of the input address argument.
+// This execution function is specific to [precompileAddr].
+func createReadAllowList(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadAllowListGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		readAddress, err := UnpackReadAllowListInput(input)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+
+		role := GetAllowListStatus(accessibleState.GetStateDB(), precompileAddr, readAddress)
+		return role.Bytes(), remainingGas, nil
+	}
+}
+
+// CreateAllowListPrecompile returns a StatefulPrecompiledContract with getters and setters for the allow list of [precompileAddr].
+func CreateAllowListPrecompile(precompileAddr common.Address) contract.StatefulPrecompiledContract {
+	// Construct the contract with no fallback function.
+	allowListFuncs := CreateAllowListFunctions(precompileAddr)
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, allowListFuncs)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
+
+// CreateAllowListFunctions returns the allow list functions of [precompileAddr], so that
+// precompiles can expose them along with their own functions.
+func CreateAllowListFunctions(precompileAddr common.Address) []*contract.StatefulPrecompileFunction {
+	var functions []*contract.StatefulPrecompileFunction
+
+	for _, role := range []Role{AdminRole, ManagerRole, EnabledRole, NoRole} {
+		funcName, err := role.GetSetterFunctionName()
+		if err != nil {
+			panic(err)
+		}
+		method, ok := AllowListABI.Methods[funcName]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", funcName))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, createAllowListRoleSetter(precompileAddr, role)))
+	}
+
+	method, ok := AllowListABI.Methods["readAllowList"]
+	if !ok {
+		panic(fmt.Errorf("given method (%s) does not exist in the ABI", "readAllowList"))
+	}
+	functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, createReadAllowList(precompileAddr)))
+
+	return functions
+}
@@ -0,0 +1,104 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/jeth/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AllowListConfig specifies the initial set of addresses with the Admin, Manager or Enabled roles.
+type AllowListConfig struct {
+	AdminAddresses   []common.Address `json:"adminAddresses,omitempty"`   // initial admin addresses
+	ManagerAddresses []common.Address `json:"managerAddresses,omitempty"` // initial manager addresses
+	EnabledAddresses []common.Address `json:"enabledAddresses,omitempty"` // initial enabled addresses
+}
+
+// Configure initializes the address space of [precompileAddr] by setting the roles of the addresses of [c].
+func (c *AllowListConfig) Configure(chainConfig precompileconfig.ChainConfig, precompileAddr common.Address, state contract.StateDB, blockContext contract.ConfigurationBlockContext) error {
+	for _, enabledAddr := range c.EnabledAddresses {
+		SetAllowListRole(state, precompileAddr, enabledAddr, EnabledRole)
+	}
+	for _, managerAddr := range c.ManagerAddresses {
+		SetAllowListRole(state, precompileAddr, managerAddr, ManagerRole)
+	}
+	for _, adminAddr := range c.AdminAddresses {
+		SetAllowListRole(state, precompileAddr, adminAddr, AdminRole)
+	}
+	return nil
+}
+
+// Equal returns true iff [other] has the same admins, managers and enabled addresses in the same order.
+func (c *AllowListConfig) Equal(other *AllowListConfig) bool {
+	if other == nil {
+		return false
+	}
+
+	return areEqualAddressLists(c.AdminAddresses, other.AdminAddresses) &&
+		areEqualAddressLists(c.ManagerAddresses, other.ManagerAddresses) &&
+		areEqualAddressLists(c.EnabledAddresses, other.EnabledAddresses)
+}
+
+// areEqualAddressLists returns true iff [a] and [b] have the same addresses in the same order.
+func areEqualAddressLists(current []common.Address, other []common.Address) bool {
+	if len(current) != len(other) {
+		return false
+	}
+	for i, address := range current {
+		if address != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify returns an error if an address is given more than one role, or if
+// addresses are given roles while the precompile of [upgrade] is disabled.
+func (c *AllowListConfig) Verify(chainConfig precompileconfig.ChainConfig, upgrade precompileconfig.Upgrade) error {
+	addressMap := make(map[common.Address]Role) // tracks which addresses we have seen and their role
+
+	// check for duplicates in enabled list
+	for _, enabledAddr := range c.EnabledAddresses {
+		if _, ok := addressMap[enabledAddr]; ok {
+			return fmt.Errorf("duplicate address in enabled list: %s", enabledAddr)
+		}
+		addressMap[enabledAddr] = EnabledRole
+	}
+
+	// check for overlap between enabled and admin lists or duplicates in admin list
+	for _, adminAddr := range c.AdminAddresses {
+		if role, ok := addressMap[adminAddr]; ok {
+			if role == AdminRole {
+				return fmt.Errorf("duplicate address in admin list: %s", adminAddr)
+			}
+			return fmt.Errorf("cannot set address as both admin and enabled: %s", adminAddr)
+		}
+		addressMap[adminAddr] = AdminRole
+	}
+
+	// check for overlap with the other lists or duplicates in manager list
+	for _, managerAddr := range c.ManagerAddresses {
+		if role, ok := addressMap[managerAddr]; ok {
+			switch role {
+			case ManagerRole:
+				return fmt.Errorf("duplicate address in manager list: %s", managerAddr)
+			case AdminRole:
+				return fmt.Errorf("cannot set address as both admin and manager: %s", managerAddr)
+			case EnabledRole:
+				return fmt.Errorf("cannot set address as both enabled and manager: %s", managerAddr)
+			}
+		}
+		addressMap[managerAddr] = ManagerRole
+	}
+
+	if upgrade.Disable && len(addressMap) > 0 {
+		return errors.New("cannot set roles when disabling the precompile")
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"testing"
+
+	"github.com/Juneo-io/jeth/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowListConfigVerify(t *testing.T) {
+	tests := map[string]struct {
+		config        AllowListConfig
+		disable       bool
+		expectedError string
+	}{
+		"empty": {},
+		"all roles": {
+			config: AllowListConfig{
+				AdminAddresses:   []common.Address{TestAdminAddr},
+				ManagerAddresses: []common.Address{TestManagerAddr},
+				EnabledAddresses: []common.Address{TestEnabledAddr},
+			},
+		},
+		"duplicate enabled": {
+			config:        AllowListConfig{EnabledAddresses: []common.Address{TestEnabledAddr, TestEnabledAddr}},
+			expectedError: "duplicate address in enabled list",
+		},
+		"duplicate admin": {
+			config:        AllowListConfig{AdminAddresses: []common.Address{TestAdminAddr, TestAdminAddr}},
+			expectedError: "duplicate address in admin list",
+		},
+		"duplicate manager": {
+			config:        AllowListConfig{ManagerAddresses: []common.Address{TestManagerAddr, TestManagerAddr}},
+			expectedError: "duplicate address in manager list",
+		},
+		"admin and enabled": {
+			config: AllowListConfig{
+				AdminAddresses:   []common.Address{TestAdminAddr},
+				EnabledAddresses: []common.Address{TestAdminAddr},
+			},
+			expectedError: "cannot set address as both admin and enabled",
+		},
+		"admin and manager": {
+			config: AllowListConfig{
+				AdminAddresses:   []common.Address{TestAdminAddr},
+				ManagerAddresses: []common.Address{TestAdminAddr},
+			},
+			expectedError: "cannot set address as both admin and manager",
+		},
+		"enabled and manager": {
+			config: AllowListConfig{
+				ManagerAddresses: []common.Address{TestEnabledAddr},
+				EnabledAddresses: []common.Address{TestEnabledAddr},
+			},
+			expectedError: "cannot set address as both enabled and manager",
+		},
+		"disable without roles": {
+			disable: true,
+		},
+		"roles set when disabling": {
+			config:        AllowListConfig{AdminAddresses: []common.Address{TestAdminAddr}},
+			disable:       true,
+			expectedError: "cannot set roles when disabling the precompile",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.config.Verify(nil, precompileconfig.Upgrade{Disable: test.disable})
+			if test.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestAllowListConfigEqual(t *testing.T) {
+	config := &AllowListConfig{
+		AdminAddresses:   []common.Address{TestAdminAddr},
+		ManagerAddresses: []common.Address{TestManagerAddr},
+		EnabledAddresses: []common.Address{TestEnabledAddr},
+	}
+	tests := map[string]struct {
+		other    *AllowListConfig
+		expected bool
+	}{
+		"nil": {
+			other: nil,
+		},
+		"same": {
+			other: &AllowListConfig{
+				AdminAddresses:   []common.Address{TestAdminAddr},
+				ManagerAddresses: []common.Address{TestManagerAddr},
+				EnabledAddresses: []common.Address{TestEnabledAddr},
+			},
+			expected: true,
+		},
+		"different admins": {
+			other: &AllowListConfig{
+				ManagerAddresses: []common.Address{TestManagerAddr},
+				EnabledAddresses: []common.Address{TestEnabledAddr},
+			},
+		},
+		"different enabled": {
+			other: &AllowListConfig{
+				AdminAddresses:   []common.Address{TestAdminAddr},
+				ManagerAddresses: []common.Address{TestManagerAddr},
+				EnabledAddresses: []common.Address{TestEnabledAddr, TestAdminAddr},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expected, config.Equal(test.other))
+		})
+	}
+}
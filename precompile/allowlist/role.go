@@ -0,0 +1,117 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Enum constants for valid Role
+var (
+	NoRole      = Role(common.BigToHash(common.Big0)) // No role assigned - this is equivalent to common.Hash{} and deletes the key from the DB when set
+	EnabledRole = Role(common.BigToHash(common.Big1)) // Enabled - allowed to use the resource gated by the allow list
+	AdminRole   = Role(common.BigToHash(common.Big2)) // Admin - allowed to modify any role, as well as use the gated resource
+	ManagerRole = Role(common.BigToHash(common.Big3)) // Manager - allowed to enable and disable addresses, as well as use the gated resource
+
+	ErrInvalidRole = errors.New("invalid permission role")
+)
+
+// Role mirrors the Solidity enum of roles of an allow list, stored as a
+// hash in the storage of the precompile.
+type Role common.Hash
+
+// Valid returns true iff [r] is a known role.
+func (r Role) Valid() bool {
+	switch r {
+	case NoRole, EnabledRole, ManagerRole, AdminRole:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsNoRole returns true if [r] indicates no specific role.
+func (r Role) IsNoRole() bool {
+	return r == NoRole
+}
+
+// IsAdmin returns true if [r] indicates the permission to modify the allow list.
+func (r Role) IsAdmin() bool {
+	return r == AdminRole
+}
+
+// IsManager returns true if [r] indicates the permission to enable and
+// disable addresses.
+func (r Role) IsManager() bool {
+	return r == ManagerRole
+}
+
+// IsEnabled returns true if [r] indicates that it has permission to access
+// the resource gated by the allow list.
+func (r Role) IsEnabled() bool {
+	switch r {
+	case EnabledRole, ManagerRole, AdminRole:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanModify returns true if [r] can change the role of an address from
+// [from] to [target].
+// Admins can change any role, managers can only move addresses between
+// [NoRole] and [EnabledRole].
+func (r Role) CanModify(from, target Role) bool {
+	switch r {
+	case AdminRole:
+		return true
+	case ManagerRole:
+		return (from == EnabledRole || from == NoRole) && (target == EnabledRole || target == NoRole)
+	default:
+		return false
+	}
+}
+
+func (r Role) Bytes() []byte {
+	return common.Hash(r).Bytes()
+}
+
+func (r Role) Big() *big.Int {
+	return common.Hash(r).Big()
+}
+
+// GetSetterFunctionName returns the name of the allow list function that
+// sets an address to [r].
+func (r Role) GetSetterFunctionName() (string, error) {
+	switch r {
+	case AdminRole:
+		return "setAdmin", nil
+	case ManagerRole:
+		return "setManager", nil
+	case EnabledRole:
+		return "setEnabled", nil
+	case NoRole:
+		return "setNone", nil
+	default:
+		return "", ErrInvalidRole
+	}
+}
+
+func (r Role) String() string {
+	switch r {
+	case NoRole:
+		return "NoRole"
+	case EnabledRole:
+		return "EnabledRole"
+	case ManagerRole:
+		return "ManagerRole"
+	case AdminRole:
+		return "AdminRole"
+	default:
+		return "UnknownRole"
+	}
+}
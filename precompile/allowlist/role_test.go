@@ -0,0 +1,73 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleCanModify(t *testing.T) {
+	roles := []Role{NoRole, EnabledRole, ManagerRole, AdminRole}
+	// canModify lists the role changes allowed to each role, from -> targets
+	canModify := map[Role]map[Role][]Role{
+		AdminRole: {
+			NoRole:      roles,
+			EnabledRole: roles,
+			ManagerRole: roles,
+			AdminRole:   roles,
+		},
+		ManagerRole: {
+			NoRole:      {NoRole, EnabledRole},
+			EnabledRole: {NoRole, EnabledRole},
+		},
+	}
+	for _, role := range roles {
+		for _, from := range roles {
+			for _, target := range roles {
+				t.Run(fmt.Sprintf("%s from %s to %s", role, from, target), func(t *testing.T) {
+					require.Equal(t, slices.Contains(canModify[role][from], target), role.CanModify(from, target))
+				})
+			}
+		}
+	}
+
+	// Unknown roles cannot modify anything
+	invalidRole := Role(common.BigToHash(common.Big32))
+	require.False(t, invalidRole.CanModify(NoRole, EnabledRole))
+}
+
+func TestRoleValid(t *testing.T) {
+	tests := []struct {
+		role     Role
+		valid    bool
+		enabled  bool
+		expected string
+	}{
+		{role: NoRole, valid: true, expected: "NoRole"},
+		{role: EnabledRole, valid: true, enabled: true, expected: "EnabledRole"},
+		{role: ManagerRole, valid: true, enabled: true, expected: "ManagerRole"},
+		{role: AdminRole, valid: true, enabled: true, expected: "AdminRole"},
+		{role: Role(common.BigToHash(common.Big32)), expected: "UnknownRole"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			require := require.New(t)
+
+			require.Equal(test.valid, test.role.Valid())
+			require.Equal(test.enabled, test.role.IsEnabled())
+			require.Equal(test.expected, test.role.String())
+			_, err := test.role.GetSetterFunctionName()
+			if test.valid {
+				require.NoError(err)
+			} else {
+				require.ErrorIs(err, ErrInvalidRole)
+			}
+		})
+	}
+}
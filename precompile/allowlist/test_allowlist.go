@@ -0,0 +1,151 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"testing"
+
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/jeth/precompile/modules"
+	"github.com/Juneo-io/jeth/precompile/precompileconfig"
+	"github.com/Juneo-io/jeth/precompile/testutils"
+	"github.com/Juneo-io/jeth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// Addresses given each role by the configs of the allow list tests
+var (
+	TestAdminAddr   = common.HexToAddress("0x0000000000000000000000000000000000000011")
+	TestManagerAddr = common.HexToAddress("0x0000000000000000000000000000000000000022")
+	TestEnabledAddr = common.HexToAddress("0x0000000000000000000000000000000000000033")
+	TestNoRoleAddr  = common.HexToAddress("0x0000000000000000000000000000000000000044")
+)
+
+// AllowListTests returns the tests of the allow list functions of [module].
+// [config] must give [TestAdminAddr], [TestManagerAddr] and [TestEnabledAddr]
+// their roles, and no role to [TestNoRoleAddr].
+func AllowListTests(module modules.Module, config precompileconfig.Config) map[string]testutils.PrecompileTest {
+	modifyGas := ModifyAllowListGasCost + AllowListEventGasCost
+	packModify := func(address common.Address, role Role) func(t testing.TB) []byte {
+		return func(t testing.TB) []byte {
+			input, err := PackModifyAllowList(address, role)
+			require.NoError(t, err)
+			return input
+		}
+	}
+	requireRole := func(address common.Address, role Role) func(t testing.TB, state contract.StateDB) {
+		return func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, role, GetAllowListStatus(state, module.Address, address))
+		}
+	}
+
+	return map[string]testutils.PrecompileTest{
+		"initial roles": {
+			Config: config,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, AdminRole, GetAllowListStatus(state, module.Address, TestAdminAddr))
+				require.Equal(t, ManagerRole, GetAllowListStatus(state, module.Address, TestManagerAddr))
+				require.Equal(t, EnabledRole, GetAllowListStatus(state, module.Address, TestEnabledAddr))
+				require.Equal(t, NoRole, GetAllowListStatus(state, module.Address, TestNoRoleAddr))
+			},
+		},
+		"admin sets admin": {
+			Caller:      TestAdminAddr,
+			Config:      config,
+			InputFn:     packModify(TestNoRoleAddr, AdminRole),
+			SuppliedGas: modifyGas,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, AdminRole, GetAllowListStatus(state, module.Address, TestNoRoleAddr))
+
+				logsTopics, logsData := state.GetLogData()
+				require.Len(t, logsTopics, 1)
+				topics, data, err := PackRoleSetEvent(AdminRole, TestNoRoleAddr, TestAdminAddr, NoRole)
+				require.NoError(t, err)
+				require.Equal(t, topics, logsTopics[0])
+				require.Equal(t, data, logsData[0])
+			},
+		},
+		"admin revokes manager": {
+			Caller:      TestAdminAddr,
+			Config:      config,
+			InputFn:     packModify(TestManagerAddr, NoRole),
+			SuppliedGas: modifyGas,
+			ExpectedRes: []byte{},
+			AfterHook:   requireRole(TestManagerAddr, NoRole),
+		},
+		"manager sets enabled": {
+			Caller:      TestManagerAddr,
+			Config:      config,
+			InputFn:     packModify(TestNoRoleAddr, EnabledRole),
+			SuppliedGas: modifyGas,
+			ExpectedRes: []byte{},
+			AfterHook:   requireRole(TestNoRoleAddr, EnabledRole),
+		},
+		"manager cannot set admin": {
+			Caller:      TestManagerAddr,
+			Config:      config,
+			InputFn:     packModify(TestNoRoleAddr, AdminRole),
+			SuppliedGas: ModifyAllowListGasCost,
+			ExpectedErr: ErrCannotModifyAllowList.Error(),
+			AfterHook:   requireRole(TestNoRoleAddr, NoRole),
+		},
+		"manager cannot revoke admin": {
+			Caller:      TestManagerAddr,
+			Config:      config,
+			InputFn:     packModify(TestAdminAddr, NoRole),
+			SuppliedGas: ModifyAllowListGasCost,
+			ExpectedErr: ErrCannotModifyAllowList.Error(),
+			AfterHook:   requireRole(TestAdminAddr, AdminRole),
+		},
+		"enabled cannot set enabled": {
+			Caller:      TestEnabledAddr,
+			Config:      config,
+			InputFn:     packModify(TestNoRoleAddr, EnabledRole),
+			SuppliedGas: ModifyAllowListGasCost,
+			ExpectedErr: ErrCannotModifyAllowList.Error(),
+		},
+		"admin sets enabled readOnly": {
+			Caller:      TestAdminAddr,
+			Config:      config,
+			InputFn:     packModify(TestNoRoleAddr, EnabledRole),
+			SuppliedGas: ModifyAllowListGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"admin sets enabled insufficient gas": {
+			Caller:      TestAdminAddr,
+			Config:      config,
+			InputFn:     packModify(TestNoRoleAddr, EnabledRole),
+			SuppliedGas: modifyGas - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"read allow list": {
+			Caller: TestNoRoleAddr,
+			Config: config,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackReadAllowList(TestManagerAddr)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: ReadAllowListGasCost,
+			ReadOnly:    true,
+			ExpectedRes: ManagerRole.Bytes(),
+		},
+	}
+}
+
+// RunPrecompileWithAllowListTests runs [contractTests] along with the allow
+// list tests of [module] configured with [config].
+func RunPrecompileWithAllowListTests(t *testing.T, module modules.Module, newStateDB func(t testing.TB) contract.StateDB, config precompileconfig.Config, contractTests map[string]testutils.PrecompileTest) {
+	tests := AllowListTests(module, config)
+	for name, test := range contractTests {
+		if _, exists := tests[name]; exists {
+			t.Fatalf("duplicate test name: %s", name)
+		}
+		tests[name] = test
+	}
+	testutils.RunPrecompileTests(t, module, newStateDB, tests)
+}
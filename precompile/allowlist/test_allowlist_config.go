@@ -0,0 +1,108 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"testing"
+
+	"github.com/Juneo-io/jeth/precompile/precompileconfig"
+	"github.com/Juneo-io/jeth/precompile/testutils"
+	"github.com/Juneo-io/jeth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+)
+
+// NewConfigFunc returns a config of a precompile with an allow list, enabled
+// at [blockTimestamp] with the given [admins], [enableds] and [managers].
+type NewConfigFunc func(blockTimestamp *uint64, admins, enableds, managers []common.Address) precompileconfig.Config
+
+// VerifyPrecompileWithAllowListTests runs [verifyTests] along with the
+// verification tests of the allow list of the configs returned by [newConfig].
+func VerifyPrecompileWithAllowListTests(t *testing.T, newConfig NewConfigFunc, verifyTests map[string]testutils.ConfigVerifyTest) {
+	tests := map[string]testutils.ConfigVerifyTest{
+		"valid allow list": {
+			Config: newConfig(utils.NewUint64(3), []common.Address{TestAdminAddr}, []common.Address{TestEnabledAddr}, []common.Address{TestManagerAddr}),
+		},
+		"duplicate admin": {
+			Config:        newConfig(utils.NewUint64(3), []common.Address{TestAdminAddr, TestAdminAddr}, nil, nil),
+			ExpectedError: "duplicate address in admin list",
+		},
+		"duplicate enabled": {
+			Config:        newConfig(utils.NewUint64(3), nil, []common.Address{TestEnabledAddr, TestEnabledAddr}, nil),
+			ExpectedError: "duplicate address in enabled list",
+		},
+		"duplicate manager": {
+			Config:        newConfig(utils.NewUint64(3), nil, nil, []common.Address{TestManagerAddr, TestManagerAddr}),
+			ExpectedError: "duplicate address in manager list",
+		},
+		"admin and enabled": {
+			Config:        newConfig(utils.NewUint64(3), []common.Address{TestAdminAddr}, []common.Address{TestAdminAddr}, nil),
+			ExpectedError: "cannot set address as both admin and enabled",
+		},
+		"admin and manager": {
+			Config:        newConfig(utils.NewUint64(3), []common.Address{TestAdminAddr}, nil, []common.Address{TestAdminAddr}),
+			ExpectedError: "cannot set address as both admin and manager",
+		},
+		"enabled and manager": {
+			Config:        newConfig(utils.NewUint64(3), nil, []common.Address{TestManagerAddr}, []common.Address{TestManagerAddr}),
+			ExpectedError: "cannot set address as both enabled and manager",
+		},
+	}
+	for name, test := range verifyTests {
+		if _, exists := tests[name]; exists {
+			t.Fatalf("duplicate test name: %s", name)
+		}
+		tests[name] = test
+	}
+	testutils.RunVerifyTests(t, tests)
+}
+
+// EqualPrecompileWithAllowListTests runs [equalTests] along with the equality
+// tests of the allow list of the configs returned by [newConfig].
+func EqualPrecompileWithAllowListTests(t *testing.T, newConfig NewConfigFunc, equalTests map[string]testutils.ConfigEqualTest) {
+	tests := map[string]testutils.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   newConfig(utils.NewUint64(3), []common.Address{TestAdminAddr}, nil, nil),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   newConfig(utils.NewUint64(3), []common.Address{TestAdminAddr}, nil, nil),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   newConfig(utils.NewUint64(3), []common.Address{TestAdminAddr}, nil, nil),
+			Other:    newConfig(utils.NewUint64(4), []common.Address{TestAdminAddr}, nil, nil),
+			Expected: false,
+		},
+		"different admins": {
+			Config:   newConfig(utils.NewUint64(3), []common.Address{TestAdminAddr}, nil, nil),
+			Other:    newConfig(utils.NewUint64(3), []common.Address{TestManagerAddr}, nil, nil),
+			Expected: false,
+		},
+		"different enableds": {
+			Config:   newConfig(utils.NewUint64(3), nil, []common.Address{TestEnabledAddr}, nil),
+			Other:    newConfig(utils.NewUint64(3), nil, []common.Address{TestManagerAddr}, nil),
+			Expected: false,
+		},
+		"different managers": {
+			Config:   newConfig(utils.NewUint64(3), nil, nil, []common.Address{TestManagerAddr}),
+			Other:    newConfig(utils.NewUint64(3), nil, nil, []common.Address{TestEnabledAddr}),
+			Expected: false,
+		},
+		"same allow list": {
+			Config:   newConfig(utils.NewUint64(3), []common.Address{TestAdminAddr}, []common.Address{TestEnabledAddr}, []common.Address{TestManagerAddr}),
+			Other:    newConfig(utils.NewUint64(3), []common.Address{TestAdminAddr}, []common.Address{TestEnabledAddr}, []common.Address{TestManagerAddr}),
+			Expected: true,
+		},
+	}
+	for name, test := range equalTests {
+		if _, exists := tests[name]; exists {
+			t.Fatalf("duplicate test name: %s", name)
+		}
+		tests[name] = test
+	}
+	testutils.RunEqualTests(t, tests)
+}
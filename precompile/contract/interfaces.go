@@ -29,6 +29,10 @@ type StateDB interface {
 	GetBalance(common.Address) *big.Int
 	AddBalance(common.Address, *big.Int)
 	GetBalanceMultiCoin(common.Address, common.Hash) *big.Int
+	AddBalanceMultiCoin(common.Address, common.Hash, *big.Int)
+	SubBalanceMultiCoin(common.Address, common.Hash, *big.Int)
+
+	SetCode(common.Address, []byte)
 
 	CreateAccount(common.Address)
 	Exist(common.Address) bool
@@ -0,0 +1,379 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package blsverifier
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Juneo-io/jeth/accounts/abi"
+	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/jeth/vmerrs"
+	"github.com/Juneo-io/juneogo/utils/crypto/bls"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+var (
+	// BLSVerifierRawABI contains the raw ABI of the BLS verifier functions.
+	//go:embed contract.abi
+	BLSVerifierRawABI string
+
+	BLSVerifierABI = contract.ParseABI(BLSVerifierRawABI)
+
+	// Singleton StatefulPrecompiledContract for verifying BLS signatures.
+	BLSVerifierPrecompile contract.StatefulPrecompiledContract = createBLSVerifierPrecompile()
+
+	errInvalidPublicKey = errors.New("invalid BLS public key")
+	errInvalidSignature = errors.New("invalid BLS signature")
+)
+
+// VerifySignatureInput holds the arguments of verifySignature.
+type VerifySignatureInput struct {
+	PublicKey []byte
+	Signature []byte
+	Message   []byte
+}
+
+// VerifyAggregateSignatureInput holds the arguments of verifyAggregateSignature.
+type VerifyAggregateSignatureInput struct {
+	PublicKeys [][]byte
+	Signature  []byte
+	Message    []byte
+}
+
+// PackVerifySignature packs the call verifying that [input.Signature] is the
+// signature of [input.Message] by [input.PublicKey].
+func PackVerifySignature(input VerifySignatureInput) ([]byte, error) {
+	return BLSVerifierABI.Pack("verifySignature", input.PublicKey, input.Signature, input.Message)
+}
+
+// UnpackVerifySignatureInput attempts to unpack [input] as the arguments of verifySignature.
+// Assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackVerifySignatureInput(input []byte) (VerifySignatureInput, error) {
+	// The BLS verifier is deployed after Durango, so strict mode is not used.
+	res, err := BLSVerifierABI.UnpackInput("verifySignature", input, false)
+	if err != nil {
+		return VerifySignatureInput{}, err
+	}
+	return VerifySignatureInput{
+		PublicKey: *abi.ConvertType(res[0], new([]byte)).(*[]byte),
+		Signature: *abi.ConvertType(res[1], new([]byte)).(*[]byte),
+		Message:   *abi.ConvertType(res[2], new([]byte)).(*[]byte),
+	}, nil
+}
+
+// VerifyProofOfPossessionInput holds the arguments of verifyProofOfPossession.
+type VerifyProofOfPossessionInput struct {
+	PublicKey         []byte
+	ProofOfPossession []byte
+}
+
+// PackVerifyProofOfPossession packs the call verifying that
+// [input.ProofOfPossession] proves the possession of the secret key of
+// [input.PublicKey].
+func PackVerifyProofOfPossession(input VerifyProofOfPossessionInput) ([]byte, error) {
+	return BLSVerifierABI.Pack("verifyProofOfPossession", input.PublicKey, input.ProofOfPossession)
+}
+
+// UnpackVerifyProofOfPossessionInput attempts to unpack [input] as the arguments of verifyProofOfPossession.
+// Assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackVerifyProofOfPossessionInput(input []byte) (VerifyProofOfPossessionInput, error) {
+	res, err := BLSVerifierABI.UnpackInput("verifyProofOfPossession", input, false)
+	if err != nil {
+		return VerifyProofOfPossessionInput{}, err
+	}
+	return VerifyProofOfPossessionInput{
+		PublicKey:         *abi.ConvertType(res[0], new([]byte)).(*[]byte),
+		ProofOfPossession: *abi.ConvertType(res[1], new([]byte)).(*[]byte),
+	}, nil
+}
+
+// PackVerifyAggregateSignature packs the call verifying that [input.Signature] is
+// the aggregate signature of [input.Message] by [input.PublicKeys].
+// [input.PublicKeys] must have a verified proof of possession.
+func PackVerifyAggregateSignature(input VerifyAggregateSignatureInput) ([]byte, error) {
+	return BLSVerifierABI.Pack("verifyAggregateSignature", input.PublicKeys, input.Signature, input.Message)
+}
+
+// UnpackVerifyAggregateSignatureInput attempts to unpack [input] as the arguments of verifyAggregateSignature.
+// Assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackVerifyAggregateSignatureInput(input []byte) (VerifyAggregateSignatureInput, error) {
+	res, err := BLSVerifierABI.UnpackInput("verifyAggregateSignature", input, false)
+	if err != nil {
+		return VerifyAggregateSignatureInput{}, err
+	}
+	return VerifyAggregateSignatureInput{
+		PublicKeys: *abi.ConvertType(res[0], new([][]byte)).(*[][]byte),
+		Signature:  *abi.ConvertType(res[1], new([]byte)).(*[]byte),
+		Message:    *abi.ConvertType(res[2], new([]byte)).(*[]byte),
+	}, nil
+}
+
+// PackAggregatePublicKeys packs the call aggregating [publicKeys].
+func PackAggregatePublicKeys(publicKeys [][]byte) ([]byte, error) {
+	return BLSVerifierABI.Pack("aggregatePublicKeys", publicKeys)
+}
+
+// PackAggregateSignatures packs the call aggregating [signatures].
+func PackAggregateSignatures(signatures [][]byte) ([]byte, error) {
+	return BLSVerifierABI.Pack("aggregateSignatures", signatures)
+}
+
+// unpackBytesArrayInput attempts to unpack [input] as the bytes array argument of [functionName].
+func unpackBytesArrayInput(functionName string, input []byte) ([][]byte, error) {
+	res, err := BLSVerifierABI.UnpackInput(functionName, input, false)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(res[0], new([][]byte)).(*[][]byte), nil
+}
+
+// deductInputGas deducts the gas charged for each word of [input] from [suppliedGas].
+// It is charged before unpacking the variable sized input.
+func deductInputGas(suppliedGas uint64, input []byte) (uint64, error) {
+	inputGas, overflow := math.SafeMul(params.BLSInputPerWordGas, toWordSize(len(input)))
+	if overflow {
+		return 0, vmerrs.ErrOutOfGas
+	}
+	return contract.DeductGas(suppliedGas, inputGas)
+}
+
+// deductAggregationGas deducts the gas charged for aggregating [count] elements
+// at [gasPerElement] from [suppliedGas].
+func deductAggregationGas(suppliedGas uint64, gasPerElement uint64, count int) (uint64, error) {
+	aggregationGas, overflow := math.SafeMul(gasPerElement, uint64(count))
+	if overflow {
+		return 0, vmerrs.ErrOutOfGas
+	}
+	return contract.DeductGas(suppliedGas, aggregationGas)
+}
+
+func toWordSize(size int) uint64 {
+	return (uint64(size) + 31) / 32
+}
+
+// parsePublicKeys parses the compressed public keys [publicKeysBytes].
+func parsePublicKeys(publicKeysBytes [][]byte) ([]*bls.PublicKey, error) {
+	publicKeys := make([]*bls.PublicKey, len(publicKeysBytes))
+	for i, publicKeyBytes := range publicKeysBytes {
+		publicKey, err := bls.PublicKeyFromCompressedBytes(publicKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w at index %d: %s", errInvalidPublicKey, i, err)
+		}
+		publicKeys[i] = publicKey
+	}
+	return publicKeys, nil
+}
+
+// verify returns true if [signatureBytes] is a valid signature of [message] by [publicKey].
+func verify(publicKey *bls.PublicKey, signatureBytes []byte, message []byte) (bool, error) {
+	signature, err := bls.SignatureFromBytes(signatureBytes)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", errInvalidSignature, err)
+	}
+	return bls.Verify(publicKey, signature, message), nil
+}
+
+// verifySignature returns true if the signature of the input is a valid signature
+// of the message by the public key.
+// Malformed public keys and signatures revert.
+func verifySignature(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, params.BLSVerifySignatureGas); err != nil {
+		return nil, 0, err
+	}
+	if remainingGas, err = deductInputGas(remainingGas, input); err != nil {
+		return nil, 0, err
+	}
+
+	args, err := UnpackVerifySignatureInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	publicKey, err := bls.PublicKeyFromCompressedBytes(args.PublicKey)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidPublicKey, err)
+	}
+	valid, err := verify(publicKey, args.Signature, args.Message)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	output, err := BLSVerifierABI.PackOutput("verifySignature", valid)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// verifyProofOfPossession returns true if the proof of possession of the input
+// is the signature of the compressed public key with the proof of possession
+// ciphersuite, as registered by the validators along with their key.
+// Malformed public keys and proofs of possession revert.
+func verifyProofOfPossession(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, params.BLSVerifyProofOfPossessionGas); err != nil {
+		return nil, 0, err
+	}
+	if remainingGas, err = deductInputGas(remainingGas, input); err != nil {
+		return nil, 0, err
+	}
+
+	args, err := UnpackVerifyProofOfPossessionInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	publicKey, err := bls.PublicKeyFromCompressedBytes(args.PublicKey)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidPublicKey, err)
+	}
+	proofOfPossession, err := bls.SignatureFromBytes(args.ProofOfPossession)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidSignature, err)
+	}
+	valid := bls.VerifyProofOfPossession(publicKey, proofOfPossession, args.PublicKey)
+
+	output, err := BLSVerifierABI.PackOutput("verifyProofOfPossession", valid)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// verifyAggregateSignature returns true if the signature of the input is a valid
+// aggregate signature of the message by all the public keys.
+// Malformed public keys and signatures revert.
+// The proofs of possession of the public keys are not verified, so a rogue key
+// chosen from the other keys can forge a valid aggregate signature. Callers must
+// only pass keys whose proof of possession was verified, with
+// verifyProofOfPossession or by registering them as validators.
+func verifyAggregateSignature(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, params.BLSVerifySignatureGas); err != nil {
+		return nil, 0, err
+	}
+	if remainingGas, err = deductInputGas(remainingGas, input); err != nil {
+		return nil, 0, err
+	}
+
+	args, err := UnpackVerifyAggregateSignatureInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if remainingGas, err = deductAggregationGas(remainingGas, params.BLSAggregatePublicKeyGas, len(args.PublicKeys)); err != nil {
+		return nil, 0, err
+	}
+	publicKeys, err := parsePublicKeys(args.PublicKeys)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	aggregatePublicKey, err := bls.AggregatePublicKeys(publicKeys)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	valid, err := verify(aggregatePublicKey, args.Signature, args.Message)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	output, err := BLSVerifierABI.PackOutput("verifyAggregateSignature", valid)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// aggregatePublicKeys returns the compressed aggregate of the public keys of the input.
+// As for verifyAggregateSignature, the proofs of possession of the public keys
+// are not verified.
+func aggregatePublicKeys(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductInputGas(suppliedGas, input); err != nil {
+		return nil, 0, err
+	}
+
+	publicKeysBytes, err := unpackBytesArrayInput("aggregatePublicKeys", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if remainingGas, err = deductAggregationGas(remainingGas, params.BLSAggregatePublicKeyGas, len(publicKeysBytes)); err != nil {
+		return nil, 0, err
+	}
+	publicKeys, err := parsePublicKeys(publicKeysBytes)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	aggregatePublicKey, err := bls.AggregatePublicKeys(publicKeys)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	output, err := BLSVerifierABI.PackOutput("aggregatePublicKeys", bls.PublicKeyToCompressedBytes(aggregatePublicKey))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// aggregateSignatures returns the aggregate of the signatures of the input.
+func aggregateSignatures(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductInputGas(suppliedGas, input); err != nil {
+		return nil, 0, err
+	}
+
+	signaturesBytes, err := unpackBytesArrayInput("aggregateSignatures", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if remainingGas, err = deductAggregationGas(remainingGas, params.BLSAggregateSignatureGas, len(signaturesBytes)); err != nil {
+		return nil, 0, err
+	}
+	signatures := make([]*bls.Signature, len(signaturesBytes))
+	for i, signatureBytes := range signaturesBytes {
+		signature, err := bls.SignatureFromBytes(signatureBytes)
+		if err != nil {
+			return nil, remainingGas, fmt.Errorf("%w at index %d: %s", errInvalidSignature, i, err)
+		}
+		signatures[i] = signature
+	}
+	aggregateSignature, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	output, err := BLSVerifierABI.PackOutput("aggregateSignatures", bls.SignatureToBytes(aggregateSignature))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// createBLSVerifierPrecompile returns a StatefulPrecompiledContract exposing the
+// BLS signature verification and aggregation functions.
+func createBLSVerifierPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"verifySignature":          verifySignature,
+		"verifyAggregateSignature": verifyAggregateSignature,
+		"verifyProofOfPossession":  verifyProofOfPossession,
+		"aggregatePublicKeys":      aggregatePublicKeys,
+		"aggregateSignatures":      aggregateSignatures,
+	}
+	for name, function := range abiFunctionMap {
+		method, ok := BLSVerifierABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
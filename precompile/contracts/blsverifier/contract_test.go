@@ -0,0 +1,194 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package blsverifier
+
+import (
+	"testing"
+
+	"github.com/Juneo-io/jeth/core/state"
+	"github.com/Juneo-io/jeth/params"
+	"github.com/Juneo-io/jeth/precompile/testutils"
+	"github.com/Juneo-io/jeth/vmerrs"
+	"github.com/Juneo-io/juneogo/utils/crypto/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBLSVerifier(t *testing.T) {
+	require := require.New(t)
+
+	message := []byte("message")
+	otherMessage := []byte("other message")
+	secretKeys := make([]*bls.SecretKey, 3)
+	publicKeys := make([][]byte, len(secretKeys))
+	signatures := make([]*bls.Signature, len(secretKeys))
+	proofsOfPossession := make([][]byte, len(secretKeys))
+	for i := range secretKeys {
+		secretKey, err := bls.NewSecretKey()
+		require.NoError(err)
+		secretKeys[i] = secretKey
+		publicKeys[i] = bls.PublicKeyToCompressedBytes(bls.PublicFromSecretKey(secretKey))
+		signatures[i] = bls.Sign(secretKey, message)
+		proofsOfPossession[i] = bls.SignatureToBytes(bls.SignProofOfPossession(secretKey, publicKeys[i]))
+	}
+	aggregateSignature, err := bls.AggregateSignatures(signatures)
+	require.NoError(err)
+	aggregateSignatureBytes := bls.SignatureToBytes(aggregateSignature)
+	signatureBytes := make([][]byte, len(signatures))
+	for i, signature := range signatures {
+		signatureBytes[i] = bls.SignatureToBytes(signature)
+	}
+	aggregatePublicKey, err := bls.AggregatePublicKeys([]*bls.PublicKey{
+		bls.PublicFromSecretKey(secretKeys[0]),
+		bls.PublicFromSecretKey(secretKeys[1]),
+		bls.PublicFromSecretKey(secretKeys[2]),
+	})
+	require.NoError(err)
+
+	inputGas := func(input []byte) uint64 {
+		return params.BLSInputPerWordGas * toWordSize(len(input))
+	}
+	packOutput := func(name string, args ...interface{}) []byte {
+		output, err := BLSVerifierABI.PackOutput(name, args...)
+		require.NoError(err)
+		return output
+	}
+	packVerify := func(publicKey []byte, signature []byte, message []byte) []byte {
+		input, err := PackVerifySignature(VerifySignatureInput{PublicKey: publicKey, Signature: signature, Message: message})
+		require.NoError(err)
+		return input
+	}
+	packVerifyAggregate := func(publicKeys [][]byte, signature []byte, message []byte) []byte {
+		input, err := PackVerifyAggregateSignature(VerifyAggregateSignatureInput{PublicKeys: publicKeys, Signature: signature, Message: message})
+		require.NoError(err)
+		return input
+	}
+
+	validVerify := packVerify(publicKeys[0], signatureBytes[0], message)
+	invalidVerify := packVerify(publicKeys[1], signatureBytes[0], message)
+	malformedKeyVerify := packVerify([]byte{1, 2, 3}, signatureBytes[0], message)
+	malformedSignatureVerify := packVerify(publicKeys[0], []byte{1, 2, 3}, message)
+	packVerifyPoP := func(publicKey []byte, proofOfPossession []byte) []byte {
+		input, err := PackVerifyProofOfPossession(VerifyProofOfPossessionInput{PublicKey: publicKey, ProofOfPossession: proofOfPossession})
+		require.NoError(err)
+		return input
+	}
+
+	validVerifyPoP := packVerifyPoP(publicKeys[0], proofsOfPossession[0])
+	otherKeyVerifyPoP := packVerifyPoP(publicKeys[1], proofsOfPossession[0])
+	// A signature of the public key is not a proof of possession.
+	signatureVerifyPoP := packVerifyPoP(publicKeys[0], bls.SignatureToBytes(bls.Sign(secretKeys[0], publicKeys[0])))
+	malformedVerifyPoP := packVerifyPoP(publicKeys[0], []byte{1, 2, 3})
+	validAggregateVerify := packVerifyAggregate(publicKeys, aggregateSignatureBytes, message)
+	otherMessageAggregateVerify := packVerifyAggregate(publicKeys, aggregateSignatureBytes, otherMessage)
+	missingKeyAggregateVerify := packVerifyAggregate(publicKeys[:2], aggregateSignatureBytes, message)
+	aggregateKeysInput, err := PackAggregatePublicKeys(publicKeys)
+	require.NoError(err)
+	noKeys, err := PackAggregatePublicKeys(nil)
+	require.NoError(err)
+	aggregateSignaturesInput, err := PackAggregateSignatures(signatureBytes)
+	require.NoError(err)
+
+	verifyGas := params.BLSVerifySignatureGas + inputGas(validVerify)
+	verifyPoPGas := params.BLSVerifyProofOfPossessionGas + inputGas(validVerifyPoP)
+	aggregateVerifyGas := params.BLSVerifySignatureGas + inputGas(validAggregateVerify) + 3*params.BLSAggregatePublicKeyGas
+	tests := map[string]testutils.PrecompileTest{
+		"verify valid signature": {
+			Input:       validVerify,
+			SuppliedGas: verifyGas,
+			ExpectedRes: packOutput("verifySignature", true),
+		},
+		"verify signature of other key": {
+			Input:       invalidVerify,
+			SuppliedGas: params.BLSVerifySignatureGas + inputGas(invalidVerify),
+			ExpectedRes: packOutput("verifySignature", false),
+		},
+		"verify signature readOnly": {
+			Input:       validVerify,
+			SuppliedGas: verifyGas,
+			ReadOnly:    true,
+			ExpectedRes: packOutput("verifySignature", true),
+		},
+		"verify signature out of gas": {
+			Input:       validVerify,
+			SuppliedGas: verifyGas - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"verify malformed public key": {
+			Input:       malformedKeyVerify,
+			SuppliedGas: params.BLSVerifySignatureGas + inputGas(malformedKeyVerify),
+			ExpectedErr: errInvalidPublicKey.Error(),
+		},
+		"verify malformed signature": {
+			Input:       malformedSignatureVerify,
+			SuppliedGas: params.BLSVerifySignatureGas + inputGas(malformedSignatureVerify),
+			ExpectedErr: errInvalidSignature.Error(),
+		},
+		"verify valid proof of possession": {
+			Input:       validVerifyPoP,
+			SuppliedGas: verifyPoPGas,
+			ExpectedRes: packOutput("verifyProofOfPossession", true),
+		},
+		"verify proof of possession of other key": {
+			Input:       otherKeyVerifyPoP,
+			SuppliedGas: params.BLSVerifyProofOfPossessionGas + inputGas(otherKeyVerifyPoP),
+			ExpectedRes: packOutput("verifyProofOfPossession", false),
+		},
+		"verify signature of public key as proof of possession": {
+			Input:       signatureVerifyPoP,
+			SuppliedGas: params.BLSVerifyProofOfPossessionGas + inputGas(signatureVerifyPoP),
+			ExpectedRes: packOutput("verifyProofOfPossession", false),
+		},
+		"verify malformed proof of possession": {
+			Input:       malformedVerifyPoP,
+			SuppliedGas: params.BLSVerifyProofOfPossessionGas + inputGas(malformedVerifyPoP),
+			ExpectedErr: errInvalidSignature.Error(),
+		},
+		"verify proof of possession out of gas": {
+			Input:       validVerifyPoP,
+			SuppliedGas: verifyPoPGas - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"verify valid aggregate signature": {
+			Input:       validAggregateVerify,
+			SuppliedGas: aggregateVerifyGas,
+			ExpectedRes: packOutput("verifyAggregateSignature", true),
+		},
+		"verify aggregate signature of other message": {
+			Input:       otherMessageAggregateVerify,
+			SuppliedGas: params.BLSVerifySignatureGas + inputGas(otherMessageAggregateVerify) + 3*params.BLSAggregatePublicKeyGas,
+			ExpectedRes: packOutput("verifyAggregateSignature", false),
+		},
+		"verify aggregate signature with missing key": {
+			Input:       missingKeyAggregateVerify,
+			SuppliedGas: params.BLSVerifySignatureGas + inputGas(missingKeyAggregateVerify) + 2*params.BLSAggregatePublicKeyGas,
+			ExpectedRes: packOutput("verifyAggregateSignature", false),
+		},
+		"verify aggregate signature out of gas": {
+			Input:       validAggregateVerify,
+			SuppliedGas: aggregateVerifyGas - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"aggregate public keys": {
+			Input:       aggregateKeysInput,
+			SuppliedGas: inputGas(aggregateKeysInput) + 3*params.BLSAggregatePublicKeyGas,
+			ExpectedRes: packOutput("aggregatePublicKeys", bls.PublicKeyToCompressedBytes(aggregatePublicKey)),
+		},
+		"aggregate no public keys": {
+			Input:       noKeys,
+			SuppliedGas: inputGas(noKeys),
+			ExpectedErr: bls.ErrNoPublicKeys.Error(),
+		},
+		"aggregate signatures": {
+			Input:       aggregateSignaturesInput,
+			SuppliedGas: inputGas(aggregateSignaturesInput) + 3*params.BLSAggregateSignatureGas,
+			ExpectedRes: packOutput("aggregateSignatures", aggregateSignatureBytes),
+		},
+		"aggregate signatures out of gas": {
+			Input:       aggregateSignaturesInput,
+			SuppliedGas: inputGas(aggregateSignaturesInput) + 3*params.BLSAggregateSignatureGas - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+	}
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
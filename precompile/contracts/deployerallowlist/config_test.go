@@ -0,0 +1,55 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package deployerallowlist
+
+import (
+	"testing"
+
+	"github.com/Juneo-io/jeth/precompile/allowlist"
+	"github.com/Juneo-io/jeth/precompile/precompileconfig"
+	"github.com/Juneo-io/jeth/precompile/testutils"
+	"github.com/Juneo-io/jeth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestConfig(blockTimestamp *uint64, admins, enableds, managers []common.Address) precompileconfig.Config {
+	return NewConfig(blockTimestamp, admins, enableds, managers)
+}
+
+func TestVerify(t *testing.T) {
+	tests := map[string]testutils.ConfigVerifyTest{
+		"roles set when disabling": {
+			Config: &Config{
+				Upgrade:         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3), Disable: true},
+				AllowListConfig: NewConfig(nil, []common.Address{allowlist.TestAdminAddr}, nil, nil).AllowListConfig,
+			},
+			ExpectedError: "cannot set roles when disabling the precompile",
+		},
+		"disable config": {
+			Config: NewDisableConfig(utils.NewUint64(3)),
+		},
+		"invalid cannot activated before Durango activation": {
+			Config: NewConfig(utils.NewUint64(3), nil, nil, nil),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDurango(gomock.Any()).Return(false)
+				return config
+			}(),
+			ExpectedError: errDeployerAllowListCannotBeActivated.Error(),
+		},
+	}
+	allowlist.VerifyPrecompileWithAllowListTests(t, newTestConfig, tests)
+}
+
+func TestEqual(t *testing.T) {
+	tests := map[string]testutils.ConfigEqualTest{
+		"enable and disable": {
+			Config:   NewConfig(utils.NewUint64(3), nil, nil, nil),
+			Other:    NewDisableConfig(utils.NewUint64(3)),
+			Expected: false,
+		},
+	}
+	allowlist.EqualPrecompileWithAllowListTests(t, newTestConfig, tests)
+}
@@ -0,0 +1,28 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package deployerallowlist
+
+import (
+	"github.com/Juneo-io/jeth/precompile/allowlist"
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Singleton StatefulPrecompiledContract for the contract deployer allow list.
+// The precompile only exposes the allow list functions: the EVM checks the
+// role of tx.origin in its CREATE and CREATE2 paths.
+var ContractDeployerAllowListPrecompile contract.StatefulPrecompiledContract = allowlist.CreateAllowListPrecompile(ContractAddress)
+
+// GetContractDeployerAllowListStatus returns the role of [address] for the contract deployer
+// allow list.
+func GetContractDeployerAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetContractDeployerAllowListStatus sets the permissions of [address] to [role] for the
+// contract deployer allow list.
+// Assumes [role] has already been verified as valid.
+func SetContractDeployerAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
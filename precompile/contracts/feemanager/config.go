@@ -0,0 +1,93 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Juneo-io/jeth/precompile/allowlist"
+	"github.com/Juneo-io/jeth/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+var (
+	errFeeManagerCannotBeActivated = errors.New("fee manager cannot be activated before Durango")
+	errMissingInitialFeeConfig     = errors.New("initial fee config must be set when enabling the fee manager")
+)
+
+// Config contains the configuration for the FeeManager precompile,
+// consisting of the initial allow list, the initial fee config and the
+// timestamp for the network upgrade.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+	InitialFeeConfig *FeeConfig `json:"initialFeeConfig,omitempty"` // fee config stored when the precompile is activated
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// FeeManager with the given [admins], [enableds] and [managers] as members of the
+// allowlist and [initialFeeConfig] as the fee config.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address, initialFeeConfig *FeeConfig) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade:          precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		InitialFeeConfig: initialFeeConfig,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables FeeManager.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the FeeManager precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	// The allow list functions do not support the strict ABI mode used before Durango
+	if c.Timestamp() != nil && !chainConfig.IsDurango(*c.Timestamp()) {
+		return errFeeManagerCannotBeActivated
+	}
+	if err := c.AllowListConfig.Verify(chainConfig, c.Upgrade); err != nil {
+		return err
+	}
+	if c.Disable {
+		if c.InitialFeeConfig != nil {
+			return errors.New("cannot set initial fee config when disabling the precompile")
+		}
+		return nil
+	}
+	if c.InitialFeeConfig == nil {
+		return errMissingInitialFeeConfig
+	}
+	if err := c.InitialFeeConfig.Verify(); err != nil {
+		return fmt.Errorf("invalid initial fee config: %w", err)
+	}
+	return nil
+}
+
+// Equal returns true if [cfg] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (cfg).(*Config)
+	if !ok {
+		return false
+	}
+	return c.Upgrade.Equal(&other.Upgrade) && c.AllowListConfig.Equal(&other.AllowListConfig) && c.InitialFeeConfig.Equal(other.InitialFeeConfig)
+}
@@ -0,0 +1,116 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Juneo-io/jeth/precompile/allowlist"
+	"github.com/Juneo-io/jeth/precompile/precompileconfig"
+	"github.com/Juneo-io/jeth/precompile/testutils"
+	"github.com/Juneo-io/jeth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+)
+
+var testFeeConfig = FeeConfig{
+	TargetGas:                big.NewInt(15_000_000),
+	MinBaseFee:               big.NewInt(25_000_000_000),
+	BaseFeeChangeDenominator: big.NewInt(36),
+	TargetBlockRate:          big.NewInt(2),
+	MinBlockGasCost:          big.NewInt(0),
+	MaxBlockGasCost:          big.NewInt(1_000_000),
+	BlockGasCostStep:         big.NewInt(200_000),
+}
+
+// newTestFeeConfig returns a copy of [testFeeConfig] modified by [modify].
+func newTestFeeConfig(modify func(feeConfig *FeeConfig)) *FeeConfig {
+	feeConfig := testFeeConfig
+	if modify != nil {
+		modify(&feeConfig)
+	}
+	return &feeConfig
+}
+
+func newTestConfig(blockTimestamp *uint64, admins, enableds, managers []common.Address) precompileconfig.Config {
+	return NewConfig(blockTimestamp, admins, enableds, managers, &testFeeConfig)
+}
+
+func TestVerify(t *testing.T) {
+	tests := map[string]testutils.ConfigVerifyTest{
+		"missing initial fee config": {
+			Config:        NewConfig(utils.NewUint64(3), []common.Address{allowlist.TestAdminAddr}, nil, nil, nil),
+			ExpectedError: errMissingInitialFeeConfig.Error(),
+		},
+		"invalid target gas": {
+			Config: NewConfig(utils.NewUint64(3), nil, nil, nil, newTestFeeConfig(func(feeConfig *FeeConfig) {
+				feeConfig.TargetGas = big.NewInt(0)
+			})),
+			ExpectedError: "targetGas = 0 must be a positive uint64",
+		},
+		"invalid base fee change denominator": {
+			Config: NewConfig(utils.NewUint64(3), nil, nil, nil, newTestFeeConfig(func(feeConfig *FeeConfig) {
+				feeConfig.BaseFeeChangeDenominator = nil
+			})),
+			ExpectedError: "baseFeeChangeDenominator = <nil> must be greater than 0",
+		},
+		"max block gas cost less than min": {
+			Config: NewConfig(utils.NewUint64(3), nil, nil, nil, newTestFeeConfig(func(feeConfig *FeeConfig) {
+				feeConfig.MinBlockGasCost = big.NewInt(2)
+				feeConfig.MaxBlockGasCost = big.NewInt(1)
+			})),
+			ExpectedError: "maxBlockGasCost = 1 cannot be less than minBlockGasCost = 2",
+		},
+		"field larger than a storage slot": {
+			Config: NewConfig(utils.NewUint64(3), nil, nil, nil, newTestFeeConfig(func(feeConfig *FeeConfig) {
+				feeConfig.MinBaseFee = new(big.Int).Lsh(common.Big1, 256)
+			})),
+			ExpectedError: "does not fit in 256 bits",
+		},
+		"fee config set when disabling": {
+			Config: &Config{
+				Upgrade:          precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3), Disable: true},
+				InitialFeeConfig: &testFeeConfig,
+			},
+			ExpectedError: "cannot set initial fee config when disabling the precompile",
+		},
+		"disable config": {
+			Config: NewDisableConfig(utils.NewUint64(3)),
+		},
+		"invalid cannot activated before Durango activation": {
+			Config: NewConfig(utils.NewUint64(3), nil, nil, nil, &testFeeConfig),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDurango(gomock.Any()).Return(false)
+				return config
+			}(),
+			ExpectedError: errFeeManagerCannotBeActivated.Error(),
+		},
+	}
+	allowlist.VerifyPrecompileWithAllowListTests(t, newTestConfig, tests)
+}
+
+func TestEqual(t *testing.T) {
+	tests := map[string]testutils.ConfigEqualTest{
+		"different initial fee config": {
+			Config: NewConfig(utils.NewUint64(3), []common.Address{allowlist.TestAdminAddr}, nil, nil, &testFeeConfig),
+			Other: NewConfig(utils.NewUint64(3), []common.Address{allowlist.TestAdminAddr}, nil, nil, newTestFeeConfig(func(feeConfig *FeeConfig) {
+				feeConfig.MinBaseFee = big.NewInt(1)
+			})),
+			Expected: false,
+		},
+		"nil and non-nil initial fee config": {
+			Config:   NewConfig(utils.NewUint64(3), []common.Address{allowlist.TestAdminAddr}, nil, nil, &testFeeConfig),
+			Other:    NewConfig(utils.NewUint64(3), []common.Address{allowlist.TestAdminAddr}, nil, nil, nil),
+			Expected: false,
+		},
+		"same fee config": {
+			Config:   NewConfig(utils.NewUint64(3), []common.Address{allowlist.TestAdminAddr}, nil, nil, &testFeeConfig),
+			Other:    NewConfig(utils.NewUint64(3), []common.Address{allowlist.TestAdminAddr}, nil, nil, newTestFeeConfig(nil)),
+			Expected: true,
+		},
+	}
+	allowlist.EqualPrecompileWithAllowListTests(t, newTestConfig, tests)
+}
@@ -0,0 +1,200 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Juneo-io/jeth/precompile/allowlist"
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/jeth/vmerrs"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// SetFeeConfigGasCost is the cost of writing the fee config fields and the
+	// number of the block they are changed in.
+	SetFeeConfigGasCost = (numFeeConfigFields + 1) * contract.WriteGasCostPerSlot
+	// FeeConfigChangedEventGasCost is the cost of emitting a FeeConfigChanged event:
+	// the base log cost, 2 topics and the 32 bytes of each fee config field.
+	FeeConfigChangedEventGasCost = contract.LogGas + 2*contract.LogTopicGas + numFeeConfigFields*common.HashLength*contract.LogDataGas
+	GetFeeConfigGasCost          = numFeeConfigFields * contract.ReadGasCostPerSlot
+	GetLastChangedAtGasCost      = contract.ReadGasCostPerSlot
+)
+
+var (
+	// FeeManagerRawABI contains the raw ABI of the fee manager functions.
+	//go:embed contract.abi
+	FeeManagerRawABI string
+
+	FeeManagerABI = contract.ParseABI(FeeManagerRawABI)
+
+	// Singleton StatefulPrecompiledContract for setting the fee config by permissioned callers.
+	FeeManagerPrecompile contract.StatefulPrecompiledContract = createFeeManagerPrecompile()
+
+	ErrCannotChangeFee = errors.New("non-enabled cannot change fee config")
+)
+
+// GetFeeManagerStatus returns the role of [address] for the fee manager allow list.
+func GetFeeManagerStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetFeeManagerStatus sets the permissions of [address] to [role] for the
+// fee manager allow list.
+// Assumes [role] has already been verified as valid.
+func SetFeeManagerStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// feeConfigABIArgs returns the fields of [feeConfig] in ABI order.
+func feeConfigABIArgs(feeConfig FeeConfig) []interface{} {
+	args := make([]interface{}, 0, numFeeConfigFields)
+	for _, field := range feeConfig.fields() {
+		args = append(args, *field)
+	}
+	return args
+}
+
+// PackSetFeeConfig packs the call setting the fee config to [feeConfig].
+func PackSetFeeConfig(feeConfig FeeConfig) ([]byte, error) {
+	return FeeManagerABI.Pack("setFeeConfig", feeConfigABIArgs(feeConfig)...)
+}
+
+// UnpackSetFeeConfigInput attempts to unpack [input] as the fee config argument of setFeeConfig.
+// Assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetFeeConfigInput(input []byte) (FeeConfig, error) {
+	var feeConfig FeeConfig
+	// The fee manager is deployed after Durango, so strict mode is not used.
+	if err := FeeManagerABI.UnpackInputIntoInterface(&feeConfig, "setFeeConfig", input, false); err != nil {
+		return FeeConfig{}, err
+	}
+	return feeConfig, nil
+}
+
+// PackGetFeeConfig packs the call reading the fee config.
+func PackGetFeeConfig() ([]byte, error) {
+	return FeeManagerABI.Pack("getFeeConfig")
+}
+
+// PackGetFeeConfigOutput packs [feeConfig] as the output of getFeeConfig.
+func PackGetFeeConfigOutput(feeConfig FeeConfig) ([]byte, error) {
+	return FeeManagerABI.PackOutput("getFeeConfig", feeConfigABIArgs(feeConfig)...)
+}
+
+// UnpackGetFeeConfigOutput attempts to unpack [output] as the fee config returned by getFeeConfig.
+func UnpackGetFeeConfigOutput(output []byte) (FeeConfig, error) {
+	var feeConfig FeeConfig
+	if err := FeeManagerABI.UnpackIntoInterface(&feeConfig, "getFeeConfig", output); err != nil {
+		return FeeConfig{}, err
+	}
+	return feeConfig, nil
+}
+
+// PackGetFeeConfigLastChangedAt packs the call reading the block number of the last fee config change.
+func PackGetFeeConfigLastChangedAt() ([]byte, error) {
+	return FeeManagerABI.Pack("getFeeConfigLastChangedAt")
+}
+
+// PackFeeConfigChangedEvent packs the FeeConfigChanged event emitted when [sender]
+// sets the fee config to [feeConfig].
+func PackFeeConfigChangedEvent(sender common.Address, feeConfig FeeConfig) ([]common.Hash, []byte, error) {
+	return FeeManagerABI.PackEvent("FeeConfigChanged", append([]interface{}{sender}, feeConfigABIArgs(feeConfig)...)...)
+}
+
+// setFeeConfig stores the fee config given as input if the caller has at least
+// the enabled role. The new fee config applies from the next block on.
+func setFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	feeConfig, err := UnpackSetFeeConfigInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := GetFeeManagerStatus(stateDB, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeFee, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, FeeConfigChangedEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackFeeConfigChangedEvent(caller, feeConfig)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	blockContext := accessibleState.GetBlockContext()
+	if err := StoreFeeConfig(stateDB, feeConfig, blockContext); err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(ContractAddress, topics, data, blockContext.Number().Uint64())
+
+	return []byte{}, remainingGas, nil
+}
+
+// getFeeConfig returns the stored fee config.
+func getFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	output, err := PackGetFeeConfigOutput(GetStoredFeeConfig(accessibleState.GetStateDB()))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// getFeeConfigLastChangedAt returns the number of the block where the fee config was last changed.
+func getFeeConfigLastChangedAt(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetLastChangedAtGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	lastChangedAt := GetFeeConfigLastChangedAt(accessibleState.GetStateDB())
+	output, err := FeeManagerABI.PackOutput("getFeeConfigLastChangedAt", lastChangedAt)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// createFeeManagerPrecompile returns a StatefulPrecompiledContract exposing the
+// allow list functions along with the fee config getters and setter.
+func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
+	functions := allowlist.CreateAllowListFunctions(ContractAddress)
+
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"setFeeConfig":              setFeeConfig,
+		"getFeeConfig":              getFeeConfig,
+		"getFeeConfigLastChangedAt": getFeeConfigLastChangedAt,
+	}
+	for name, function := range abiFunctionMap {
+		method, ok := FeeManagerABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
@@ -0,0 +1,148 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Juneo-io/jeth/core/state"
+	"github.com/Juneo-io/jeth/precompile/allowlist"
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/jeth/precompile/testutils"
+	"github.com/Juneo-io/jeth/utils"
+	"github.com/Juneo-io/jeth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeManager(t *testing.T) {
+	config := NewConfig(utils.NewUint64(0), []common.Address{allowlist.TestAdminAddr}, []common.Address{allowlist.TestEnabledAddr}, []common.Address{allowlist.TestManagerAddr}, &testFeeConfig)
+	setGas := SetFeeConfigGasCost + FeeConfigChangedEventGasCost
+	newFeeConfig := newTestFeeConfig(func(feeConfig *FeeConfig) {
+		feeConfig.TargetGas = big.NewInt(20_000_000)
+		feeConfig.MinBaseFee = big.NewInt(50_000_000_000)
+	})
+	packSet := func(feeConfig *FeeConfig) func(t testing.TB) []byte {
+		return func(t testing.TB) []byte {
+			input, err := PackSetFeeConfig(*feeConfig)
+			require.NoError(t, err)
+			return input
+		}
+	}
+	requireFeeConfig := func(feeConfig *FeeConfig) func(t testing.TB, state contract.StateDB) {
+		return func(t testing.TB, state contract.StateDB) {
+			storedFeeConfig := GetStoredFeeConfig(state)
+			require.True(t, feeConfig.Equal(&storedFeeConfig))
+		}
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"initial fee config": {
+			Config:    config,
+			AfterHook: requireFeeConfig(&testFeeConfig),
+		},
+		"enabled sets fee config": {
+			Caller:  allowlist.TestEnabledAddr,
+			Config:  config,
+			InputFn: packSet(newFeeConfig),
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Number().Return(big.NewInt(7)).AnyTimes()
+			},
+			SuppliedGas: setGas,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				requireFeeConfig(newFeeConfig)(t, state)
+				require.Equal(t, big.NewInt(7), GetFeeConfigLastChangedAt(state))
+
+				logsTopics, logsData := state.GetLogData()
+				require.Len(t, logsTopics, 1)
+				topics, data, err := PackFeeConfigChangedEvent(allowlist.TestEnabledAddr, *newFeeConfig)
+				require.NoError(t, err)
+				require.Equal(t, topics, logsTopics[0])
+				require.Equal(t, data, logsData[0])
+			},
+		},
+		"admin sets fee config": {
+			Caller:      allowlist.TestAdminAddr,
+			Config:      config,
+			InputFn:     packSet(newFeeConfig),
+			SuppliedGas: setGas,
+			ExpectedRes: []byte{},
+			AfterHook:   requireFeeConfig(newFeeConfig),
+		},
+		"no role cannot set fee config": {
+			Caller:      allowlist.TestNoRoleAddr,
+			Config:      config,
+			InputFn:     packSet(newFeeConfig),
+			SuppliedGas: SetFeeConfigGasCost,
+			ExpectedErr: ErrCannotChangeFee.Error(),
+			AfterHook:   requireFeeConfig(&testFeeConfig),
+		},
+		"invalid fee config": {
+			Caller: allowlist.TestEnabledAddr,
+			Config: config,
+			InputFn: packSet(newTestFeeConfig(func(feeConfig *FeeConfig) {
+				feeConfig.BaseFeeChangeDenominator = big.NewInt(0)
+			})),
+			SuppliedGas: setGas,
+			ExpectedErr: "baseFeeChangeDenominator = 0 must be greater than 0",
+			AfterHook:   requireFeeConfig(&testFeeConfig),
+		},
+		"set fee config readOnly": {
+			Caller:      allowlist.TestEnabledAddr,
+			Config:      config,
+			InputFn:     packSet(newFeeConfig),
+			SuppliedGas: SetFeeConfigGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"set fee config insufficient gas": {
+			Caller:      allowlist.TestEnabledAddr,
+			Config:      config,
+			InputFn:     packSet(newFeeConfig),
+			SuppliedGas: setGas - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"get fee config": {
+			Caller: allowlist.TestNoRoleAddr,
+			Config: config,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetFeeConfig()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: GetFeeConfigGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				output, err := PackGetFeeConfigOutput(testFeeConfig)
+				require.NoError(t, err)
+				return output
+			}(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				output, err := PackGetFeeConfigOutput(testFeeConfig)
+				require.NoError(t, err)
+				feeConfig, err := UnpackGetFeeConfigOutput(output)
+				require.NoError(t, err)
+				require.True(t, testFeeConfig.Equal(&feeConfig))
+			},
+		},
+		"get fee config last changed at": {
+			Caller: allowlist.TestNoRoleAddr,
+			Config: config,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetFeeConfigLastChangedAt()
+				require.NoError(t, err)
+				return input
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Number().Return(big.NewInt(3)).AnyTimes()
+			},
+			SuppliedGas: GetLastChangedAtGasCost,
+			ReadOnly:    true,
+			ExpectedRes: common.BigToHash(big.NewInt(3)).Bytes(),
+		},
+	}
+	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, config, tests)
+}
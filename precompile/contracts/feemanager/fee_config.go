@@ -0,0 +1,140 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// numFeeConfigFields is the number of fields of [FeeConfig]. Each field is
+// kept in its own storage slot of the fee manager.
+const numFeeConfigFields = 7
+
+var (
+	// feeConfigStoredKey is the prefix of the storage keys of the fee config fields.
+	// The last byte of the key is the index of the field.
+	// The keys do not collide with the allow list keys, which are addresses.
+	feeConfigStoredKey        = common.Hash{'f', 'c', 'k'}
+	feeConfigLastChangedAtKey = common.Hash{'l', 'c', 'a'}
+)
+
+// FeeConfig specifies the parameters of the dynamic fee algorithm.
+// It replaces the constants of the network upgrades once the fee manager is enabled.
+type FeeConfig struct {
+	// TargetGas is the amount of gas targeted over the rolling window of 10 seconds.
+	TargetGas *big.Int `json:"targetGas,omitempty"`
+	// MinBaseFee is the lower bound of the base fee.
+	MinBaseFee *big.Int `json:"minBaseFee,omitempty"`
+	// BaseFeeChangeDenominator bounds the base fee change between two blocks.
+	BaseFeeChangeDenominator *big.Int `json:"baseFeeChangeDenominator,omitempty"`
+
+	// TargetBlockRate is the targeted number of seconds between blocks.
+	TargetBlockRate *big.Int `json:"targetBlockRate,omitempty"`
+	// MinBlockGasCost and MaxBlockGasCost bound the block gas cost.
+	MinBlockGasCost *big.Int `json:"minBlockGasCost,omitempty"`
+	MaxBlockGasCost *big.Int `json:"maxBlockGasCost,omitempty"`
+	// BlockGasCostStep is the change of the block gas cost for each second
+	// the block is issued before or after the target block rate.
+	BlockGasCostStep *big.Int `json:"blockGasCostStep,omitempty"`
+}
+
+// fields returns pointers to the fields of [f] in storage and ABI order.
+func (f *FeeConfig) fields() [numFeeConfigFields]**big.Int {
+	return [numFeeConfigFields]**big.Int{
+		&f.TargetGas,
+		&f.MinBaseFee,
+		&f.BaseFeeChangeDenominator,
+		&f.TargetBlockRate,
+		&f.MinBlockGasCost,
+		&f.MaxBlockGasCost,
+		&f.BlockGasCostStep,
+	}
+}
+
+// Verify returns an error if [f] cannot be used by the dynamic fee algorithm.
+func (f *FeeConfig) Verify() error {
+	// Each field must fit in its storage slot
+	for i, field := range f.fields() {
+		if *field != nil && (*field).BitLen() > 256 {
+			return fmt.Errorf("fee config field %d does not fit in 256 bits", i)
+		}
+	}
+	switch {
+	case f.TargetGas == nil || f.TargetGas.Sign() <= 0 || !f.TargetGas.IsUint64():
+		return fmt.Errorf("targetGas = %v must be a positive uint64", f.TargetGas)
+	case f.MinBaseFee == nil || f.MinBaseFee.Sign() < 0:
+		return fmt.Errorf("minBaseFee = %v cannot be less than 0", f.MinBaseFee)
+	case f.BaseFeeChangeDenominator == nil || f.BaseFeeChangeDenominator.Sign() <= 0:
+		return fmt.Errorf("baseFeeChangeDenominator = %v must be greater than 0", f.BaseFeeChangeDenominator)
+	case f.TargetBlockRate == nil || f.TargetBlockRate.Sign() <= 0 || !f.TargetBlockRate.IsUint64():
+		return fmt.Errorf("targetBlockRate = %v must be a positive uint64", f.TargetBlockRate)
+	case f.MinBlockGasCost == nil || f.MinBlockGasCost.Sign() < 0:
+		return fmt.Errorf("minBlockGasCost = %v cannot be less than 0", f.MinBlockGasCost)
+	case f.MaxBlockGasCost == nil || f.MaxBlockGasCost.Cmp(f.MinBlockGasCost) < 0:
+		return fmt.Errorf("maxBlockGasCost = %v cannot be less than minBlockGasCost = %v", f.MaxBlockGasCost, f.MinBlockGasCost)
+	case f.BlockGasCostStep == nil || f.BlockGasCostStep.Sign() < 0:
+		return fmt.Errorf("blockGasCostStep = %v cannot be less than 0", f.BlockGasCostStep)
+	}
+	return nil
+}
+
+// Equal returns true iff [other] has the same fields as [f].
+func (f *FeeConfig) Equal(other *FeeConfig) bool {
+	if f == nil || other == nil {
+		return f == other
+	}
+	otherFields := other.fields()
+	for i, field := range f.fields() {
+		if !bigEqual(*field, *otherFields[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func bigEqual(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+// feeConfigKey returns the storage key of the field of [FeeConfig] at [index].
+func feeConfigKey(index int) common.Hash {
+	key := feeConfigStoredKey
+	key[common.HashLength-1] = byte(index)
+	return key
+}
+
+// GetStoredFeeConfig returns the fee config stored in the fee manager.
+func GetStoredFeeConfig(stateDB contract.StateDB) FeeConfig {
+	var feeConfig FeeConfig
+	for i, field := range feeConfig.fields() {
+		*field = stateDB.GetState(ContractAddress, feeConfigKey(i)).Big()
+	}
+	return feeConfig
+}
+
+// GetFeeConfigLastChangedAt returns the number of the block where the stored
+// fee config was last changed.
+func GetFeeConfigLastChangedAt(stateDB contract.StateDB) *big.Int {
+	return stateDB.GetState(ContractAddress, feeConfigLastChangedAtKey).Big()
+}
+
+// StoreFeeConfig verifies [feeConfig] and stores it in the fee manager along with
+// the number of the block it is changed in.
+func StoreFeeConfig(stateDB contract.StateDB, feeConfig FeeConfig, blockContext contract.ConfigurationBlockContext) error {
+	if err := feeConfig.Verify(); err != nil {
+		return fmt.Errorf("cannot verify fee config: %w", err)
+	}
+	for i, field := range feeConfig.fields() {
+		stateDB.SetState(ContractAddress, feeConfigKey(i), common.BigToHash(*field))
+	}
+	stateDB.SetState(ContractAddress, feeConfigLastChangedAtKey, common.BigToHash(blockContext.Number()))
+	return nil
+}
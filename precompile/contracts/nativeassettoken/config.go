@@ -0,0 +1,122 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeassettoken
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/jeth/precompile/precompileconfig"
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+var (
+	errNativeAssetTokenCannotBeActivated = errors.New("native asset token cannot be activated before Durango")
+	errMissingTokens                     = errors.New("at least one token must be set when enabling the native asset token precompile")
+)
+
+// TokenConfig specifies a native asset exposed as an ERC-20 token along with
+// the metadata reported by the token.
+type TokenConfig struct {
+	AssetID  ids.ID `json:"assetID"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// Config contains the configuration for the NativeAssetToken precompile,
+// consisting of the native assets exposed as tokens and the timestamp for the
+// network upgrade.
+type Config struct {
+	precompileconfig.Upgrade
+	Tokens []TokenConfig `json:"tokens,omitempty"`
+
+	// contracts maps the address of each token to its contract. It is built on
+	// first use so that looking up a token does not hash every asset ID.
+	contractsOnce sync.Once
+	contracts     map[common.Address]contract.StatefulPrecompiledContract
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// NativeAssetToken and exposes [tokens].
+func NewConfig(blockTimestamp *uint64, tokens []TokenConfig) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		Tokens:  tokens,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables NativeAssetToken.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the NativeAssetToken precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	if c.Timestamp() != nil && !chainConfig.IsDurango(*c.Timestamp()) {
+		return errNativeAssetTokenCannotBeActivated
+	}
+	if c.Disable {
+		if len(c.Tokens) != 0 {
+			return errors.New("cannot set tokens when disabling the precompile")
+		}
+		return nil
+	}
+	if len(c.Tokens) == 0 {
+		return errMissingTokens
+	}
+	assetIDs := make(map[ids.ID]struct{}, len(c.Tokens))
+	for i, token := range c.Tokens {
+		if token.AssetID == ids.Empty {
+			return fmt.Errorf("token %d has an empty asset ID", i)
+		}
+		if _, ok := assetIDs[token.AssetID]; ok {
+			return fmt.Errorf("duplicate asset ID %s in tokens", token.AssetID)
+		}
+		assetIDs[token.AssetID] = struct{}{}
+		if len(token.Symbol) == 0 {
+			return fmt.Errorf("token of asset %s has an empty symbol", token.AssetID)
+		}
+	}
+	return nil
+}
+
+// Equal returns true if [cfg] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (cfg).(*Config)
+	if !ok {
+		return false
+	}
+	return c.Upgrade.Equal(&other.Upgrade) && slices.Equal(c.Tokens, other.Tokens)
+}
+
+// TokenContract returns the contract of the token exposed by [c] at [address],
+// or false if [c] does not expose any token at [address].
+func (c *Config) TokenContract(address common.Address) (contract.StatefulPrecompiledContract, bool) {
+	c.contractsOnce.Do(func() {
+		c.contracts = make(map[common.Address]contract.StatefulPrecompiledContract, len(c.Tokens))
+		for _, token := range c.Tokens {
+			c.contracts[TokenAddress(token.AssetID)] = newTokenContract(token)
+		}
+	})
+	tokenContract, ok := c.contracts[address]
+	return tokenContract, ok
+}
@@ -0,0 +1,111 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeassettoken
+
+import (
+	"testing"
+
+	"github.com/Juneo-io/jeth/precompile/precompileconfig"
+	"github.com/Juneo-io/jeth/precompile/testutils"
+	"github.com/Juneo-io/jeth/utils"
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	testAssetID      = ids.ID{'a', 's', 's', 'e', 't'}
+	testOtherAssetID = ids.ID{'o', 't', 'h', 'e', 'r'}
+	testToken        = TokenConfig{AssetID: testAssetID, Name: "Test Asset", Symbol: "TST", Decimals: 9}
+	testOtherToken   = TokenConfig{AssetID: testOtherAssetID, Name: "Other Asset", Symbol: "OTH", Decimals: 6}
+)
+
+func TestVerify(t *testing.T) {
+	tests := map[string]testutils.ConfigVerifyTest{
+		"valid config": {
+			Config: NewConfig(utils.NewUint64(3), []TokenConfig{testToken, testOtherToken}),
+		},
+		"no tokens": {
+			Config:        NewConfig(utils.NewUint64(3), nil),
+			ExpectedError: errMissingTokens.Error(),
+		},
+		"empty asset ID": {
+			Config:        NewConfig(utils.NewUint64(3), []TokenConfig{{Symbol: "TST"}}),
+			ExpectedError: "empty asset ID",
+		},
+		"duplicate asset ID": {
+			Config:        NewConfig(utils.NewUint64(3), []TokenConfig{testToken, testToken}),
+			ExpectedError: "duplicate asset ID",
+		},
+		"empty symbol": {
+			Config:        NewConfig(utils.NewUint64(3), []TokenConfig{{AssetID: testAssetID}}),
+			ExpectedError: "empty symbol",
+		},
+		"valid disable config": {
+			Config: NewDisableConfig(utils.NewUint64(3)),
+		},
+		"tokens set when disabling": {
+			Config: &Config{
+				Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3), Disable: true},
+				Tokens:  []TokenConfig{testToken},
+			},
+			ExpectedError: "cannot set tokens when disabling the precompile",
+		},
+		"invalid cannot activated before Durango activation": {
+			Config: NewConfig(utils.NewUint64(3), []TokenConfig{testToken}),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDurango(gomock.Any()).Return(false)
+				return config
+			}(),
+			ExpectedError: errNativeAssetTokenCannotBeActivated.Error(),
+		},
+	}
+	testutils.RunVerifyTests(t, tests)
+}
+
+func TestEqual(t *testing.T) {
+	tests := map[string]testutils.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   NewConfig(utils.NewUint64(3), []TokenConfig{testToken}),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   NewConfig(utils.NewUint64(3), []TokenConfig{testToken}),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   NewConfig(utils.NewUint64(3), []TokenConfig{testToken}),
+			Other:    NewConfig(utils.NewUint64(4), []TokenConfig{testToken}),
+			Expected: false,
+		},
+		"different tokens": {
+			Config:   NewConfig(utils.NewUint64(3), []TokenConfig{testToken}),
+			Other:    NewConfig(utils.NewUint64(3), []TokenConfig{testOtherToken}),
+			Expected: false,
+		},
+		"same config": {
+			Config:   NewConfig(utils.NewUint64(3), []TokenConfig{testToken, testOtherToken}),
+			Other:    NewConfig(utils.NewUint64(3), []TokenConfig{testToken, testOtherToken}),
+			Expected: true,
+		},
+	}
+	testutils.RunEqualTests(t, tests)
+}
+
+func TestTokenContract(t *testing.T) {
+	require := require.New(t)
+
+	config := NewConfig(utils.NewUint64(3), []TokenConfig{testToken, testOtherToken})
+	_, ok := config.TokenContract(TokenAddress(testAssetID))
+	require.True(ok)
+	_, ok = config.TokenContract(TokenAddress(testOtherAssetID))
+	require.True(ok)
+	_, ok = config.TokenContract(TokenAddress(ids.GenerateTestID()))
+	require.False(ok)
+	_, ok = config.TokenContract(ContractAddress)
+	require.False(ok)
+}
@@ -0,0 +1,101 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeassettoken
+
+import (
+	"fmt"
+
+	"github.com/Juneo-io/jeth/accounts/abi"
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/juneogo/ids"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TokenAddressGasCost is the cost of hashing the asset ID into the token address.
+const TokenAddressGasCost uint64 = 100
+
+var (
+	// NativeAssetTokenRawABI contains the raw ABI of the native asset token precompile.
+	//go:embed contract.abi
+	NativeAssetTokenRawABI string
+
+	NativeAssetTokenABI = contract.ParseABI(NativeAssetTokenRawABI)
+
+	// Singleton StatefulPrecompiledContract for looking up the address of the token of a native asset.
+	NativeAssetTokenPrecompile contract.StatefulPrecompiledContract = createNativeAssetTokenPrecompile()
+)
+
+// TokenAddress returns the deterministic address of the token of [assetID]:
+// the last 20 bytes of keccak256(ContractAddress ++ assetID).
+// The token is only accessible if [assetID] is exposed by the active config.
+func TokenAddress(assetID ids.ID) common.Address {
+	return common.BytesToAddress(crypto.Keccak256(ContractAddress.Bytes(), assetID[:]))
+}
+
+// PackTokenAddress packs [assetID] into the input data of the tokenAddress function.
+func PackTokenAddress(assetID ids.ID) ([]byte, error) {
+	return NativeAssetTokenABI.Pack("tokenAddress", [32]byte(assetID))
+}
+
+// UnpackTokenAddressInput attempts to unpack [input] as the asset ID argument of tokenAddress.
+// Assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackTokenAddressInput(input []byte) (ids.ID, error) {
+	// The native asset token precompile is deployed after Durango, so strict mode is not used.
+	res, err := NativeAssetTokenABI.UnpackInput("tokenAddress", input, false)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return ids.ID(*abi.ConvertType(res[0], new([32]byte)).(*[32]byte)), nil
+}
+
+// PackTokenAddressOutput packs [token] as the output of tokenAddress.
+func PackTokenAddressOutput(token common.Address) ([]byte, error) {
+	return NativeAssetTokenABI.PackOutput("tokenAddress", token)
+}
+
+// tokenAddress returns the address of the token of the given asset ID.
+func tokenAddress(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, TokenAddressGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	assetID, err := UnpackTokenAddressInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	output, err := PackTokenAddressOutput(TokenAddress(assetID))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// createNativeAssetTokenPrecompile returns a StatefulPrecompiledContract exposing
+// the lookup of the token addresses.
+func createNativeAssetTokenPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"tokenAddress": tokenAddress,
+	}
+	for name, function := range abiFunctionMap {
+		method, ok := NativeAssetTokenABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
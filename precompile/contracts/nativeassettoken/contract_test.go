@@ -0,0 +1,250 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeassettoken
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Juneo-io/jeth/core/state"
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/jeth/precompile/modules"
+	"github.com/Juneo-io/jeth/precompile/testutils"
+	"github.com/Juneo-io/jeth/utils"
+	"github.com/Juneo-io/jeth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testOwnerAddr     = common.HexToAddress("0x0000000000000000000000000000000000000011")
+	testSpenderAddr   = common.HexToAddress("0x0000000000000000000000000000000000000022")
+	testRecipientAddr = common.HexToAddress("0x0000000000000000000000000000000000000033")
+)
+
+func TestNativeAssetToken(t *testing.T) {
+	tests := map[string]testutils.PrecompileTest{
+		"token address": {
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackTokenAddress(testAssetID)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: TokenAddressGasCost,
+			ExpectedRes: func() []byte {
+				output, err := PackTokenAddressOutput(TokenAddress(testAssetID))
+				require.NoError(t, err)
+				return output
+			}(),
+		},
+		"token address out of gas": {
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackTokenAddress(testAssetID)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: TokenAddressGasCost - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"configure marks tokens as contracts": {
+			Config: NewConfig(utils.NewUint64(0), []TokenConfig{testToken, testOtherToken}),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, uint64(1), state.GetNonce(TokenAddress(testAssetID)))
+				require.Equal(t, uint64(1), state.GetNonce(TokenAddress(testOtherAssetID)))
+			},
+		},
+	}
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestToken(t *testing.T) {
+	tokenModule := modules.Module{
+		ConfigKey:    ConfigKey,
+		Address:      TokenAddress(testAssetID),
+		Contract:     newTokenContract(testToken),
+		Configurator: Module.Configurator,
+	}
+	config := NewConfig(utils.NewUint64(0), []TokenConfig{testToken})
+	fund := func(t testing.TB, state contract.StateDB) {
+		state.AddBalanceMultiCoin(testOwnerAddr, common.Hash(testAssetID), big.NewInt(1000))
+		// Balances of other assets are not exposed by the token
+		state.AddBalanceMultiCoin(testOwnerAddr, common.Hash(testOtherAssetID), big.NewInt(5))
+	}
+	pack := func(f func() ([]byte, error)) func(t testing.TB) []byte {
+		return func(t testing.TB) []byte {
+			input, err := f()
+			require.NoError(t, err)
+			return input
+		}
+	}
+	packOutput := func(name string, args ...interface{}) []byte {
+		output, err := TokenABI.PackOutput(name, args...)
+		require.NoError(t, err)
+		return output
+	}
+	requireBalances := func(owner, recipient int64) func(t testing.TB, state contract.StateDB) {
+		return func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, big.NewInt(owner), state.GetBalanceMultiCoin(testOwnerAddr, common.Hash(testAssetID)))
+			require.Equal(t, big.NewInt(recipient), state.GetBalanceMultiCoin(testRecipientAddr, common.Hash(testAssetID)))
+			require.Equal(t, big.NewInt(5), state.GetBalanceMultiCoin(testOwnerAddr, common.Hash(testOtherAssetID)))
+		}
+	}
+	requireTransferEvent := func(t testing.TB, state contract.StateDB, from, to common.Address, amount int64) {
+		logsTopics, logsData := state.GetLogData()
+		require.Len(t, logsTopics, 1)
+		topics, data, err := PackTransferEvent(from, to, big.NewInt(amount))
+		require.NoError(t, err)
+		require.Equal(t, topics, logsTopics[0])
+		require.Equal(t, data, logsData[0])
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"metadata": {
+			Config:      config,
+			Input:       TokenABI.Methods["symbol"].ID,
+			SuppliedGas: TokenMetadataGasCost,
+			ExpectedRes: packOutput("symbol", "TST"),
+		},
+		"asset ID": {
+			Config:      config,
+			Input:       TokenABI.Methods["assetID"].ID,
+			SuppliedGas: TokenMetadataGasCost,
+			ExpectedRes: packOutput("assetID", [32]byte(testAssetID)),
+		},
+		"balance of": {
+			Config:      config,
+			BeforeHook:  fund,
+			InputFn:     pack(func() ([]byte, error) { return PackBalanceOf(testOwnerAddr) }),
+			SuppliedGas: BalanceOfGasCost,
+			ExpectedRes: packOutput("balanceOf", big.NewInt(1000)),
+		},
+		"transfer": {
+			Caller:      testOwnerAddr,
+			Config:      config,
+			BeforeHook:  fund,
+			InputFn:     pack(func() ([]byte, error) { return PackTransfer(testRecipientAddr, big.NewInt(400)) }),
+			SuppliedGas: TransferGasCost + TokenEventGasCost,
+			ExpectedRes: packOutput("transfer", true),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				requireBalances(600, 400)(t, state)
+				requireTransferEvent(t, state, testOwnerAddr, testRecipientAddr, 400)
+			},
+		},
+		"transfer insufficient balance": {
+			Caller:      testOwnerAddr,
+			Config:      config,
+			BeforeHook:  fund,
+			InputFn:     pack(func() ([]byte, error) { return PackTransfer(testRecipientAddr, big.NewInt(1001)) }),
+			SuppliedGas: TransferGasCost + TokenEventGasCost,
+			ExpectedErr: vmerrs.ErrInsufficientBalance.Error(),
+			AfterHook:   requireBalances(1000, 0),
+		},
+		"transfer readOnly": {
+			Caller:      testOwnerAddr,
+			Config:      config,
+			BeforeHook:  fund,
+			InputFn:     pack(func() ([]byte, error) { return PackTransfer(testRecipientAddr, big.NewInt(400)) }),
+			SuppliedGas: TransferGasCost + TokenEventGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+			AfterHook:   requireBalances(1000, 0),
+		},
+		"transfer out of gas": {
+			Caller:      testOwnerAddr,
+			Config:      config,
+			BeforeHook:  fund,
+			InputFn:     pack(func() ([]byte, error) { return PackTransfer(testRecipientAddr, big.NewInt(400)) }),
+			SuppliedGas: TransferGasCost + TokenEventGasCost - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+			AfterHook:   requireBalances(1000, 0),
+		},
+		"approve": {
+			Caller:      testOwnerAddr,
+			Config:      config,
+			InputFn:     pack(func() ([]byte, error) { return PackApprove(testSpenderAddr, big.NewInt(300)) }),
+			SuppliedGas: ApproveGasCost + TokenEventGasCost,
+			ExpectedRes: packOutput("approve", true),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, big.NewInt(300), GetAllowance(state, testAssetID, testOwnerAddr, testSpenderAddr))
+				require.Zero(t, GetAllowance(state, testOtherAssetID, testOwnerAddr, testSpenderAddr).Sign())
+
+				logsTopics, logsData := state.GetLogData()
+				require.Len(t, logsTopics, 1)
+				topics, data, err := PackApprovalEvent(testOwnerAddr, testSpenderAddr, big.NewInt(300))
+				require.NoError(t, err)
+				require.Equal(t, topics, logsTopics[0])
+				require.Equal(t, data, logsData[0])
+			},
+		},
+		"allowance": {
+			Config: config,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetAllowance(state, testAssetID, testOwnerAddr, testSpenderAddr, big.NewInt(300))
+			},
+			InputFn:     pack(func() ([]byte, error) { return PackAllowance(testOwnerAddr, testSpenderAddr) }),
+			SuppliedGas: AllowanceGasCost,
+			ExpectedRes: packOutput("allowance", big.NewInt(300)),
+		},
+		"transfer from spends allowance": {
+			Caller: testSpenderAddr,
+			Config: config,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				fund(t, state)
+				SetAllowance(state, testAssetID, testOwnerAddr, testSpenderAddr, big.NewInt(300))
+			},
+			InputFn: pack(func() ([]byte, error) {
+				return PackTransferFrom(testOwnerAddr, testRecipientAddr, big.NewInt(200))
+			}),
+			SuppliedGas: TransferFromGasCost + TokenEventGasCost,
+			ExpectedRes: packOutput("transferFrom", true),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				requireBalances(800, 200)(t, state)
+				require.Equal(t, big.NewInt(100), GetAllowance(state, testAssetID, testOwnerAddr, testSpenderAddr))
+				requireTransferEvent(t, state, testOwnerAddr, testRecipientAddr, 200)
+			},
+		},
+		"transfer from does not spend max allowance": {
+			Caller: testSpenderAddr,
+			Config: config,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				fund(t, state)
+				SetAllowance(state, testAssetID, testOwnerAddr, testSpenderAddr, math.MaxBig256)
+			},
+			InputFn: pack(func() ([]byte, error) {
+				return PackTransferFrom(testOwnerAddr, testRecipientAddr, big.NewInt(200))
+			}),
+			SuppliedGas: TransferFromGasCost + TokenEventGasCost,
+			ExpectedRes: packOutput("transferFrom", true),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				requireBalances(800, 200)(t, state)
+				require.Equal(t, math.MaxBig256, GetAllowance(state, testAssetID, testOwnerAddr, testSpenderAddr))
+			},
+		},
+		"transfer from insufficient allowance": {
+			Caller: testSpenderAddr,
+			Config: config,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				fund(t, state)
+				SetAllowance(state, testAssetID, testOwnerAddr, testSpenderAddr, big.NewInt(100))
+			},
+			InputFn: pack(func() ([]byte, error) {
+				return PackTransferFrom(testOwnerAddr, testRecipientAddr, big.NewInt(200))
+			}),
+			SuppliedGas: TransferFromGasCost + TokenEventGasCost,
+			ExpectedErr: ErrInsufficientAllowance.Error(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				requireBalances(1000, 0)(t, state)
+				require.Equal(t, big.NewInt(100), GetAllowance(state, testAssetID, testOwnerAddr, testSpenderAddr))
+			},
+		},
+		"invalid selector": {
+			Config:      config,
+			Input:       []byte{1, 2, 3, 4},
+			SuppliedGas: 0,
+			ExpectedErr: "invalid function selector",
+		},
+	}
+	testutils.RunPrecompileTests(t, tokenModule, state.NewTestStateDB, tests)
+}
@@ -0,0 +1,339 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeassettoken
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Juneo-io/jeth/accounts/abi"
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/jeth/vmerrs"
+	"github.com/Juneo-io/juneogo/ids"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// TokenMetadataGasCost is the cost of reading the metadata of a token.
+	TokenMetadataGasCost = contract.ReadGasCostPerSlot
+	BalanceOfGasCost     = contract.ReadGasCostPerSlot
+	AllowanceGasCost     = contract.ReadGasCostPerSlot
+	ApproveGasCost       = contract.WriteGasCostPerSlot
+	// TransferGasCost is the cost of checking the balance of the sender and
+	// writing the balances of the sender and the recipient.
+	TransferGasCost = contract.ReadGasCostPerSlot + 2*contract.WriteGasCostPerSlot
+	// TransferFromGasCost additionally covers reading and updating the allowance.
+	TransferFromGasCost = TransferGasCost + contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot
+	// TokenEventGasCost is the cost of emitting a Transfer or Approval event:
+	// the base log cost, 3 topics and the 32 bytes of the value.
+	TokenEventGasCost = contract.LogGas + 3*contract.LogTopicGas + common.HashLength*contract.LogDataGas
+)
+
+var (
+	// TokenRawABI contains the raw ERC-20 ABI implemented by the tokens.
+	//go:embed token.abi
+	TokenRawABI string
+
+	TokenABI = contract.ParseABI(TokenRawABI)
+
+	// tokenFunctions maps the function selectors of [TokenABI] to their implementation.
+	tokenFunctions = createTokenFunctions()
+
+	ErrInsufficientAllowance = errors.New("insufficient allowance for transfer")
+)
+
+// tokenFunction is a function of the token contract bound to the token it is called on.
+type tokenFunction func(t *tokenContract, accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error)
+
+// tokenContract implements the ERC-20 interface over the multicoin balances of a native asset.
+type tokenContract struct {
+	config  TokenConfig
+	address common.Address
+	coinID  common.Hash
+}
+
+func newTokenContract(config TokenConfig) *tokenContract {
+	return &tokenContract{
+		config:  config,
+		address: TokenAddress(config.AssetID),
+		coinID:  common.Hash(config.AssetID),
+	}
+}
+
+// Run selects the token function using the 4 byte function selector at the start of the input.
+func (t *tokenContract) Run(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if len(input) < contract.SelectorLen {
+		return nil, suppliedGas, fmt.Errorf("missing function selector to native asset token - input length (%d)", len(input))
+	}
+	selector := input[:contract.SelectorLen]
+	function, ok := tokenFunctions[string(selector)]
+	if !ok {
+		return nil, suppliedGas, fmt.Errorf("invalid function selector %#x", selector)
+	}
+	return function(t, accessibleState, caller, input[contract.SelectorLen:], suppliedGas, readOnly)
+}
+
+// allowanceKey returns the storage key of the amount [spender] may transfer from [owner].
+func allowanceKey(owner, spender common.Address) common.Hash {
+	return crypto.Keccak256Hash(owner.Bytes(), spender.Bytes())
+}
+
+// GetAllowance returns the amount of [assetID] that [spender] may transfer from [owner]
+// through the token of [assetID].
+func GetAllowance(stateDB contract.StateDB, assetID ids.ID, owner, spender common.Address) *big.Int {
+	return stateDB.GetState(TokenAddress(assetID), allowanceKey(owner, spender)).Big()
+}
+
+// SetAllowance sets the amount of [assetID] that [spender] may transfer from [owner]
+// through the token of [assetID] to [amount].
+func SetAllowance(stateDB contract.StateDB, assetID ids.ID, owner, spender common.Address, amount *big.Int) {
+	stateDB.SetState(TokenAddress(assetID), allowanceKey(owner, spender), common.BigToHash(amount))
+}
+
+// PackBalanceOf packs the call reading the balance of [account].
+func PackBalanceOf(account common.Address) ([]byte, error) {
+	return TokenABI.Pack("balanceOf", account)
+}
+
+// PackAllowance packs the call reading the amount [spender] may transfer from [owner].
+func PackAllowance(owner, spender common.Address) ([]byte, error) {
+	return TokenABI.Pack("allowance", owner, spender)
+}
+
+// PackTransfer packs the call transferring [amount] from the caller to [to].
+func PackTransfer(to common.Address, amount *big.Int) ([]byte, error) {
+	return TokenABI.Pack("transfer", to, amount)
+}
+
+// PackApprove packs the call allowing [spender] to transfer [amount] from the caller.
+func PackApprove(spender common.Address, amount *big.Int) ([]byte, error) {
+	return TokenABI.Pack("approve", spender, amount)
+}
+
+// PackTransferFrom packs the call transferring [amount] from [from] to [to] on behalf of [from].
+func PackTransferFrom(from, to common.Address, amount *big.Int) ([]byte, error) {
+	return TokenABI.Pack("transferFrom", from, to, amount)
+}
+
+// PackTransferEvent packs the Transfer event emitted when [amount] is transferred from [from] to [to].
+func PackTransferEvent(from, to common.Address, amount *big.Int) ([]common.Hash, []byte, error) {
+	return TokenABI.PackEvent("Transfer", from, to, amount)
+}
+
+// PackApprovalEvent packs the Approval event emitted when [owner] allows [spender] to transfer [amount].
+func PackApprovalEvent(owner, spender common.Address, amount *big.Int) ([]common.Hash, []byte, error) {
+	return TokenABI.PackEvent("Approval", owner, spender, amount)
+}
+
+// unpackInput unpacks [input] as the arguments of the token function [name].
+// The native asset token precompile is deployed after Durango, so strict mode is not used.
+func unpackInput(name string, input []byte) ([]interface{}, error) {
+	return TokenABI.UnpackInput(name, input, false)
+}
+
+func unpackAddress(arg interface{}) common.Address {
+	return *abi.ConvertType(arg, new(common.Address)).(*common.Address)
+}
+
+func unpackAmount(arg interface{}) *big.Int {
+	return *abi.ConvertType(arg, new(*big.Int)).(**big.Int)
+}
+
+// transfer moves [amount] of the asset from [from] to [to] and emits a Transfer event.
+func (t *tokenContract) transfer(accessibleState contract.AccessibleState, from, to common.Address, amount *big.Int) error {
+	stateDB := accessibleState.GetStateDB()
+	if stateDB.GetBalanceMultiCoin(from, t.coinID).Cmp(amount) < 0 {
+		return fmt.Errorf("%w: %s", vmerrs.ErrInsufficientBalance, from)
+	}
+	topics, data, err := PackTransferEvent(from, to, amount)
+	if err != nil {
+		return err
+	}
+	stateDB.SubBalanceMultiCoin(from, t.coinID, amount)
+	stateDB.AddBalanceMultiCoin(to, t.coinID, amount)
+	stateDB.AddLog(t.address, topics, data, accessibleState.GetBlockContext().Number().Uint64())
+	return nil
+}
+
+// readMetadata returns [value] packed as the output of the metadata function [name].
+func readMetadata(name string, value interface{}, suppliedGas uint64) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, TokenMetadataGasCost); err != nil {
+		return nil, 0, err
+	}
+	output, err := TokenABI.PackOutput(name, value)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+func (t *tokenContract) name(accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	return readMetadata("name", t.config.Name, suppliedGas)
+}
+
+func (t *tokenContract) symbol(accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	return readMetadata("symbol", t.config.Symbol, suppliedGas)
+}
+
+func (t *tokenContract) decimals(accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	return readMetadata("decimals", t.config.Decimals, suppliedGas)
+}
+
+func (t *tokenContract) assetID(accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	return readMetadata("assetID", [32]byte(t.config.AssetID), suppliedGas)
+}
+
+// balanceOf returns the multicoin balance of the given account.
+func (t *tokenContract) balanceOf(accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, BalanceOfGasCost); err != nil {
+		return nil, 0, err
+	}
+	args, err := unpackInput("balanceOf", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	balance := accessibleState.GetStateDB().GetBalanceMultiCoin(unpackAddress(args[0]), t.coinID)
+	output, err := TokenABI.PackOutput("balanceOf", balance)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// allowance returns the amount the given spender may transfer from the given owner.
+func (t *tokenContract) allowance(accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, AllowanceGasCost); err != nil {
+		return nil, 0, err
+	}
+	args, err := unpackInput("allowance", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	allowance := GetAllowance(accessibleState.GetStateDB(), t.config.AssetID, unpackAddress(args[0]), unpackAddress(args[1]))
+	output, err := TokenABI.PackOutput("allowance", allowance)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// approveToken sets the amount the given spender may transfer from the caller.
+func (t *tokenContract) approveToken(accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ApproveGasCost+TokenEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	args, err := unpackInput("approve", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	spender, amount := unpackAddress(args[0]), unpackAmount(args[1])
+	topics, data, err := PackApprovalEvent(caller, spender, amount)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB := accessibleState.GetStateDB()
+	SetAllowance(stateDB, t.config.AssetID, caller, spender, amount)
+	stateDB.AddLog(t.address, topics, data, accessibleState.GetBlockContext().Number().Uint64())
+
+	output, err := TokenABI.PackOutput("approve", true)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// transferToken transfers the given amount from the caller to the given recipient.
+func (t *tokenContract) transferToken(accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, TransferGasCost+TokenEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	args, err := unpackInput("transfer", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	if err := t.transfer(accessibleState, caller, unpackAddress(args[0]), unpackAmount(args[1])); err != nil {
+		return nil, remainingGas, err
+	}
+
+	output, err := TokenABI.PackOutput("transfer", true)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// transferFrom transfers the given amount between the given accounts on behalf of
+// the sender, spending the allowance of the caller. An allowance of the maximum
+// uint256 value is never spent.
+func (t *tokenContract) transferFrom(accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, TransferFromGasCost+TokenEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	args, err := unpackInput("transferFrom", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	from, to, amount := unpackAddress(args[0]), unpackAddress(args[1]), unpackAmount(args[2])
+	stateDB := accessibleState.GetStateDB()
+	allowance := GetAllowance(stateDB, t.config.AssetID, from, caller)
+	if allowance.Cmp(amount) < 0 {
+		return nil, remainingGas, fmt.Errorf("%w: %s cannot spend %s from %s", ErrInsufficientAllowance, caller, amount, from)
+	}
+	if err := t.transfer(accessibleState, from, to, amount); err != nil {
+		return nil, remainingGas, err
+	}
+	if allowance.Cmp(math.MaxBig256) != 0 {
+		SetAllowance(stateDB, t.config.AssetID, from, caller, new(big.Int).Sub(allowance, amount))
+	}
+
+	output, err := TokenABI.PackOutput("transferFrom", true)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// createTokenFunctions returns the token functions keyed by their selector.
+func createTokenFunctions() map[string]tokenFunction {
+	abiFunctionMap := map[string]tokenFunction{
+		"name":         (*tokenContract).name,
+		"symbol":       (*tokenContract).symbol,
+		"decimals":     (*tokenContract).decimals,
+		"assetID":      (*tokenContract).assetID,
+		"balanceOf":    (*tokenContract).balanceOf,
+		"allowance":    (*tokenContract).allowance,
+		"approve":      (*tokenContract).approveToken,
+		"transfer":     (*tokenContract).transferToken,
+		"transferFrom": (*tokenContract).transferFrom,
+	}
+	functions := make(map[string]tokenFunction, len(abiFunctionMap))
+	for name, function := range abiFunctionMap {
+		method, ok := TokenABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions[string(method.ID)] = function
+	}
+	return functions
+}
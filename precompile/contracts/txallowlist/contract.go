@@ -0,0 +1,27 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txallowlist
+
+import (
+	"github.com/Juneo-io/jeth/precompile/allowlist"
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Singleton StatefulPrecompiledContract for the tx allow list.
+// The precompile only exposes the allow list functions: the sender role is
+// checked by the tx pool and during the state transition.
+var TxAllowListPrecompile contract.StatefulPrecompiledContract = allowlist.CreateAllowListPrecompile(ContractAddress)
+
+// GetTxAllowListStatus returns the role of [address] for the tx allow list.
+func GetTxAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetTxAllowListStatus sets the permissions of [address] to [role] for the
+// tx allow list.
+// Assumes [role] has already been verified as valid.
+func SetTxAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
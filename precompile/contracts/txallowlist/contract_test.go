@@ -0,0 +1,18 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txallowlist
+
+import (
+	"testing"
+
+	"github.com/Juneo-io/jeth/core/state"
+	"github.com/Juneo-io/jeth/precompile/allowlist"
+	"github.com/Juneo-io/jeth/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTxAllowList(t *testing.T) {
+	config := NewConfig(utils.NewUint64(0), []common.Address{allowlist.TestAdminAddr}, []common.Address{allowlist.TestEnabledAddr}, []common.Address{allowlist.TestManagerAddr})
+	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, config, nil)
+}
@@ -128,6 +128,9 @@ func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, blockHash com
 	if err := acceptCtx.Warp.AddMessage(unsignedMessage); err != nil {
 		return fmt.Errorf("failed to add warp message during accept (TxHash: %s, LogIndex: %d): %w", txHash, logIndex, err)
 	}
+	if err := acceptCtx.Warp.IndexMessage(unsignedMessage, blockHash, blockNumber, txHash, logIndex); err != nil {
+		return fmt.Errorf("failed to index warp message during accept (TxHash: %s, LogIndex: %d): %w", txHash, logIndex, err)
+	}
 	return nil
 }
 
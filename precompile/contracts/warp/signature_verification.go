@@ -0,0 +1,133 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/Juneo-io/juneogo/snow/validators"
+	"github.com/Juneo-io/juneogo/utils/set"
+	"github.com/Juneo-io/juneogo/vms/platformvm/warp"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var errUnsupportedSignature = errors.New("unsupported warp signature type")
+
+// SignatureVerification reports the weights of the signers of a warp message
+// and of the validators it is verified against, so that the weight missing
+// from a failed verification can be surfaced.
+type SignatureVerification struct {
+	SupernetID     ids.ID
+	PChainHeight   uint64
+	NumSigners     int
+	SignerWeight   uint64
+	TotalWeight    uint64
+	RequiredWeight uint64
+}
+
+// MissingWeight returns the weight the signers lack to reach the quorum.
+func (v *SignatureVerification) MissingWeight() uint64 {
+	if v.SignerWeight >= v.RequiredWeight {
+		return 0
+	}
+	return v.RequiredWeight - v.SignerWeight
+}
+
+// VerifySignature verifies the signature of [warpMsg] with
+// [warp.Signature.Verify], requiring [quorumNum]/[quorumDen] of the weight of
+// the canonical validator set of [supernetID] at [pChainHeight]. If
+// [supernetID] is empty, the supernet of the source chain is looked up in
+// [state].
+//
+// The returned error is the reason the verification failed. The weights of the
+// result are computed separately from the canonical validator set and the
+// signers of the signature, and are left empty if they cannot be computed.
+func VerifySignature(
+	ctx context.Context,
+	warpMsg *warp.Message,
+	networkID uint32,
+	state validators.State,
+	pChainHeight uint64,
+	supernetID ids.ID,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*SignatureVerification, error) {
+	if supernetID != ids.Empty {
+		state = &supernetState{State: state, supernetID: supernetID}
+	}
+	verifyErr := warpMsg.Signature.Verify(ctx, &warpMsg.UnsignedMessage, networkID, state, pChainHeight, quorumNum, quorumDen)
+
+	result := &SignatureVerification{
+		SupernetID:   supernetID,
+		PChainHeight: pChainHeight,
+	}
+	if err := result.computeWeights(ctx, warpMsg, state, quorumNum, quorumDen); err != nil {
+		log.Debug("failed to compute warp signature weights", "messageID", warpMsg.ID(), "err", err)
+	}
+	return result, verifyErr
+}
+
+// computeWeights fills the weights of [v] from the canonical validator set of
+// the supernet of [warpMsg] and the signers of its signature.
+func (v *SignatureVerification) computeWeights(ctx context.Context, warpMsg *warp.Message, state validators.State, quorumNum, quorumDen uint64) error {
+	supernetID, err := state.GetSupernetID(ctx, warpMsg.SourceChainID)
+	if err != nil {
+		return err
+	}
+	v.SupernetID = supernetID
+
+	vdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, state, v.PChainHeight, supernetID)
+	if err != nil {
+		return err
+	}
+	v.TotalWeight = totalWeight
+	v.RequiredWeight = requiredWeight(totalWeight, quorumNum, quorumDen)
+
+	signature, ok := warpMsg.Signature.(*warp.BitSetSignature)
+	if !ok {
+		return fmt.Errorf("%w: %T", errUnsupportedSignature, warpMsg.Signature)
+	}
+	signers, err := warp.FilterValidators(set.BitsFromBytes(signature.Signers), vdrs)
+	if err != nil {
+		return err
+	}
+	v.NumSigners = len(signers)
+	v.SignerWeight, err = warp.SumWeight(signers)
+	return err
+}
+
+// supernetState overrides the supernet of every chain in [validators.State],
+// so that signatures are verified against the validators of [supernetID].
+type supernetState struct {
+	validators.State
+	supernetID ids.ID
+}
+
+func (s *supernetState) GetSupernetID(context.Context, ids.ID) (ids.ID, error) {
+	return s.supernetID, nil
+}
+
+// requiredWeight returns the minimum weight satisfying
+// weight * [quorumDen] >= [totalWeight] * [quorumNum], as checked by
+// [warp.VerifyWeight].
+func requiredWeight(totalWeight, quorumNum, quorumDen uint64) uint64 {
+	if quorumDen == 0 {
+		return 0
+	}
+	required := new(big.Int).SetUint64(totalWeight)
+	required.Mul(required, new(big.Int).SetUint64(quorumNum))
+	den := new(big.Int).SetUint64(quorumDen)
+	required.Add(required, den)
+	required.Sub(required, big.NewInt(1))
+	required.Div(required, den)
+	if !required.IsUint64() {
+		return math.MaxUint64
+	}
+	return required.Uint64()
+}
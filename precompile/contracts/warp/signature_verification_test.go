@@ -651,3 +651,67 @@ func TestSignatureVerification(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifySignatureWeights(t *testing.T) {
+	otherSupernetID := ids.GenerateTestID()
+	tests := []struct {
+		name               string
+		supernetID         ids.ID
+		signers            []int
+		expectedSupernetID ids.ID
+		expectedErr        error
+		expectedMissing    uint64
+	}{
+		{
+			name:               "insufficient weight",
+			signers:            []int{0},
+			expectedSupernetID: sourceSupernetID,
+			expectedErr:        avalancheWarp.ErrInsufficientWeight,
+			expectedMissing:    3,
+		},
+		{
+			name:               "valid signature of another supernet",
+			supernetID:         otherSupernetID,
+			signers:            []int{1, 2},
+			expectedSupernetID: otherSupernetID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			state := validators.NewMockState(ctrl)
+			if test.supernetID == ids.Empty {
+				state.EXPECT().GetSupernetID(gomock.Any(), sourceChainID).Return(sourceSupernetID, nil).Times(2)
+			}
+			state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight, test.expectedSupernetID).Return(vdrs, nil).Times(2)
+
+			signers := set.NewBits()
+			signatures := make([]*bls.Signature, 0, len(test.signers))
+			for _, i := range test.signers {
+				signers.Add(i)
+				signatures = append(signatures, blsSignatures[i])
+			}
+			aggSig, err := bls.AggregateSignatures(signatures)
+			require.NoError(err)
+			aggSigBytes := [bls.SignatureLen]byte{}
+			copy(aggSigBytes[:], bls.SignatureToBytes(aggSig))
+			msg, err := avalancheWarp.NewMessage(unsignedMsg, &avalancheWarp.BitSetSignature{
+				Signers:   signers.Bytes(),
+				Signature: aggSigBytes,
+			})
+			require.NoError(err)
+
+			result, err := VerifySignature(context.Background(), msg, networkID, state, pChainHeight, test.supernetID, 2, 3)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedSupernetID, result.SupernetID)
+			require.Equal(len(test.signers), result.NumSigners)
+			require.Equal(uint64(3*len(test.signers)), result.SignerWeight)
+			require.Equal(uint64(9), result.TotalWeight)
+			require.Equal(uint64(6), result.RequiredWeight)
+			require.Equal(test.expectedMissing, result.MissingWeight())
+		})
+	}
+}
@@ -61,6 +61,7 @@ type SharedMemoryWriter interface {
 
 type WarpMessageWriter interface {
 	AddMessage(unsignedMessage *warp.UnsignedMessage) error
+	IndexMessage(unsignedMessage *warp.UnsignedMessage, blockHash common.Hash, blockNumber uint64, txHash common.Hash, logIndex int) error
 }
 
 // AcceptContext defines the context passed in to a precompileconfig's Accepter
@@ -7,5 +7,10 @@ package registry
 // Force imports of each precompile to ensure each precompile's init function runs and registers itself
 // with the registry.
 import (
+	_ "github.com/Juneo-io/jeth/precompile/contracts/blsverifier"
+	_ "github.com/Juneo-io/jeth/precompile/contracts/deployerallowlist"
+	_ "github.com/Juneo-io/jeth/precompile/contracts/feemanager"
+	_ "github.com/Juneo-io/jeth/precompile/contracts/nativeassettoken"
+	_ "github.com/Juneo-io/jeth/precompile/contracts/txallowlist"
 	_ "github.com/Juneo-io/jeth/precompile/contracts/warp"
 )
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"strings"
 	"testing"
@@ -28,6 +29,7 @@ import (
 	"github.com/Juneo-io/jeth/trie"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 func TestGetCode(t *testing.T) {
@@ -163,7 +165,7 @@ func TestGetBlocks(t *testing.T) {
 		BlockParser:      mockBlockParser,
 	})
 
-	blocksRequestHandler := handlers.NewBlockRequestHandler(buildGetter(blocks), message.Codec, handlerstats.NewNoopHandlerStats())
+	blocksRequestHandler := handlers.NewBlockRequestHandler(buildGetter(blocks), nil, message.Codec, handlerstats.NewNoopHandlerStats(), nil)
 
 	// encodeBlockSlice takes a slice of blocks that are ordered in increasing height order
 	// and returns a slice of byte slices with those blocks encoded in reverse order
@@ -393,6 +395,145 @@ func TestGetBlocks(t *testing.T) {
 	}
 }
 
+func TestGetReceipts(t *testing.T) {
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		gspec   = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{addr1: {Balance: big.NewInt(1000000000000000000)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	memdb := rawdb.NewMemoryDatabase()
+	tdb := trie.NewDatabase(memdb, nil)
+	genesis := gspec.MustCommit(memdb, tdb)
+	engine := dummy.NewETHFaker()
+	blocks, receipts, err := core.GenerateChain(gspec.Config, genesis, engine, memdb, 16, 0, func(i int, b *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(b.TxNonce(addr1), addr1, big.NewInt(10000), params.TxGas, b.BaseFee(), nil), signer, key1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.AddTx(tx)
+	})
+	if err != nil {
+		t.Fatal("unexpected error when generating test blockchain", err)
+	}
+
+	receiptsDB := make(map[common.Hash]types.Receipts, len(blocks))
+	for i, blk := range blocks {
+		receiptsDB[blk.Hash()] = receipts[i]
+	}
+	blocksRequestHandler := handlers.NewBlockRequestHandler(
+		buildGetter(append([]*types.Block{genesis}, blocks...)),
+		&handlers.TestReceiptProvider{
+			GetReceiptsByHashFn: func(hash common.Hash) types.Receipts { return receiptsDB[hash] },
+		},
+		message.Codec,
+		handlerstats.NewNoopHandlerStats(),
+		nil,
+	)
+
+	// requestedBlocks are ordered from newest to oldest
+	requestedBlocks := make([]*types.Block, 0, 8)
+	for i := 12; i > 4; i-- {
+		requestedBlocks = append(requestedBlocks, blocks[i])
+	}
+	marshalResponse := func(t *testing.T, receipts []types.Receipts) []byte {
+		response := message.ReceiptsResponse{}
+		for _, blockReceipts := range receipts {
+			receiptsBytes, err := rlp.EncodeToBytes(blockReceipts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response.Receipts = append(response.Receipts, receiptsBytes)
+		}
+		responseBytes, err := message.Codec.Marshal(message.Version, response)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return responseBytes
+	}
+
+	tests := map[string]struct {
+		getResponse func(t *testing.T) []byte
+		expectedErr error
+	}{
+		"normal response": {
+			getResponse: func(t *testing.T) []byte {
+				response, err := blocksRequestHandler.OnReceiptsRequest(context.Background(), ids.GenerateTestNodeID(), 1, message.ReceiptsRequest{
+					Hash:    requestedBlocks[0].Hash(),
+					Height:  requestedBlocks[0].NumberU64(),
+					Parents: uint16(len(requestedBlocks)),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return response
+			},
+		},
+		"receipts missing from response": {
+			getResponse: func(t *testing.T) []byte {
+				return marshalResponse(t, make([]types.Receipts, 1))
+			},
+			expectedErr: errHashMismatch,
+		},
+		"too many receipts": {
+			getResponse: func(t *testing.T) []byte {
+				return marshalResponse(t, make([]types.Receipts, len(requestedBlocks)+1))
+			},
+			expectedErr: errTooManyReceipts,
+		},
+		"empty response": {
+			getResponse: func(t *testing.T) []byte {
+				return marshalResponse(t, nil)
+			},
+			expectedErr: errEmptyResponse,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockNetClient := &mockNetwork{}
+			stateSyncClient := NewClient(&ClientConfig{
+				NetworkClient: mockNetClient,
+				Codec:         message.Codec,
+				Stats:         clientstats.NewNoOpStats(),
+				BlockParser:   mockBlockParser,
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			responseBytes := test.getResponse(t)
+			if test.expectedErr == nil {
+				mockNetClient.mockResponse(1, nil, responseBytes)
+			} else {
+				attempted := false
+				mockNetClient.mockResponse(2, func() {
+					if attempted {
+						cancel()
+					}
+					attempted = true
+				}, responseBytes)
+			}
+
+			receiptsResponse, err := stateSyncClient.GetReceipts(ctx, requestedBlocks)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Len(t, receiptsResponse, len(requestedBlocks))
+			for i, blockReceipts := range receiptsResponse {
+				assert.Equal(t, requestedBlocks[i].ReceiptHash(), types.DeriveSha(blockReceipts, trie.NewStackTrie(nil)))
+			}
+		})
+	}
+}
+
 func buildGetter(blocks []*types.Block) handlers.BlockProvider {
 	return &handlers.TestBlockProvider{
 		GetBlockFn: func(blockHash common.Hash, blockHeight uint64) *types.Block {
@@ -415,7 +556,7 @@ func TestGetLeafs(t *testing.T) {
 	largeTrieRoot, largeTrieKeys, _ := syncutils.GenerateTrie(t, trieDB, 100_000, common.HashLength)
 	smallTrieRoot, _, _ := syncutils.GenerateTrie(t, trieDB, leafsLimit, common.HashLength)
 
-	handler := handlers.NewLeafsRequestHandler(trieDB, nil, message.Codec, handlerstats.NewNoopHandlerStats())
+	handler := handlers.NewLeafsRequestHandler(trieDB, nil, message.Codec, handlerstats.NewNoopHandlerStats(), nil)
 	client := NewClient(&ClientConfig{
 		NetworkClient:    &mockNetwork{},
 		Codec:            message.Codec,
@@ -797,7 +938,7 @@ func TestGetLeafsRetries(t *testing.T) {
 	trieDB := trie.NewDatabase(rawdb.NewMemoryDatabase(), nil)
 	root, _, _ := syncutils.GenerateTrie(t, trieDB, 100_000, common.HashLength)
 
-	handler := handlers.NewLeafsRequestHandler(trieDB, nil, message.Codec, handlerstats.NewNoopHandlerStats())
+	handler := handlers.NewLeafsRequestHandler(trieDB, nil, message.Codec, handlerstats.NewNoopHandlerStats(), nil)
 	mockNetClient := &mockNetwork{}
 
 	const maxAttempts = 8
@@ -148,3 +148,27 @@ func (t *testBlockParser) ParseEthBlock(b []byte) (*types.Block, error) {
 
 	return block, nil
 }
+
+func (ml *MockClient) GetReceipts(ctx context.Context, blocks []*types.Block) ([]types.Receipts, error) {
+	if ml.blocksHandler == nil {
+		panic("no blocks handler for mock client")
+	}
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	request := message.ReceiptsRequest{
+		Hash:    blocks[0].Hash(),
+		Height:  blocks[0].NumberU64(),
+		Parents: uint16(len(blocks)),
+	}
+	response, err := ml.blocksHandler.OnReceiptsRequest(ctx, ids.GenerateTestNodeID(), 1, request)
+	if err != nil {
+		return nil, err
+	}
+
+	receiptsRes, _, err := parseReceipts(ml.codec, blocks, response)
+	if err != nil {
+		return nil, err
+	}
+	return receiptsRes.([]types.Receipts), nil
+}
@@ -12,10 +12,12 @@ import (
 	"github.com/Juneo-io/juneogo/ids"
 	"github.com/Juneo-io/juneogo/utils/units"
 
+	"github.com/Juneo-io/jeth/core/types"
 	"github.com/Juneo-io/jeth/plugin/evm/message"
 	"github.com/Juneo-io/jeth/sync/handlers/stats"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 const (
@@ -26,30 +28,49 @@ const (
 )
 
 // BlockRequestHandler is a peer.RequestHandler for message.BlockRequest
-// serving requested blocks starting at specified hash
+// and message.ReceiptsRequest serving requested blocks and their receipts
+// starting at specified hash
 type BlockRequestHandler struct {
-	stats         stats.BlockRequestHandlerStats
-	blockProvider BlockProvider
-	codec         codec.Manager
+	stats           stats.BlockRequestHandlerStats
+	blockProvider   BlockProvider
+	receiptProvider ReceiptProvider
+	codec           codec.Manager
+	throttler       *Throttler
 }
 
-func NewBlockRequestHandler(blockProvider BlockProvider, codec codec.Manager, handlerStats stats.BlockRequestHandlerStats) *BlockRequestHandler {
+// NewBlockRequestHandler returns a BlockRequestHandler serving blocks from [blockProvider]
+// and receipts from [receiptProvider], within the per-peer budgets of [throttler] if non-nil.
+// Receipts requests are dropped if [receiptProvider] is nil.
+func NewBlockRequestHandler(blockProvider BlockProvider, receiptProvider ReceiptProvider, codec codec.Manager, handlerStats stats.BlockRequestHandlerStats, throttler *Throttler) *BlockRequestHandler {
 	return &BlockRequestHandler{
-		blockProvider: blockProvider,
-		codec:         codec,
-		stats:         handlerStats,
+		blockProvider:   blockProvider,
+		receiptProvider: receiptProvider,
+		codec:           codec,
+		stats:           handlerStats,
+		throttler:       throttler,
 	}
 }
 
 // OnBlockRequest handles incoming message.BlockRequest, returning blocks as requested
 // Never returns error
 // Expects returned errors to be treated as FATAL
+// Returns empty response if the peer is out of budget
 // Returns empty response or subset of requested blocks if ctx expires during fetch
+// or the bytes left in the budget of the peer are exceeded
 // Assumes ctx is active
 func (b *BlockRequestHandler) OnBlockRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockRequest message.BlockRequest) ([]byte, error) {
 	startTime := time.Now()
 	b.stats.IncBlockRequest()
 
+	maxBytes, ok := b.acquire(nodeID)
+	if !ok {
+		return throttledResponse(b.codec, nodeID, requestID, message.BlockResponse{})
+	}
+	var bytesServed int
+	defer func() {
+		b.throttler.release(nodeID, 0, bytesServed)
+	}()
+
 	// override given Parents limit if it is greater than parentLimit
 	parents := blockRequest.Parents
 	if parents > parentLimit {
@@ -89,8 +110,8 @@ func (b *BlockRequestHandler) OnBlockRequest(ctx context.Context, nodeID ids.Nod
 			return nil, nil
 		}
 
-		if buf.Len()+totalBytes > targetMessageByteSize && len(blocks) > 0 {
-			log.Debug("Skipping block due to max total bytes size", "totalBlockDataSize", totalBytes, "blockSize", buf.Len(), "maxTotalBytesSize", targetMessageByteSize)
+		if buf.Len()+totalBytes > maxBytes && len(blocks) > 0 {
+			log.Debug("Skipping block due to max total bytes size", "totalBlockDataSize", totalBytes, "blockSize", buf.Len(), "maxTotalBytesSize", maxBytes)
 			break
 		}
 
@@ -115,5 +136,123 @@ func (b *BlockRequestHandler) OnBlockRequest(ctx context.Context, nodeID ids.Nod
 		return nil, nil
 	}
 
+	bytesServed = len(responseBytes)
+	return responseBytes, nil
+}
+
+// OnReceiptsRequest handles incoming message.ReceiptsRequest, returning the receipts
+// of the requested blocks in their consensus encoding
+// Never returns error
+// Expects returned errors to be treated as FATAL
+// Returns empty response if the peer is out of budget
+// Returns empty response or receipts of a subset of requested blocks if ctx expires during fetch
+// or the bytes left in the budget of the peer are exceeded
+// Assumes ctx is active
+func (b *BlockRequestHandler) OnReceiptsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, receiptsRequest message.ReceiptsRequest) ([]byte, error) {
+	if b.receiptProvider == nil {
+		log.Debug("receipts are not served, dropping request", "nodeID", nodeID, "requestID", requestID)
+		return nil, nil
+	}
+	startTime := time.Now()
+	b.stats.IncReceiptsRequest()
+
+	maxBytes, ok := b.acquire(nodeID)
+	if !ok {
+		return throttledResponse(b.codec, nodeID, requestID, message.ReceiptsResponse{})
+	}
+	var bytesServed int
+	defer func() {
+		b.throttler.release(nodeID, 0, bytesServed)
+	}()
+
+	// override given Parents limit if it is greater than parentLimit
+	parents := receiptsRequest.Parents
+	if parents > parentLimit {
+		parents = parentLimit
+	}
+	receipts := make([][]byte, 0, parents)
+	totalBytes := 0
+
+	// ensure metrics are captured properly on all return paths
+	defer func() {
+		b.stats.UpdateReceiptsRequestProcessingTime(time.Since(startTime))
+		b.stats.UpdateReceiptsReturned(uint16(len(receipts)))
+	}()
+
+	hash := receiptsRequest.Hash
+	height := receiptsRequest.Height
+	for i := 0; i < int(parents); i++ {
+		// we return whatever we have until ctx errors, limit is exceeded, or we reach the genesis block
+		if ctx.Err() != nil {
+			break
+		}
+
+		if (hash == common.Hash{}) {
+			break
+		}
+
+		block := b.blockProvider.GetBlock(hash, height)
+		if block == nil {
+			b.stats.IncMissingBlockHash()
+			break
+		}
+
+		var blockReceipts types.Receipts
+		if len(block.Transactions()) > 0 {
+			blockReceipts = b.receiptProvider.GetReceiptsByHash(hash)
+			if len(blockReceipts) != len(block.Transactions()) {
+				b.stats.IncMissingReceipts()
+				break
+			}
+		}
+
+		receiptsBytes, err := rlp.EncodeToBytes(blockReceipts)
+		if err != nil {
+			log.Error("failed to RLP encode receipts", "hash", hash, "height", height, "err", err)
+			return nil, nil
+		}
+
+		if len(receiptsBytes)+totalBytes > maxBytes && len(receipts) > 0 {
+			log.Debug("Skipping receipts due to max total bytes size", "totalReceiptsDataSize", totalBytes, "receiptsSize", len(receiptsBytes), "maxTotalBytesSize", maxBytes)
+			break
+		}
+
+		receipts = append(receipts, receiptsBytes)
+		totalBytes += len(receiptsBytes)
+		hash = block.ParentHash()
+		height--
+	}
+
+	if len(receipts) == 0 {
+		// drop this request
+		log.Debug("no requested receipts found, dropping request", "nodeID", nodeID, "requestID", requestID, "hash", receiptsRequest.Hash, "parents", receiptsRequest.Parents)
+		return nil, nil
+	}
+
+	response := message.ReceiptsResponse{
+		Receipts: receipts,
+	}
+	responseBytes, err := b.codec.Marshal(message.Version, response)
+	if err != nil {
+		log.Error("failed to marshal ReceiptsResponse, dropping request", "nodeID", nodeID, "requestID", requestID, "hash", receiptsRequest.Hash, "parents", receiptsRequest.Parents, "receiptsLen", len(response.Receipts), "err", err)
+		return nil, nil
+	}
+
+	bytesServed = len(responseBytes)
 	return responseBytes, nil
 }
+
+// acquire reserves a request of [nodeID] from the throttler and returns the
+// maximum size of the response, which is [targetMessageByteSize] or the bytes
+// left in the budget of the peer if lower.
+func (b *BlockRequestHandler) acquire(nodeID ids.NodeID) (int, bool) {
+	_, bytesBudget, ok := b.throttler.acquire(nodeID, false)
+	if !ok {
+		return 0, false
+	}
+	maxBytes := targetMessageByteSize
+	if bytesBudget < uint64(maxBytes) {
+		maxBytes = int(bytesBudget)
+	}
+	return maxBytes, true
+}
@@ -55,7 +55,7 @@ func executeBlockRequestTest(t testing.TB, test blockRequestTest, blocks []*type
 			return blk
 		},
 	}
-	blockRequestHandler := NewBlockRequestHandler(blockProvider, message.Codec, mockHandlerStats)
+	blockRequestHandler := NewBlockRequestHandler(blockProvider, nil, message.Codec, mockHandlerStats, nil)
 
 	var blockRequest message.BlockRequest
 	if test.startBlockHash != (common.Hash{}) {
@@ -252,7 +252,7 @@ func TestBlockRequestHandlerCtxExpires(t *testing.T) {
 			return blk
 		},
 	}
-	blockRequestHandler := NewBlockRequestHandler(blockProvider, message.Codec, stats.NewNoopHandlerStats())
+	blockRequestHandler := NewBlockRequestHandler(blockProvider, nil, message.Codec, stats.NewNoopHandlerStats(), nil)
 
 	responseBytes, err := blockRequestHandler.OnBlockRequest(ctx, ids.GenerateTestNodeID(), 1, message.BlockRequest{
 		Hash:    blocks[10].Hash(),
@@ -279,3 +279,133 @@ func TestBlockRequestHandlerCtxExpires(t *testing.T) {
 		assert.Equal(t, blocks[len(blocks)-i-1].Hash(), block.Hash())
 	}
 }
+
+func TestReceiptsRequestHandler(t *testing.T) {
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		funds   = big.NewInt(1000000000000000000)
+		gspec   = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{addr1: {Balance: funds}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	memdb := rawdb.NewMemoryDatabase()
+	tdb := trie.NewDatabase(memdb, nil)
+	genesis := gspec.MustCommit(memdb, tdb)
+	engine := dummy.NewETHFaker()
+	blocks, receipts, err := core.GenerateChain(gspec.Config, genesis, engine, memdb, 16, 0, func(i int, b *core.BlockGen) {
+		if i%4 == 0 {
+			// leave some blocks empty
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(b.TxNonce(addr1), addr1, big.NewInt(10000), params.TxGas, b.BaseFee(), nil), signer, key1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.AddTx(tx)
+	})
+	if err != nil {
+		t.Fatal("unexpected error when generating test blockchain", err)
+	}
+	assert.Len(t, blocks, 16)
+
+	blocksDB := make(map[common.Hash]*types.Block, len(blocks))
+	receiptsDB := make(map[common.Hash]types.Receipts, len(blocks))
+	for i, blk := range blocks {
+		blocksDB[blk.Hash()] = blk
+		receiptsDB[blk.Hash()] = receipts[i]
+	}
+	blockProvider := &TestBlockProvider{
+		GetBlockFn: func(hash common.Hash, height uint64) *types.Block {
+			blk, ok := blocksDB[hash]
+			if !ok || blk.NumberU64() != height {
+				return nil
+			}
+			return blk
+		},
+	}
+
+	tests := map[string]struct {
+		receiptProvider   ReceiptProvider
+		startBlockIndex   int
+		requestedParents  uint16
+		expectedReceipts  int
+		expectNilResponse bool
+		assertStats       func(t *testing.T, stats *stats.MockHandlerStats)
+	}{
+		"handler_returns_receipts_as_requested": {
+			receiptProvider: &TestReceiptProvider{
+				GetReceiptsByHashFn: func(hash common.Hash) types.Receipts { return receiptsDB[hash] },
+			},
+			startBlockIndex:  12,
+			requestedParents: 8,
+			expectedReceipts: 8,
+		},
+		"handler_stops_at_missing_block": {
+			receiptProvider: &TestReceiptProvider{
+				GetReceiptsByHashFn: func(hash common.Hash) types.Receipts { return receiptsDB[hash] },
+			},
+			startBlockIndex:  3,
+			requestedParents: 64,
+			expectedReceipts: 4,
+			assertStats: func(t *testing.T, stats *stats.MockHandlerStats) {
+				// the genesis block is not known to the block provider
+				assert.Equal(t, uint32(1), stats.MissingBlockHashCount)
+			},
+		},
+		"handler_missing_receipts": {
+			receiptProvider: &TestReceiptProvider{
+				GetReceiptsByHashFn: func(common.Hash) types.Receipts { return nil },
+			},
+			startBlockIndex:   15,
+			requestedParents:  8,
+			expectNilResponse: true,
+			assertStats: func(t *testing.T, stats *stats.MockHandlerStats) {
+				assert.Equal(t, uint32(1), stats.MissingReceiptsCount)
+			},
+		},
+		"handler_without_receipt_provider": {
+			startBlockIndex:   15,
+			requestedParents:  8,
+			expectNilResponse: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockHandlerStats := &stats.MockHandlerStats{}
+			blockRequestHandler := NewBlockRequestHandler(blockProvider, test.receiptProvider, message.Codec, mockHandlerStats, nil)
+
+			startBlock := blocks[test.startBlockIndex]
+			responseBytes, err := blockRequestHandler.OnReceiptsRequest(context.Background(), ids.GenerateTestNodeID(), 1, message.ReceiptsRequest{
+				Hash:    startBlock.Hash(),
+				Height:  startBlock.NumberU64(),
+				Parents: test.requestedParents,
+			})
+			assert.NoError(t, err)
+			if test.assertStats != nil {
+				test.assertStats(t, mockHandlerStats)
+			}
+			if test.expectNilResponse {
+				assert.Nil(t, responseBytes)
+				return
+			}
+
+			var response message.ReceiptsResponse
+			if _, err = message.Codec.Unmarshal(responseBytes, &response); err != nil {
+				t.Fatal("error unmarshalling", err)
+			}
+			assert.Len(t, response.Receipts, test.expectedReceipts)
+			for i, receiptsBytes := range response.Receipts {
+				var blockReceipts types.Receipts
+				if err := rlp.DecodeBytes(receiptsBytes, &blockReceipts); err != nil {
+					t.Fatal("could not parse receipts", err)
+				}
+				block := blocks[test.startBlockIndex-i]
+				assert.Len(t, blockReceipts, len(block.Transactions()))
+				assert.Equal(t, block.ReceiptHash(), types.DeriveSha(blockReceipts, trie.NewStackTrie(nil)))
+			}
+		})
+	}
+}
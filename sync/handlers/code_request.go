@@ -24,13 +24,17 @@ type CodeRequestHandler struct {
 	codeReader ethdb.KeyValueReader
 	codec      codec.Manager
 	stats      stats.CodeRequestHandlerStats
+	throttler  *Throttler
 }
 
-func NewCodeRequestHandler(codeReader ethdb.KeyValueReader, codec codec.Manager, stats stats.CodeRequestHandlerStats) *CodeRequestHandler {
+// NewCodeRequestHandler returns a CodeRequestHandler serving code from [codeReader], within
+// the per-peer budgets of [throttler] if non-nil.
+func NewCodeRequestHandler(codeReader ethdb.KeyValueReader, codec codec.Manager, stats stats.CodeRequestHandlerStats, throttler *Throttler) *CodeRequestHandler {
 	handler := &CodeRequestHandler{
 		codeReader: codeReader,
 		codec:      codec,
 		stats:      stats,
+		throttler:  throttler,
 	}
 	return handler
 }
@@ -38,6 +42,7 @@ func NewCodeRequestHandler(codeReader ethdb.KeyValueReader, codec codec.Manager,
 // OnCodeRequest handles request to retrieve contract code by its hash in message.CodeRequest
 // Never returns error
 // Returns nothing if code hash is not found
+// Returns empty response if the peer is out of budget
 // Expects returned errors to be treated as FATAL
 // Assumes ctx is active
 func (n *CodeRequestHandler) OnCodeRequest(_ context.Context, nodeID ids.NodeID, requestID uint32, codeRequest message.CodeRequest) ([]byte, error) {
@@ -60,6 +65,16 @@ func (n *CodeRequestHandler) OnCodeRequest(_ context.Context, nodeID ids.NodeID,
 		return nil, nil
 	}
 
+	// Code is not trimmed to the bytes left in the budget, since all the
+	// requested code must be returned.
+	if _, _, ok := n.throttler.acquire(nodeID, false); !ok {
+		return throttledResponse(n.codec, nodeID, requestID, message.CodeResponse{})
+	}
+	var bytesServed int
+	defer func() {
+		n.throttler.release(nodeID, 0, bytesServed)
+	}()
+
 	codeBytes := make([][]byte, len(codeRequest.Hashes))
 	totalBytes := 0
 	for i, hash := range codeRequest.Hashes {
@@ -79,6 +94,7 @@ func (n *CodeRequestHandler) OnCodeRequest(_ context.Context, nodeID ids.NodeID,
 		return nil, nil
 	}
 	n.stats.UpdateCodeBytesReturned(uint32(totalBytes))
+	bytesServed = len(responseBytes)
 	return responseBytes, nil
 }
 
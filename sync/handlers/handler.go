@@ -13,11 +13,16 @@ type BlockProvider interface {
 	GetBlock(common.Hash, uint64) *types.Block
 }
 
+type ReceiptProvider interface {
+	GetReceiptsByHash(common.Hash) types.Receipts
+}
+
 type SnapshotProvider interface {
 	Snapshots() *snapshot.Tree
 }
 
 type SyncDataProvider interface {
 	BlockProvider
+	ReceiptProvider
 	SnapshotProvider
 }
@@ -46,15 +46,19 @@ type LeafsRequestHandler struct {
 	snapshotProvider SnapshotProvider
 	codec            codec.Manager
 	stats            stats.LeafsRequestHandlerStats
+	throttler        *Throttler
 	pool             sync.Pool
 }
 
-func NewLeafsRequestHandler(trieDB *trie.Database, snapshotProvider SnapshotProvider, codec codec.Manager, syncerStats stats.LeafsRequestHandlerStats) *LeafsRequestHandler {
+// NewLeafsRequestHandler returns a LeafsRequestHandler serving leafs from [trieDB], within
+// the per-peer budgets of [throttler] if non-nil.
+func NewLeafsRequestHandler(trieDB *trie.Database, snapshotProvider SnapshotProvider, codec codec.Manager, syncerStats stats.LeafsRequestHandlerStats, throttler *Throttler) *LeafsRequestHandler {
 	return &LeafsRequestHandler{
 		trieDB:           trieDB,
 		snapshotProvider: snapshotProvider,
 		codec:            codec,
 		stats:            syncerStats,
+		throttler:        throttler,
 		pool: sync.Pool{
 			New: func() interface{} { return make([][]byte, 0, maxLeavesLimit) },
 		},
@@ -66,7 +70,9 @@ func NewLeafsRequestHandler(trieDB *trie.Database, snapshotProvider SnapshotProv
 // Returned message.LeafsResponse may contain partial leaves within requested Start and End range if:
 // - ctx expired while fetching leafs
 // - number of leaves read is greater than Limit (message.LeafsRequest)
-// Specified Limit in message.LeafsRequest is overridden to maxLeavesLimit if it is greater than maxLeavesLimit
+// Specified Limit in message.LeafsRequest is overridden to maxLeavesLimit if it is greater than maxLeavesLimit,
+// and to the leafs left in the budget of the peer if it is greater than them
+// Returns empty response if the peer is out of budget
 // Expects returned errors to be treated as FATAL
 // Never returns errors
 // Expects NodeType to be one of message.AtomicTrieNode or message.StateTrieNode
@@ -98,6 +104,15 @@ func (lrh *LeafsRequestHandler) OnLeafsRequest(ctx context.Context, nodeID ids.N
 		return nil, nil
 	}
 
+	leafsBudget, _, ok := lrh.throttler.acquire(nodeID, true)
+	if !ok {
+		return throttledResponse(lrh.codec, nodeID, requestID, message.LeafsResponse{})
+	}
+	var leafsServed, bytesServed int
+	defer func() {
+		lrh.throttler.release(nodeID, leafsServed, bytesServed)
+	}()
+
 	// TODO: We should know the state root that accounts correspond to,
 	// as this information will be necessary to access storage tries when
 	// the trie is path based.
@@ -113,6 +128,9 @@ func (lrh *LeafsRequestHandler) OnLeafsRequest(ctx context.Context, nodeID ids.N
 	if limit > maxLeavesLimit {
 		limit = maxLeavesLimit
 	}
+	if uint64(limit) > leafsBudget {
+		limit = uint16(leafsBudget)
+	}
 
 	var leafsResponse message.LeafsResponse
 	// pool response's key/val allocations
@@ -166,6 +184,7 @@ func (lrh *LeafsRequestHandler) OnLeafsRequest(ctx context.Context, nodeID ids.N
 		return nil, nil
 	}
 
+	leafsServed, bytesServed = len(leafsResponse.Keys), len(responseBytes)
 	log.Debug("handled leafsRequest", "time", time.Since(startTime), "leafs", len(leafsResponse.Keys), "proofLen", len(leafsResponse.ProofVals))
 	return responseBytes, nil
 }
@@ -19,6 +19,11 @@ type MockHandlerStats struct {
 	BlocksReturnedSum uint32
 	BlockRequestProcessingTimeSum time.Duration
 
+	ReceiptsRequestCount,
+	MissingReceiptsCount,
+	ReceiptsReturnedSum uint32
+	ReceiptsRequestProcessingTimeSum time.Duration
+
 	CodeRequestCount,
 	MissingCodeHashCount,
 	TooManyHashesRequested,
@@ -51,6 +56,10 @@ func (m *MockHandlerStats) Reset() {
 	m.MissingBlockHashCount = 0
 	m.BlocksReturnedSum = 0
 	m.BlockRequestProcessingTimeSum = 0
+	m.ReceiptsRequestCount = 0
+	m.MissingReceiptsCount = 0
+	m.ReceiptsReturnedSum = 0
+	m.ReceiptsRequestProcessingTimeSum = 0
 	m.CodeRequestCount = 0
 	m.MissingCodeHashCount = 0
 	m.TooManyHashesRequested = 0
@@ -99,6 +108,30 @@ func (m *MockHandlerStats) UpdateBlockRequestProcessingTime(duration time.Durati
 	m.BlockRequestProcessingTimeSum += duration
 }
 
+func (m *MockHandlerStats) IncReceiptsRequest() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ReceiptsRequestCount++
+}
+
+func (m *MockHandlerStats) IncMissingReceipts() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.MissingReceiptsCount++
+}
+
+func (m *MockHandlerStats) UpdateReceiptsReturned(num uint16) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ReceiptsReturnedSum += uint32(num)
+}
+
+func (m *MockHandlerStats) UpdateReceiptsRequestProcessingTime(duration time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ReceiptsRequestProcessingTimeSum += duration
+}
+
 func (m *MockHandlerStats) IncCodeRequest() {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -21,6 +21,10 @@ type BlockRequestHandlerStats interface {
 	IncMissingBlockHash()
 	UpdateBlocksReturned(num uint16)
 	UpdateBlockRequestProcessingTime(duration time.Duration)
+	IncReceiptsRequest()
+	IncMissingReceipts()
+	UpdateReceiptsReturned(num uint16)
+	UpdateReceiptsRequestProcessingTime(duration time.Duration)
 }
 
 type CodeRequestHandlerStats interface {
@@ -58,6 +62,12 @@ type handlerStats struct {
 	blocksReturned             metrics.Histogram
 	blockRequestProcessingTime metrics.Timer
 
+	// receipts requests, served by the BlockRequestHandler
+	receiptsRequest               metrics.Counter
+	missingReceipts               metrics.Counter
+	receiptsReturned              metrics.Histogram
+	receiptsRequestProcessingTime metrics.Timer
+
 	// CodeRequestHandler stats
 	codeRequest              metrics.Counter
 	missingCodeHash          metrics.Counter
@@ -101,6 +111,22 @@ func (h *handlerStats) UpdateBlockRequestProcessingTime(duration time.Duration)
 	h.blockRequestProcessingTime.Update(duration)
 }
 
+func (h *handlerStats) IncReceiptsRequest() {
+	h.receiptsRequest.Inc(1)
+}
+
+func (h *handlerStats) IncMissingReceipts() {
+	h.missingReceipts.Inc(1)
+}
+
+func (h *handlerStats) UpdateReceiptsReturned(num uint16) {
+	h.receiptsReturned.Update(int64(num))
+}
+
+func (h *handlerStats) UpdateReceiptsRequestProcessingTime(duration time.Duration) {
+	h.receiptsRequestProcessingTime.Update(duration)
+}
+
 func (h *handlerStats) IncCodeRequest() {
 	h.codeRequest.Inc(1)
 }
@@ -177,6 +203,12 @@ func NewHandlerStats(enabled bool) HandlerStats {
 		blocksReturned:             metrics.GetOrRegisterHistogram("block_request_total_blocks", nil, metrics.NewExpDecaySample(1028, 0.015)),
 		blockRequestProcessingTime: metrics.GetOrRegisterTimer("block_request_processing_time", nil),
 
+		// initialize receipts request stats
+		receiptsRequest:               metrics.GetOrRegisterCounter("receipts_request_count", nil),
+		missingReceipts:               metrics.GetOrRegisterCounter("receipts_request_missing_receipts", nil),
+		receiptsReturned:              metrics.GetOrRegisterHistogram("receipts_request_total_receipts", nil, metrics.NewExpDecaySample(1028, 0.015)),
+		receiptsRequestProcessingTime: metrics.GetOrRegisterTimer("receipts_request_processing_time", nil),
+
 		// initialize code request stats
 		codeRequest:              metrics.GetOrRegisterCounter("code_request_count", nil),
 		missingCodeHash:          metrics.GetOrRegisterCounter("code_request_missing_code_hash", nil),
@@ -217,6 +249,10 @@ func (n *noopHandlerStats) IncBlockRequest()
 func (n *noopHandlerStats) IncMissingBlockHash()                                {}
 func (n *noopHandlerStats) UpdateBlocksReturned(uint16)                         {}
 func (n *noopHandlerStats) UpdateBlockRequestProcessingTime(time.Duration)      {}
+func (n *noopHandlerStats) IncReceiptsRequest()                                 {}
+func (n *noopHandlerStats) IncMissingReceipts()                                 {}
+func (n *noopHandlerStats) UpdateReceiptsReturned(uint16)                       {}
+func (n *noopHandlerStats) UpdateReceiptsRequestProcessingTime(time.Duration)   {}
 func (n *noopHandlerStats) IncCodeRequest()                                     {}
 func (n *noopHandlerStats) IncMissingCodeHash()                                 {}
 func (n *noopHandlerStats) IncTooManyHashesRequested()                          {}
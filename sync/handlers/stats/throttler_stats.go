@@ -0,0 +1,129 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package stats
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Juneo-io/juneogo/ids"
+
+	"github.com/Juneo-io/jeth/metrics"
+)
+
+// ThrottlerStats reports prometheus metrics for the per-peer budgets of the
+// state sync handlers, aggregated over all peers
+type ThrottlerStats interface {
+	IncRequest()
+	IncThrottledRequest()
+	UpdateLeafsServed(numLeafs int)
+	UpdateBytesServed(numBytes int)
+	UpdateTrackedPeers(numPeers int)
+
+	// PeerStats registers and returns the metrics of the requests served to [nodeID]
+	PeerStats(nodeID ids.NodeID) PeerStats
+}
+
+// PeerStats reports prometheus metrics for the requests served to a single peer
+type PeerStats interface {
+	IncRequest()
+	IncThrottledRequest()
+	UpdateLeafsServed(numLeafs int)
+	UpdateBytesServed(numBytes int)
+
+	// Unregister removes the metrics of the peer once it is no longer tracked
+	Unregister()
+}
+
+type throttlerStats struct {
+	request          metrics.Counter
+	throttledRequest metrics.Counter
+	leafsServed      metrics.Counter
+	bytesServed      metrics.Counter
+	trackedPeers     metrics.Gauge
+}
+
+func (t *throttlerStats) IncRequest()                     { t.request.Inc(1) }
+func (t *throttlerStats) IncThrottledRequest()            { t.throttledRequest.Inc(1) }
+func (t *throttlerStats) UpdateLeafsServed(numLeafs int)  { t.leafsServed.Inc(int64(numLeafs)) }
+func (t *throttlerStats) UpdateBytesServed(numBytes int)  { t.bytesServed.Inc(int64(numBytes)) }
+func (t *throttlerStats) UpdateTrackedPeers(numPeers int) { t.trackedPeers.Update(int64(numPeers)) }
+
+func (t *throttlerStats) PeerStats(nodeID ids.NodeID) PeerStats {
+	// Node IDs are formatted as "NodeID-<cb58>", so the dash is replaced to
+	// form a valid metric name.
+	prefix := fmt.Sprintf("sync_peer_%s", strings.ReplaceAll(nodeID.String(), "-", "_"))
+	stats := &peerStats{
+		names: []string{
+			prefix + "_request_count",
+			prefix + "_throttled_request_count",
+			prefix + "_leafs_served",
+			prefix + "_bytes_served",
+		},
+	}
+	stats.request = metrics.GetOrRegisterCounter(stats.names[0], nil)
+	stats.throttledRequest = metrics.GetOrRegisterCounter(stats.names[1], nil)
+	stats.leafsServed = metrics.GetOrRegisterCounter(stats.names[2], nil)
+	stats.bytesServed = metrics.GetOrRegisterCounter(stats.names[3], nil)
+	return stats
+}
+
+type peerStats struct {
+	names []string
+
+	request          metrics.Counter
+	throttledRequest metrics.Counter
+	leafsServed      metrics.Counter
+	bytesServed      metrics.Counter
+}
+
+func (p *peerStats) IncRequest()                    { p.request.Inc(1) }
+func (p *peerStats) IncThrottledRequest()           { p.throttledRequest.Inc(1) }
+func (p *peerStats) UpdateLeafsServed(numLeafs int) { p.leafsServed.Inc(int64(numLeafs)) }
+func (p *peerStats) UpdateBytesServed(numBytes int) { p.bytesServed.Inc(int64(numBytes)) }
+
+func (p *peerStats) Unregister() {
+	for _, name := range p.names {
+		metrics.Unregister(name)
+	}
+}
+
+func NewThrottlerStats(enabled bool) ThrottlerStats {
+	if !enabled {
+		return NewNoopThrottlerStats()
+	}
+	return &throttlerStats{
+		request:          metrics.GetOrRegisterCounter("sync_throttler_request_count", nil),
+		throttledRequest: metrics.GetOrRegisterCounter("sync_throttled_request_count", nil),
+		leafsServed:      metrics.GetOrRegisterCounter("sync_throttler_leafs_served", nil),
+		bytesServed:      metrics.GetOrRegisterCounter("sync_throttler_bytes_served", nil),
+		trackedPeers:     metrics.GetOrRegisterGauge("sync_throttler_tracked_peers", nil),
+	}
+}
+
+// no op implementation
+type noopThrottlerStats struct{}
+
+func NewNoopThrottlerStats() ThrottlerStats {
+	return &noopThrottlerStats{}
+}
+
+func (n *noopThrottlerStats) IncRequest()                    {}
+func (n *noopThrottlerStats) IncThrottledRequest()           {}
+func (n *noopThrottlerStats) UpdateLeafsServed(int)          {}
+func (n *noopThrottlerStats) UpdateBytesServed(int)          {}
+func (n *noopThrottlerStats) UpdateTrackedPeers(int)         {}
+func (n *noopThrottlerStats) PeerStats(ids.NodeID) PeerStats { return NewNoopPeerStats() }
+
+type noopPeerStats struct{}
+
+func NewNoopPeerStats() PeerStats {
+	return &noopPeerStats{}
+}
+
+func (n *noopPeerStats) IncRequest()           {}
+func (n *noopPeerStats) IncThrottledRequest()  {}
+func (n *noopPeerStats) UpdateLeafsServed(int) {}
+func (n *noopPeerStats) UpdateBytesServed(int) {}
+func (n *noopPeerStats) Unregister()           {}
@@ -0,0 +1,225 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Juneo-io/juneogo/codec"
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/Juneo-io/juneogo/utils/timer/mockable"
+
+	"github.com/Juneo-io/jeth/plugin/evm/message"
+	"github.com/Juneo-io/jeth/sync/handlers/stats"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// peerPruneInterval is how often, and after how long being idle, peers are
+// no longer tracked by the Throttler. It must be over a second so that the
+// budgets of pruned peers are full.
+const peerPruneInterval = time.Minute
+
+// maxPeerStats is the maximum number of tracked peers with metrics of their
+// own, so the number of metrics stays bounded. The requests of the other peers
+// are only reported by the aggregate metrics.
+const maxPeerStats = 64
+
+// ThrottlerConfig sets the budgets of the requests served to each peer.
+// A budget of 0 is unlimited.
+type ThrottlerConfig struct {
+	LeafsPerSecond        uint64 // leafs returned in leafs responses
+	BytesPerSecond        uint64 // bytes returned in all responses
+	MaxConcurrentRequests int    // requests handled at once
+}
+
+// Throttler enforces per-peer budgets on the requests served by the handlers,
+// so a burst of syncing peers cannot saturate the node. Rate budgets are token
+// buckets holding one second of budget.
+//
+// Requests received while a peer is out of budget are answered with an empty
+// response before any data is read, so the peer retries with another node
+// without waiting for the request to time out. Otherwise, responses
+// that may be partial (leafs, blocks and receipts) are trimmed to the budget
+// left. The budgets are charged once the response is built, so a peer may
+// overdraw them and must wait for them to refill before being served again.
+//
+// The requests of each tracked peer are reported by the sync_peer_<nodeID>_*
+// metrics, for up to [maxPeerStats] peers, which are unregistered once the peer
+// is pruned.
+//
+// A nil *Throttler serves all requests.
+type Throttler struct {
+	config ThrottlerConfig
+	stats  stats.ThrottlerStats
+	clock  mockable.Clock
+
+	lock       sync.Mutex
+	peers      map[ids.NodeID]*peerBudget
+	peerStats  int // number of tracked peers with metrics of their own
+	lastPruned time.Time
+}
+
+// peerBudget tracks the requests being served to a peer and its budgets.
+type peerBudget struct {
+	active   int
+	leafs    tokenBucket
+	bytes    tokenBucket
+	lastUsed time.Time
+	stats    stats.PeerStats
+	hasStats bool // whether [stats] are registered metrics
+}
+
+func NewThrottler(config ThrottlerConfig, throttlerStats stats.ThrottlerStats) *Throttler {
+	return &Throttler{
+		config: config,
+		stats:  throttlerStats,
+		peers:  make(map[ids.NodeID]*peerBudget),
+	}
+}
+
+// acquire reserves one of the concurrent requests of [nodeID] and returns the
+// leafs and bytes left in its budgets, which are at least 1. It returns false
+// if the peer is at its limit of concurrent requests, has no bytes left, or has
+// no leafs left if [leafsRequest] is true. [release] must be called once a
+// reserved request is handled.
+func (t *Throttler) acquire(nodeID ids.NodeID, leafsRequest bool) (uint64, uint64, bool) {
+	if t == nil {
+		return math.MaxUint64, math.MaxUint64, true
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.clock.Time()
+	t.prune(now)
+	peer, ok := t.peers[nodeID]
+	if !ok {
+		peer = &peerBudget{
+			leafs: newTokenBucket(t.config.LeafsPerSecond, now),
+			bytes: newTokenBucket(t.config.BytesPerSecond, now),
+			stats: stats.NewNoopPeerStats(),
+		}
+		if t.peerStats < maxPeerStats {
+			peer.stats = t.stats.PeerStats(nodeID)
+			peer.hasStats = true
+			t.peerStats++
+		}
+		t.peers[nodeID] = peer
+		t.stats.UpdateTrackedPeers(len(t.peers))
+	}
+	peer.lastUsed = now
+	t.stats.IncRequest()
+	peer.stats.IncRequest()
+
+	leafs, bytes := peer.leafs.available(now), peer.bytes.available(now)
+	if (t.config.MaxConcurrentRequests > 0 && peer.active >= t.config.MaxConcurrentRequests) ||
+		bytes == 0 || (leafsRequest && leafs == 0) {
+		t.stats.IncThrottledRequest()
+		peer.stats.IncThrottledRequest()
+		return 0, 0, false
+	}
+	peer.active++
+	return leafs, bytes, true
+}
+
+// release frees the request of [nodeID] reserved by [acquire] and charges the
+// [leafs] and [bytes] served to its budgets.
+func (t *Throttler) release(nodeID ids.NodeID, leafs int, bytes int) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	peer, ok := t.peers[nodeID]
+	if !ok {
+		// peers with requests in progress are not pruned
+		return
+	}
+	now := t.clock.Time()
+	peer.active--
+	peer.lastUsed = now
+	peer.leafs.take(leafs, now)
+	peer.bytes.take(bytes, now)
+	t.stats.UpdateLeafsServed(leafs)
+	t.stats.UpdateBytesServed(bytes)
+	peer.stats.UpdateLeafsServed(leafs)
+	peer.stats.UpdateBytesServed(bytes)
+}
+
+// throttledResponse returns the encoding of the empty [response] sent to
+// [nodeID] when it is out of budget.
+func throttledResponse(codec codec.Manager, nodeID ids.NodeID, requestID uint32, response interface{}) ([]byte, error) {
+	log.Debug("peer is out of budget, sending empty response", "nodeID", nodeID, "requestID", requestID)
+	responseBytes, err := codec.Marshal(message.Version, response)
+	if err != nil {
+		log.Error("failed to marshal empty response, dropping request", "nodeID", nodeID, "requestID", requestID, "err", err)
+		return nil, nil
+	}
+	return responseBytes, nil
+}
+
+// prune stops tracking the peers which have been idle for [peerPruneInterval],
+// at most once per [peerPruneInterval]. Assumes the lock is held.
+func (t *Throttler) prune(now time.Time) {
+	if now.Sub(t.lastPruned) < peerPruneInterval {
+		return
+	}
+	t.lastPruned = now
+	for nodeID, peer := range t.peers {
+		if peer.active == 0 && now.Sub(peer.lastUsed) >= peerPruneInterval {
+			if peer.hasStats {
+				peer.stats.Unregister()
+				t.peerStats--
+			}
+			delete(t.peers, nodeID)
+		}
+	}
+	t.stats.UpdateTrackedPeers(len(t.peers))
+}
+
+// tokenBucket holds up to one second of a budget of [rate] per second. A rate
+// of 0 is unlimited.
+type tokenBucket struct {
+	rate    uint64
+	tokens  float64 // may be negative if the budget was overdrawn
+	updated time.Time
+}
+
+func newTokenBucket(rate uint64, now time.Time) tokenBucket {
+	return tokenBucket{
+		rate:    rate,
+		tokens:  float64(rate),
+		updated: now,
+	}
+}
+
+// available returns the whole number of tokens left at [now].
+func (b *tokenBucket) available(now time.Time) uint64 {
+	if b.rate == 0 {
+		return math.MaxUint64
+	}
+	b.refill(now)
+	if b.tokens < 1 {
+		return 0
+	}
+	return uint64(b.tokens)
+}
+
+// take removes [n] tokens from the bucket at [now], even if fewer are left.
+func (b *tokenBucket) take(n int, now time.Time) {
+	if b.rate == 0 {
+		return
+	}
+	b.refill(now)
+	b.tokens -= float64(n)
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(b.tokens+float64(b.rate)*elapsed.Seconds(), float64(b.rate))
+		b.updated = now
+	}
+}
@@ -0,0 +1,224 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/plugin/evm/message"
+	"github.com/Juneo-io/jeth/sync/handlers/stats"
+	"github.com/Juneo-io/jeth/sync/syncutils"
+	"github.com/Juneo-io/jeth/trie"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestThrottlerNil(t *testing.T) {
+	require := require.New(t)
+
+	var throttler *Throttler
+	leafsLeft, bytesLeft, ok := throttler.acquire(ids.GenerateTestNodeID(), true)
+	require.True(ok)
+	require.Equal(uint64(math.MaxUint64), leafsLeft)
+	require.Equal(uint64(math.MaxUint64), bytesLeft)
+	throttler.release(ids.GenerateTestNodeID(), 1, 1)
+}
+
+func TestThrottlerMaxConcurrentRequests(t *testing.T) {
+	require := require.New(t)
+
+	throttler := NewThrottler(ThrottlerConfig{MaxConcurrentRequests: 2}, stats.NewNoopThrottlerStats())
+	nodeID, otherNodeID := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+
+	for i := 0; i < 2; i++ {
+		leafsLeft, bytesLeft, ok := throttler.acquire(nodeID, true)
+		require.True(ok)
+		require.Equal(uint64(math.MaxUint64), leafsLeft)
+		require.Equal(uint64(math.MaxUint64), bytesLeft)
+	}
+	_, _, ok := throttler.acquire(nodeID, false)
+	require.False(ok)
+
+	// The limit is per peer.
+	_, _, ok = throttler.acquire(otherNodeID, false)
+	require.True(ok)
+
+	throttler.release(nodeID, 0, 0)
+	_, _, ok = throttler.acquire(nodeID, false)
+	require.True(ok)
+}
+
+func TestThrottlerBudgets(t *testing.T) {
+	require := require.New(t)
+
+	throttler := NewThrottler(ThrottlerConfig{LeafsPerSecond: 100, BytesPerSecond: 1000}, stats.NewNoopThrottlerStats())
+	now := time.Unix(1_000_000, 0)
+	throttler.clock.Set(now)
+	nodeID := ids.GenerateTestNodeID()
+
+	leafsLeft, bytesLeft, ok := throttler.acquire(nodeID, true)
+	require.True(ok)
+	require.Equal(uint64(100), leafsLeft)
+	require.Equal(uint64(1000), bytesLeft)
+	throttler.release(nodeID, 100, 500)
+
+	// Out of leafs, so only requests which are not for leafs are served.
+	_, _, ok = throttler.acquire(nodeID, true)
+	require.False(ok)
+	leafsLeft, bytesLeft, ok = throttler.acquire(nodeID, false)
+	require.True(ok)
+	require.Zero(leafsLeft)
+	require.Equal(uint64(500), bytesLeft)
+
+	// The bytes budget is overdrawn, so no requests are served until it
+	// refills.
+	throttler.release(nodeID, 0, 1000)
+	_, _, ok = throttler.acquire(nodeID, false)
+	require.False(ok)
+
+	throttler.clock.Set(now.Add(time.Second / 2))
+	_, _, ok = throttler.acquire(nodeID, false)
+	require.False(ok)
+
+	throttler.clock.Set(now.Add(time.Second))
+	leafsLeft, bytesLeft, ok = throttler.acquire(nodeID, true)
+	require.True(ok)
+	require.Equal(uint64(100), leafsLeft)
+	require.Equal(uint64(500), bytesLeft)
+	throttler.release(nodeID, 0, 0)
+
+	// Budgets refill up to one second of budget.
+	throttler.clock.Set(now.Add(time.Hour))
+	leafsLeft, bytesLeft, ok = throttler.acquire(nodeID, true)
+	require.True(ok)
+	require.Equal(uint64(100), leafsLeft)
+	require.Equal(uint64(1000), bytesLeft)
+}
+
+func TestThrottlerPrune(t *testing.T) {
+	require := require.New(t)
+
+	throttler := NewThrottler(ThrottlerConfig{BytesPerSecond: 1000}, stats.NewNoopThrottlerStats())
+	now := time.Unix(1_000_000, 0)
+	throttler.clock.Set(now)
+	idleNodeID, activeNodeID := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+
+	_, _, ok := throttler.acquire(idleNodeID, false)
+	require.True(ok)
+	throttler.release(idleNodeID, 0, 1000)
+	_, _, ok = throttler.acquire(activeNodeID, false)
+	require.True(ok)
+
+	// Peers with requests in progress are kept.
+	throttler.clock.Set(now.Add(peerPruneInterval))
+	_, _, ok = throttler.acquire(ids.GenerateTestNodeID(), false)
+	require.True(ok)
+	require.Len(throttler.peers, 2)
+	require.NotContains(throttler.peers, idleNodeID)
+	require.Contains(throttler.peers, activeNodeID)
+}
+
+// testThrottlerStats records the peers with registered metrics
+type testThrottlerStats struct {
+	stats.ThrottlerStats
+	registered map[ids.NodeID]struct{}
+}
+
+func (s *testThrottlerStats) PeerStats(nodeID ids.NodeID) stats.PeerStats {
+	s.registered[nodeID] = struct{}{}
+	return &testPeerStats{
+		PeerStats:  stats.NewNoopPeerStats(),
+		unregister: func() { delete(s.registered, nodeID) },
+	}
+}
+
+type testPeerStats struct {
+	stats.PeerStats
+	unregister func()
+}
+
+func (s *testPeerStats) Unregister() { s.unregister() }
+
+func TestThrottlerPeerStats(t *testing.T) {
+	require := require.New(t)
+
+	throttlerStats := &testThrottlerStats{
+		ThrottlerStats: stats.NewNoopThrottlerStats(),
+		registered:     make(map[ids.NodeID]struct{}),
+	}
+	throttler := NewThrottler(ThrottlerConfig{}, throttlerStats)
+	now := time.Unix(1_000_000, 0)
+	throttler.clock.Set(now)
+
+	// The number of peers with metrics of their own is bounded.
+	for i := 0; i < maxPeerStats+1; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		_, _, ok := throttler.acquire(nodeID, false)
+		require.True(ok)
+		throttler.release(nodeID, 0, 0)
+	}
+	require.Len(throttler.peers, maxPeerStats+1)
+	require.Len(throttlerStats.registered, maxPeerStats)
+
+	// The metrics of pruned peers are unregistered.
+	throttler.clock.Set(now.Add(peerPruneInterval))
+	nodeID := ids.GenerateTestNodeID()
+	_, _, ok := throttler.acquire(nodeID, false)
+	require.True(ok)
+	require.Len(throttler.peers, 1)
+	require.Len(throttlerStats.registered, 1)
+	require.Contains(throttlerStats.registered, nodeID)
+}
+
+func TestLeafsRequestHandlerThrottled(t *testing.T) {
+	require := require.New(t)
+
+	trieDB := trie.NewDatabase(rawdb.NewMemoryDatabase(), nil)
+	root, _, _ := syncutils.GenerateTrie(t, trieDB, 1000, common.HashLength)
+	throttler := NewThrottler(ThrottlerConfig{LeafsPerSecond: 300}, stats.NewNoopThrottlerStats())
+	throttler.clock.Set(time.Unix(1_000_000, 0))
+	handler := NewLeafsRequestHandler(trieDB, nil, message.Codec, stats.NewNoopHandlerStats(), throttler)
+	nodeID := ids.GenerateTestNodeID()
+
+	request := message.LeafsRequest{
+		Root:     root,
+		Start:    bytes.Repeat([]byte{0x00}, common.HashLength),
+		End:      bytes.Repeat([]byte{0xff}, common.HashLength),
+		Limit:    maxLeavesLimit,
+		NodeType: message.StateTrieNode,
+	}
+
+	// The limit is trimmed to the leafs left in the budget.
+	responseBytes, err := handler.OnLeafsRequest(context.Background(), nodeID, 1, request)
+	require.NoError(err)
+	var response message.LeafsResponse
+	_, err = message.Codec.Unmarshal(responseBytes, &response)
+	require.NoError(err)
+	require.Len(response.Keys, 300)
+	require.True(response.More)
+
+	// Requests are answered with an empty response until the budget refills,
+	// so the peer does not wait for them to time out.
+	responseBytes, err = handler.OnLeafsRequest(context.Background(), nodeID, 2, request)
+	require.NoError(err)
+	require.NotNil(responseBytes)
+	response = message.LeafsResponse{}
+	_, err = message.Codec.Unmarshal(responseBytes, &response)
+	require.NoError(err)
+	require.Empty(response.Keys)
+	require.Empty(response.Vals)
+	require.Empty(response.ProofVals)
+
+	// Other peers are still served.
+	responseBytes, err = handler.OnLeafsRequest(context.Background(), ids.GenerateTestNodeID(), 3, request)
+	require.NoError(err)
+	require.NotNil(responseBytes)
+}
@@ -0,0 +1,379 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package statefile implements the file format used to export the chain state
+// at a state sync summary, so that a node can bootstrap from it without peers.
+//
+// A state file is gzip compressed. It starts with a header holding the summary
+// bytes, followed by a sequence of records and an end record. The sha256 hash
+// of all the uncompressed bytes up to the end record is appended last and
+// checked when the end record is read.
+package statefile
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// magic identifies state files.
+	magic = "jeth-state"
+
+	// version is the version of the state file format.
+	version = 0
+
+	// maxFieldSize bounds the size of a record field, so that a corrupted
+	// file cannot cause large allocations.
+	maxFieldSize = 64 * 1024 * 1024
+)
+
+var (
+	errInvalidMagic    = errors.New("not a state file")
+	errChecksumInvalid = errors.New("state file checksum mismatch")
+	errFieldTooLarge   = errors.New("state file field too large")
+
+	ErrUnexpectedChecksum = errors.New("state file checksum is not the expected checksum")
+)
+
+// Kind is the kind of a record.
+type Kind byte
+
+const (
+	endKind Kind = iota
+
+	// BlockKind records hold the RLP encoded summary block or one of its parents.
+	BlockKind
+	// AccountKind records hold an account hash and its RLP encoded account.
+	AccountKind
+	// StorageKind records hold a storage slot hash and its value, and belong
+	// to the account of the last account record.
+	StorageKind
+	// CodeKind records hold a code hash and its code.
+	CodeKind
+	// AtomicKind records hold a key and a value of the atomic trie.
+	AtomicKind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case endKind:
+		return "end"
+	case BlockKind:
+		return "block"
+	case AccountKind:
+		return "account"
+	case StorageKind:
+		return "storage"
+	case CodeKind:
+		return "code"
+	case AtomicKind:
+		return "atomic"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(k))
+	}
+}
+
+// Record is a single entry of a state file. Blocks only have a value.
+type Record struct {
+	Kind  Kind
+	Key   []byte
+	Value []byte
+}
+
+// Writer writes a state file.
+type Writer struct {
+	path   string
+	file   *os.File
+	buf    *bufio.Writer
+	gzip   *gzip.Writer
+	hasher hash.Hash
+	w      io.Writer // Writes to both [gzip] and [hasher]
+
+	scratch [binary.MaxVarintLen64]byte
+}
+
+// Create creates the state file at [path] for the summary [summary].
+func Create(path string, summary []byte) (*Writer, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	buf := bufio.NewWriter(file)
+	gz := gzip.NewWriter(buf)
+	hasher := sha256.New()
+	w := &Writer{
+		path:   path,
+		file:   file,
+		buf:    buf,
+		gzip:   gz,
+		hasher: hasher,
+		w:      io.MultiWriter(gz, hasher),
+	}
+	if err := w.writeHeader(summary); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Writer) writeHeader(summary []byte) error {
+	if _, err := io.WriteString(w.w, magic); err != nil {
+		return err
+	}
+	if _, err := w.w.Write([]byte{version}); err != nil {
+		return err
+	}
+	return w.writeField(summary)
+}
+
+func (w *Writer) writeField(field []byte) error {
+	n := binary.PutUvarint(w.scratch[:], uint64(len(field)))
+	if _, err := w.w.Write(w.scratch[:n]); err != nil {
+		return err
+	}
+	_, err := w.w.Write(field)
+	return err
+}
+
+func (w *Writer) writeRecord(kind Kind, key []byte, value []byte) error {
+	if _, err := w.w.Write([]byte{byte(kind)}); err != nil {
+		return err
+	}
+	if err := w.writeField(key); err != nil {
+		return err
+	}
+	return w.writeField(value)
+}
+
+// WriteBlock writes the RLP encoded block [block].
+func (w *Writer) WriteBlock(block []byte) error {
+	return w.writeRecord(BlockKind, nil, block)
+}
+
+// WriteAccount writes the RLP encoded account [account] at [hash].
+func (w *Writer) WriteAccount(hash common.Hash, account []byte) error {
+	return w.writeRecord(AccountKind, hash[:], account)
+}
+
+// WriteStorage writes the storage slot [hash] of the last written account.
+func (w *Writer) WriteStorage(hash common.Hash, value []byte) error {
+	return w.writeRecord(StorageKind, hash[:], value)
+}
+
+// WriteCode writes the contract code [code] with hash [hash].
+func (w *Writer) WriteCode(hash common.Hash, code []byte) error {
+	return w.writeRecord(CodeKind, hash[:], code)
+}
+
+// WriteAtomic writes the atomic trie leaf [key]/[value].
+func (w *Writer) WriteAtomic(key []byte, value []byte) error {
+	return w.writeRecord(AtomicKind, key, value)
+}
+
+// Close writes the end record and the checksum, and closes the file. It
+// returns the checksum of the file.
+func (w *Writer) Close() (common.Hash, error) {
+	defer w.file.Close()
+
+	if err := w.writeRecord(endKind, nil, nil); err != nil {
+		return common.Hash{}, err
+	}
+	checksum := common.BytesToHash(w.hasher.Sum(nil))
+	if _, err := w.gzip.Write(checksum[:]); err != nil {
+		return common.Hash{}, err
+	}
+	if err := w.gzip.Close(); err != nil {
+		return common.Hash{}, err
+	}
+	if err := w.buf.Flush(); err != nil {
+		return common.Hash{}, err
+	}
+	if err := w.file.Sync(); err != nil {
+		return common.Hash{}, err
+	}
+	return checksum, w.file.Close()
+}
+
+// Abort closes and removes the state file, after a failure to write it.
+func (w *Writer) Abort() {
+	w.file.Close()
+	os.Remove(w.path)
+}
+
+// hashReader hashes all the bytes read from [r].
+type hashReader struct {
+	r *bufio.Reader
+	h hash.Hash
+}
+
+func (hr *hashReader) Read(p []byte) (int, error) {
+	n, err := hr.r.Read(p)
+	hr.h.Write(p[:n])
+	return n, err
+}
+
+func (hr *hashReader) ReadByte() (byte, error) {
+	b, err := hr.r.ReadByte()
+	if err == nil {
+		hr.h.Write([]byte{b})
+	}
+	return b, err
+}
+
+// Reader reads a state file.
+type Reader struct {
+	file     *os.File
+	gzip     *gzip.Reader
+	r        *hashReader
+	summary  []byte
+	checksum common.Hash
+	done     bool
+}
+
+// Open opens the state file at [path] and reads its header.
+func Open(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := newReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func newReader(file *os.File) (*Reader, error) {
+	gz, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidMagic, err)
+	}
+	r := &Reader{
+		file: file,
+		gzip: gz,
+		r:    &hashReader{r: bufio.NewReader(gz), h: sha256.New()},
+	}
+	header := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidMagic, err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, errInvalidMagic
+	}
+	if header[len(magic)] != version {
+		return nil, fmt.Errorf("unsupported state file version %d", header[len(magic)])
+	}
+	if r.summary, err = r.readField(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Summary returns the summary bytes of the state file.
+func (r *Reader) Summary() []byte {
+	return r.summary
+}
+
+func (r *Reader) readField() ([]byte, error) {
+	size, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxFieldSize {
+		return nil, errFieldTooLarge
+	}
+	field := make([]byte, size)
+	if _, err := io.ReadFull(r.r, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// Next returns the next record of the state file. It returns [io.EOF] once
+// the end record is read and the checksum of the file is verified.
+func (r *Reader) Next() (Record, error) {
+	if r.done {
+		return Record{}, io.EOF
+	}
+	kind, err := r.r.ReadByte()
+	if err != nil {
+		return Record{}, unexpectedEOF(err)
+	}
+	record := Record{Kind: Kind(kind)}
+	if record.Key, err = r.readField(); err != nil {
+		return Record{}, unexpectedEOF(err)
+	}
+	if record.Value, err = r.readField(); err != nil {
+		return Record{}, unexpectedEOF(err)
+	}
+	if record.Kind != endKind {
+		return record, nil
+	}
+
+	// The checksum itself is not hashed, so it's read from the underlying
+	// reader.
+	expected := r.r.h.Sum(nil)
+	checksum := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(r.r.r, checksum); err != nil {
+		return Record{}, unexpectedEOF(err)
+	}
+	if common.BytesToHash(checksum) != common.BytesToHash(expected) {
+		return Record{}, errChecksumInvalid
+	}
+	r.checksum = common.BytesToHash(checksum)
+	r.done = true
+	return Record{}, io.EOF
+}
+
+// Checksum returns the verified checksum of the state file, once [Next]
+// returned [io.EOF].
+func (r *Reader) Checksum() common.Hash {
+	return r.checksum
+}
+
+// Close closes the state file.
+func (r *Reader) Close() error {
+	r.gzip.Close()
+	return r.file.Close()
+}
+
+// Verify reads the state file at [path] to the end and verifies its checksum
+// is [checksum].
+func Verify(path string, checksum common.Hash) error {
+	r, err := Open(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for {
+		if _, err := r.Next(); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	if r.Checksum() != checksum {
+		return fmt.Errorf("%w: (%s != %s)", ErrUnexpectedChecksum, r.Checksum(), checksum)
+	}
+	return nil
+}
+
+// unexpectedEOF converts [io.EOF] to [io.ErrUnexpectedEOF], since the file
+// must end with an end record.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
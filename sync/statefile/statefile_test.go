@@ -0,0 +1,121 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statefile
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, path string, records []Record) common.Hash {
+	w, err := Create(path, []byte("summary"))
+	require.NoError(t, err)
+	for _, record := range records {
+		switch record.Kind {
+		case BlockKind:
+			require.NoError(t, w.WriteBlock(record.Value))
+		case AccountKind:
+			require.NoError(t, w.WriteAccount(common.BytesToHash(record.Key), record.Value))
+		case StorageKind:
+			require.NoError(t, w.WriteStorage(common.BytesToHash(record.Key), record.Value))
+		case CodeKind:
+			require.NoError(t, w.WriteCode(common.BytesToHash(record.Key), record.Value))
+		case AtomicKind:
+			require.NoError(t, w.WriteAtomic(record.Key, record.Value))
+		}
+	}
+	checksum, err := w.Close()
+	require.NoError(t, err)
+	return checksum
+}
+
+func TestStateFileRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	records := []Record{
+		{Kind: BlockKind, Key: []byte{}, Value: []byte{0xc0}},
+		{Kind: AccountKind, Key: common.Hash{1}.Bytes(), Value: []byte("account")},
+		{Kind: StorageKind, Key: common.Hash{2}.Bytes(), Value: []byte("slot")},
+		{Kind: CodeKind, Key: common.Hash{3}.Bytes(), Value: make([]byte, 24_576)},
+		{Kind: AtomicKind, Key: []byte("atomic key"), Value: []byte{}},
+	}
+	path := filepath.Join(t.TempDir(), "state")
+	checksum := writeTestFile(t, path, records)
+	require.NotEqual(common.Hash{}, checksum)
+	require.NoError(Verify(path, checksum))
+	require.ErrorIs(Verify(path, common.Hash{1}), ErrUnexpectedChecksum)
+
+	r, err := Open(path)
+	require.NoError(err)
+	defer r.Close()
+	require.Equal([]byte("summary"), r.Summary())
+	for _, expected := range records {
+		record, err := r.Next()
+		require.NoError(err)
+		require.Equal(expected, record)
+	}
+	_, err = r.Next()
+	require.ErrorIs(err, io.EOF)
+	_, err = r.Next()
+	require.ErrorIs(err, io.EOF)
+}
+
+func TestStateFileCorrupted(t *testing.T) {
+	records := []Record{
+		{Kind: AccountKind, Key: common.Hash{1}.Bytes(), Value: []byte("account")},
+		{Kind: AccountKind, Key: common.Hash{2}.Bytes(), Value: []byte("account")},
+	}
+	tests := map[string]struct {
+		rewrite     func(t *testing.T, path string)
+		expectedErr error
+	}{
+		"bad checksum": {
+			rewrite: func(t *testing.T, path string) {
+				w, err := Create(path, []byte("summary"))
+				require.NoError(t, err)
+				require.NoError(t, w.WriteAccount(common.Hash{1}, []byte("account")))
+				// Swap the hasher so that the checksum doesn't cover the last record.
+				w.w = w.gzip
+				require.NoError(t, w.WriteAccount(common.Hash{2}, []byte("account")))
+				_, err = w.Close()
+				require.NoError(t, err)
+			},
+			expectedErr: errChecksumInvalid,
+		},
+		"truncated": {
+			rewrite: func(t *testing.T, path string) {
+				w, err := Create(path, []byte("summary"))
+				require.NoError(t, err)
+				for _, record := range records {
+					require.NoError(t, w.WriteAccount(common.BytesToHash(record.Key), record.Value))
+				}
+				// Close the file without the end record.
+				require.NoError(t, w.gzip.Close())
+				require.NoError(t, w.buf.Flush())
+				require.NoError(t, w.file.Close())
+			},
+			expectedErr: io.ErrUnexpectedEOF,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state")
+			test.rewrite(t, path)
+
+			require.ErrorIs(t, Verify(path, common.Hash{}), test.expectedErr)
+		})
+	}
+}
+
+func TestStateFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state")
+	require.NoError(t, os.WriteFile(path, []byte("not a state file"), 0o600))
+	_, err := Open(path)
+	require.ErrorIs(t, err, errInvalidMagic)
+}
@@ -222,6 +222,14 @@ func (c *codeSyncer) addCode(codeHashes []common.Hash) error {
 	return c.addHashesToQueue(selectedCodeHashes)
 }
 
+// pending returns the number of code hashes that have not been fetched yet.
+func (c *codeSyncer) pending() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.outstandingCodeHashes.Len()
+}
+
 // notifyAccountTrieCompleted notifies the code syncer that there will be no more incoming
 // code hashes from syncing the account trie, so it only needs to compelete its outstanding
 // work.
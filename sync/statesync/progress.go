@@ -0,0 +1,38 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import "github.com/ethereum/go-ethereum/common"
+
+// Progress is a snapshot of the progress of syncing the EVM state. Leafs are
+// counted from the start of the sync by this node, so leafs restored when
+// resuming a sync are not included.
+type Progress struct {
+	Root         common.Hash `json:"root"`
+	MainTrieDone bool        `json:"mainTrieDone"`
+
+	LeafsFetched   uint64  `json:"leafsFetched"`
+	LeafsPerSecond float64 `json:"leafsPerSecond"`
+
+	// TriesSynced includes the main trie. TriesRemaining is only known once
+	// the main trie is synced.
+	TriesSynced     int            `json:"triesSynced"`
+	TriesRemaining  int            `json:"triesRemaining"`
+	TriesInProgress []TrieProgress `json:"triesInProgress"`
+
+	CodeHashesPending int `json:"codeHashesPending"`
+
+	// ETASeconds is the estimated time left to sync the tries, or 0 until
+	// the first estimate is made.
+	ETASeconds uint64 `json:"etaSeconds"`
+}
+
+// TrieProgress is a snapshot of the progress of a trie being synced.
+type TrieProgress struct {
+	Root              common.Hash `json:"root"`
+	Account           common.Hash `json:"account"` // empty for the main trie
+	LeafsFetched      uint64      `json:"leafsFetched"`
+	Segments          int         `json:"segments"`
+	SegmentsRemaining int         `json:"segmentsRemaining"`
+}
@@ -0,0 +1,249 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/core/types"
+	"github.com/Juneo-io/jeth/sync/statefile"
+	"github.com/Juneo-io/jeth/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	errAccountsOutOfOrder = errors.New("state file accounts out of order")
+	errStorageOutOfOrder  = errors.New("state file storage slots out of order")
+	errUnexpectedStorage  = errors.New("state file storage slot without account storage")
+	errUnexpectedCode     = errors.New("state file code not referenced by any account")
+)
+
+// ExportState writes the accounts, storage slots and code of the EVM state at
+// [root] to [w]. The storage slots of each account directly follow it and the
+// code of a contract follows the first account referencing it.
+func ExportState(w *statefile.Writer, db ethdb.Database, trieDB *trie.Database, root common.Hash) error {
+	accTrie, err := trie.NewStateTrie(trie.StateTrieID(root), trieDB)
+	if err != nil {
+		return err
+	}
+	nodeIt, err := accTrie.NodeIterator(nil)
+	if err != nil {
+		return err
+	}
+	exportedCode := make(map[common.Hash]struct{})
+	it := trie.NewIterator(nodeIt)
+	for it.Next() {
+		accHash := common.BytesToHash(it.Key)
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return fmt.Errorf("could not decode account %s: %w", accHash, err)
+		}
+		if err := w.WriteAccount(accHash, it.Value); err != nil {
+			return err
+		}
+		if acc.Root != (common.Hash{}) && acc.Root != types.EmptyRootHash {
+			if err := exportStorage(w, trieDB, root, accHash, acc.Root); err != nil {
+				return fmt.Errorf("could not export storage of account %s: %w", accHash, err)
+			}
+		}
+		codeHash := common.BytesToHash(acc.CodeHash)
+		if codeHash == (common.Hash{}) || codeHash == types.EmptyCodeHash {
+			continue
+		}
+		if _, ok := exportedCode[codeHash]; ok {
+			continue
+		}
+		code := rawdb.ReadCode(db, codeHash)
+		if len(code) == 0 {
+			return fmt.Errorf("missing code %s of account %s", codeHash, accHash)
+		}
+		if err := w.WriteCode(codeHash, code); err != nil {
+			return err
+		}
+		exportedCode[codeHash] = struct{}{}
+	}
+	return it.Err
+}
+
+// exportStorage writes the storage slots of the storage trie [storageRoot] of
+// the account [accHash] to [w].
+func exportStorage(w *statefile.Writer, trieDB *trie.Database, root, accHash, storageRoot common.Hash) error {
+	storageTrie, err := trie.NewStateTrie(trie.StorageTrieID(root, accHash, storageRoot), trieDB)
+	if err != nil {
+		return err
+	}
+	nodeIt, err := storageTrie.NodeIterator(nil)
+	if err != nil {
+		return err
+	}
+	it := trie.NewIterator(nodeIt)
+	for it.Next() {
+		if err := w.WriteStorage(common.BytesToHash(it.Key), it.Value); err != nil {
+			return err
+		}
+	}
+	return it.Err
+}
+
+// StateImporter writes the EVM state read from a state file to the database,
+// as the trie nodes and the snapshot of the state. The records must be added
+// in the order written by [ExportState]. The roots of the storage tries are
+// verified as they are completed and the root of the account trie is verified
+// by [Finish].
+type StateImporter struct {
+	scheme    string
+	batch     ethdb.Batch
+	batchSize int
+
+	accountTrie *trie.StackTrie
+	accounts    int
+
+	// The last added account and its storage trie, if it has storage.
+	account     common.Hash
+	accountRoot common.Hash
+	storageTrie *trie.StackTrie
+	lastSlot    []byte
+
+	// code maps the code hashes referenced by the accounts added so far to
+	// whether their code was imported.
+	code map[common.Hash]bool
+}
+
+// NewStateImporter returns a StateImporter writing the trie nodes to [db] with
+// [scheme], in batches of [batchSize] bytes.
+func NewStateImporter(db ethdb.Database, scheme string, batchSize int) *StateImporter {
+	if scheme == "" {
+		scheme = rawdb.HashScheme
+	}
+	i := &StateImporter{
+		scheme:    scheme,
+		batch:     db.NewBatch(),
+		batchSize: batchSize,
+		code:      make(map[common.Hash]bool),
+	}
+	i.accountTrie = trie.NewStackTrie(i.writeNode)
+	return i
+}
+
+func (i *StateImporter) writeNode(owner common.Hash, path []byte, hash common.Hash, blob []byte) {
+	rawdb.WriteTrieNode(i.batch, owner, path, hash, blob, i.scheme)
+}
+
+// AddAccount adds the RLP encoded account [value] at [hash].
+func (i *StateImporter) AddAccount(hash common.Hash, value []byte) error {
+	if i.accounts > 0 && bytes.Compare(hash[:], i.account[:]) <= 0 {
+		return fmt.Errorf("%w: %s after %s", errAccountsOutOfOrder, hash, i.account)
+	}
+	if err := i.finishStorage(); err != nil {
+		return err
+	}
+	var acc types.StateAccount
+	if err := rlp.DecodeBytes(value, &acc); err != nil {
+		return fmt.Errorf("could not decode account %s: %w", hash, err)
+	}
+	if err := i.accountTrie.Update(hash[:], value); err != nil {
+		return err
+	}
+	writeAccountSnapshot(i.batch, hash, acc)
+	i.account = hash
+	i.accounts++
+
+	if acc.Root != (common.Hash{}) && acc.Root != types.EmptyRootHash {
+		i.accountRoot = acc.Root
+		i.storageTrie = trie.NewStackTrieWithOwner(i.writeNode, hash)
+		i.lastSlot = nil
+	}
+	codeHash := common.BytesToHash(acc.CodeHash)
+	if codeHash != (common.Hash{}) && codeHash != types.EmptyCodeHash {
+		if _, ok := i.code[codeHash]; !ok {
+			i.code[codeHash] = false
+		}
+	}
+	return i.maybeFlush()
+}
+
+// AddStorage adds the storage slot [key] with [value] to the last added account.
+func (i *StateImporter) AddStorage(key common.Hash, value []byte) error {
+	if i.storageTrie == nil {
+		return fmt.Errorf("%w: %s", errUnexpectedStorage, key)
+	}
+	if i.lastSlot != nil && bytes.Compare(key[:], i.lastSlot) <= 0 {
+		return fmt.Errorf("%w: %s of account %s", errStorageOutOfOrder, key, i.account)
+	}
+	if err := i.storageTrie.Update(key[:], value); err != nil {
+		return err
+	}
+	rawdb.WriteStorageSnapshot(i.batch, i.account, key, value)
+	i.lastSlot = key[:]
+	return i.maybeFlush()
+}
+
+// AddCode adds the contract code [code] with hash [hash]. The code must be
+// referenced by an account added before it.
+func (i *StateImporter) AddCode(hash common.Hash, code []byte) error {
+	if _, ok := i.code[hash]; !ok {
+		return fmt.Errorf("%w: %s", errUnexpectedCode, hash)
+	}
+	if actual := crypto.Keccak256Hash(code); actual != hash {
+		return fmt.Errorf("code hash mismatch: expected %s, got %s", hash, actual)
+	}
+	rawdb.WriteCode(i.batch, hash, code)
+	i.code[hash] = true
+	return i.maybeFlush()
+}
+
+// Finish verifies the imported state has the root [root] and includes all the
+// code it references, then writes the remaining data to the database.
+func (i *StateImporter) Finish(root common.Hash) error {
+	if err := i.finishStorage(); err != nil {
+		return err
+	}
+	for hash, imported := range i.code {
+		if !imported {
+			return fmt.Errorf("missing code %s", hash)
+		}
+	}
+	actual, err := i.accountTrie.Commit()
+	if err != nil {
+		return err
+	}
+	if actual != root {
+		return fmt.Errorf("state root mismatch: expected %s, got %s", root, actual)
+	}
+	return i.batch.Write()
+}
+
+// finishStorage commits the storage trie of the last added account and
+// verifies its root.
+func (i *StateImporter) finishStorage() error {
+	if i.storageTrie == nil {
+		return nil
+	}
+	actual, err := i.storageTrie.Commit()
+	if err != nil {
+		return err
+	}
+	if actual != i.accountRoot {
+		return fmt.Errorf("storage root mismatch for account %s: expected %s, got %s", i.account, i.accountRoot, actual)
+	}
+	i.storageTrie = nil
+	return nil
+}
+
+func (i *StateImporter) maybeFlush() error {
+	if i.batch.ValueSize() < i.batchSize {
+		return nil
+	}
+	if err := i.batch.Write(); err != nil {
+		return err
+	}
+	i.batch.Reset()
+	return nil
+}
@@ -0,0 +1,153 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/Juneo-io/jeth/core/rawdb"
+	"github.com/Juneo-io/jeth/sync/statefile"
+	"github.com/Juneo-io/jeth/sync/syncutils"
+	"github.com/Juneo-io/jeth/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/stretchr/testify/require"
+)
+
+// exportStateFile exports the state at [root] to a new state file and returns
+// its path.
+func exportStateFile(t *testing.T, serverDB ethdb.Database, serverTrieDB *trie.Database, root common.Hash) string {
+	path := filepath.Join(t.TempDir(), "state")
+	w, err := statefile.Create(path, nil)
+	require.NoError(t, err)
+	require.NoError(t, ExportState(w, serverDB, serverTrieDB, root))
+	_, err = w.Close()
+	require.NoError(t, err)
+	return path
+}
+
+// importStateFile imports the state file at [path] to [clientDB] and verifies
+// it against [root].
+func importStateFile(t *testing.T, path string, clientDB ethdb.Database, scheme string, root common.Hash) error {
+	r, err := statefile.Open(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	importer := NewStateImporter(clientDB, scheme, 1000)
+	for {
+		record, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		switch record.Kind {
+		case statefile.AccountKind:
+			err = importer.AddAccount(common.BytesToHash(record.Key), record.Value)
+		case statefile.StorageKind:
+			err = importer.AddStorage(common.BytesToHash(record.Key), record.Value)
+		case statefile.CodeKind:
+			err = importer.AddCode(common.BytesToHash(record.Key), record.Value)
+		default:
+			t.Fatalf("unexpected record kind %s", record.Kind)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return importer.Finish(root)
+}
+
+func TestStateFile(t *testing.T) {
+	tests := map[string]struct {
+		fill func(t *testing.T, serverDB ethdb.Database, serverTrieDB *trie.Database) common.Hash
+	}{
+		"accounts with code and storage": {
+			fill: func(t *testing.T, serverDB ethdb.Database, serverTrieDB *trie.Database) common.Hash {
+				return fillAccountsWithStorage(t, serverDB, serverTrieDB, common.Hash{}, 250)
+			},
+		},
+		"accounts with overlapping storage": {
+			fill: func(t *testing.T, _ ethdb.Database, serverTrieDB *trie.Database) common.Hash {
+				root, _ := FillAccountsWithOverlappingStorage(t, serverTrieDB, common.Hash{}, 250, 3)
+				return root
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			serverDB := rawdb.NewMemoryDatabase()
+			serverTrieDB := trie.NewDatabase(serverDB, nil)
+			root := test.fill(t, serverDB, serverTrieDB)
+			path := exportStateFile(t, serverDB, serverTrieDB, root)
+
+			clientDB := rawdb.NewMemoryDatabase()
+			require.NoError(t, importStateFile(t, path, clientDB, rawdb.HashScheme, root))
+			assertDBConsistency(t, root, clientDB, serverTrieDB, trie.NewDatabase(clientDB, nil))
+
+			pathDB := rawdb.NewMemoryDatabase()
+			require.NoError(t, importStateFile(t, path, pathDB, rawdb.PathScheme, root))
+			require.Equal(t, rawdb.PathScheme, rawdb.ReadStateScheme(pathDB))
+		})
+	}
+}
+
+func TestStateFileRootMismatch(t *testing.T) {
+	serverDB := rawdb.NewMemoryDatabase()
+	serverTrieDB := trie.NewDatabase(serverDB, nil)
+	root := fillAccountsWithStorage(t, serverDB, serverTrieDB, common.Hash{}, 10)
+	path := exportStateFile(t, serverDB, serverTrieDB, root)
+
+	err := importStateFile(t, path, rawdb.NewMemoryDatabase(), rawdb.HashScheme, common.Hash{1})
+	require.ErrorContains(t, err, "state root mismatch")
+}
+
+func TestStateFileMissingNode(t *testing.T) {
+	require := require.New(t)
+
+	serverDB := rawdb.NewMemoryDatabase()
+	serverTrieDB := trie.NewDatabase(serverDB, nil)
+	root := fillAccountsWithStorage(t, serverDB, serverTrieDB, common.Hash{}, 10)
+
+	// Delete some nodes of the account trie, as pruning the state at [root]
+	// would, and read them from a new trie database so they are not cached.
+	tr, err := trie.New(trie.TrieID(root), serverTrieDB)
+	require.NoError(err)
+	syncutils.CorruptTrie(t, serverDB, tr, 2)
+
+	w, err := statefile.Create(filepath.Join(t.TempDir(), "state"), nil)
+	require.NoError(err)
+	defer w.Abort()
+	err = ExportState(w, serverDB, trie.NewDatabase(serverDB, nil), root)
+	var missingNodeErr *trie.MissingNodeError
+	require.ErrorAs(err, &missingNodeErr)
+}
+
+func TestStateImporterInvalidRecords(t *testing.T) {
+	require := require.New(t)
+
+	serverDB := rawdb.NewMemoryDatabase()
+	serverTrieDB := trie.NewDatabase(serverDB, nil)
+	root := fillAccountsWithStorage(t, serverDB, serverTrieDB, common.Hash{}, 2)
+	path := exportStateFile(t, serverDB, serverTrieDB, root)
+
+	r, err := statefile.Open(path)
+	require.NoError(err)
+	defer r.Close()
+	account, err := r.Next()
+	require.NoError(err)
+	require.Equal(statefile.AccountKind, account.Kind)
+	slot, err := r.Next()
+	require.NoError(err)
+	require.Equal(statefile.StorageKind, slot.Kind)
+
+	importer := NewStateImporter(rawdb.NewMemoryDatabase(), rawdb.HashScheme, 1000)
+	require.ErrorIs(importer.AddStorage(common.BytesToHash(slot.Key), slot.Value), errUnexpectedStorage)
+	require.ErrorIs(importer.AddCode(common.Hash{1}, []byte{1}), errUnexpectedCode)
+	require.NoError(importer.AddAccount(common.BytesToHash(account.Key), account.Value))
+	require.ErrorIs(importer.AddAccount(common.BytesToHash(account.Key), account.Value), errAccountsOutOfOrder)
+	require.NoError(importer.AddStorage(common.BytesToHash(slot.Key), slot.Value))
+	require.ErrorIs(importer.AddStorage(common.BytesToHash(slot.Key), slot.Value), errStorageOutOfOrder)
+}
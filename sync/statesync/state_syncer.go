@@ -4,10 +4,13 @@
 package statesync
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
+	"github.com/Juneo-io/jeth/core/rawdb"
 	"github.com/Juneo-io/jeth/core/state/snapshot"
 	syncclient "github.com/Juneo-io/jeth/sync/client"
 	"github.com/Juneo-io/jeth/trie"
@@ -31,12 +34,14 @@ type StateSyncerConfig struct {
 	MaxOutstandingCodeHashes int    // Maximum number of code hashes in the code syncer queue
 	NumCodeFetchingWorkers   int    // Number of code syncing threads
 	RequestSize              uint16 // Number of leafs to request from a peer at a time
+	Scheme                   string // Scheme used to store the synced trie nodes, defaults to the hash scheme
 }
 
 // stateSync keeps the state of the entire state sync operation.
 type stateSync struct {
 	db        ethdb.Database    // database we are syncing
 	root      common.Hash       // root of the EVM state we are syncing to
+	scheme    string            // scheme used to store the synced trie nodes
 	trieDB    *trie.Database    // trieDB on top of db we are syncing. used to restore any existing tries.
 	snapshot  snapshot.Snapshot // used to access the database we are syncing as a snapshot.
 	batchSize int               // write batches when they reach this size
@@ -62,11 +67,16 @@ type stateSync struct {
 }
 
 func NewStateSyncer(config *StateSyncerConfig) (*stateSync, error) {
+	scheme := config.Scheme
+	if scheme == "" {
+		scheme = rawdb.HashScheme
+	}
 	ss := &stateSync{
 		batchSize:       config.BatchSize,
 		db:              config.DB,
 		client:          config.Client,
 		root:            config.Root,
+		scheme:          scheme,
 		trieDB:          trie.NewDatabase(config.DB, nil),
 		snapshot:        snapshot.NewDiskLayer(config.DB),
 		stats:           newTrieSyncStats(),
@@ -98,7 +108,7 @@ func NewStateSyncer(config *StateSyncerConfig) (*stateSync, error) {
 
 	// create a trieToSync for the main trie and mark it as in progress.
 	var err error
-	ss.mainTrie, err = NewTrieToSync(ss, ss.root, common.Hash{}, NewMainTrieTask(ss))
+	ss.mainTrie, err = NewTrieToSync(ss, ss.root, nil, NewMainTrieTask(ss))
 	if err != nil {
 		return nil, err
 	}
@@ -175,12 +185,10 @@ func (t *stateSync) storageTrieProducer(ctx context.Context) error {
 				return ctx.Err()
 			}
 
-			// Arbitrarily use the first account for making requests to the server.
+			// create a trieToSync for the storage trie and mark it as in progress.
 			// Note: getNextTrie guarantees that if a non-nil storage root is returned, then the
 			// slice of account hashes is non-empty.
-			syncAccount := accounts[0]
-			// create a trieToSync for the storage trie and mark it as in progress.
-			storageTrie, err := NewTrieToSync(t, root, syncAccount, NewStorageTrieTask(t, root, accounts))
+			storageTrie, err := NewTrieToSync(t, root, accounts, NewStorageTrieTask(t, root, accounts))
 			if err != nil {
 				return err
 			}
@@ -225,6 +233,30 @@ func (t *stateSync) Start(ctx context.Context) error {
 
 func (t *stateSync) Done() <-chan error { return t.done }
 
+// Progress returns a snapshot of the progress of the sync.
+func (t *stateSync) Progress() Progress {
+	// Copy the tries in progress, so the lock isn't held while reading them.
+	t.lock.RLock()
+	tries := make([]TrieProgress, 0, len(t.triesInProgress))
+	for _, trie := range t.triesInProgress {
+		tries = append(tries, trie.progress())
+	}
+	t.lock.RUnlock()
+	sort.Slice(tries, func(i, j int) bool {
+		return bytes.Compare(tries[i].Root[:], tries[j].Root[:]) < 0
+	})
+
+	progress := t.stats.progress(tries)
+	progress.Root = t.root
+	select {
+	case <-t.mainTrieDone:
+		progress.MainTrieDone = true
+	default:
+	}
+	progress.CodeHashesPending = t.codeSyncer.pending()
+	return progress
+}
+
 // addTrieInProgress tracks the root as being currently synced.
 func (t *stateSync) addTrieInProgress(root common.Hash, trie *trieToSync) {
 	t.lock.Lock()
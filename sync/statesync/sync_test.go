@@ -22,6 +22,7 @@ import (
 	handlerstats "github.com/Juneo-io/jeth/sync/handlers/stats"
 	"github.com/Juneo-io/jeth/sync/syncutils"
 	"github.com/Juneo-io/jeth/trie"
+	"github.com/Juneo-io/jeth/trie/triedb/pathdb"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -48,8 +49,8 @@ func testSync(t *testing.T, test syncTest) {
 		ctx = test.ctx
 	}
 	clientDB, serverDB, serverTrieDB, root := test.prepareForTest(t)
-	leafsRequestHandler := handlers.NewLeafsRequestHandler(serverTrieDB, nil, message.Codec, handlerstats.NewNoopHandlerStats())
-	codeRequestHandler := handlers.NewCodeRequestHandler(serverDB, message.Codec, handlerstats.NewNoopHandlerStats())
+	leafsRequestHandler := handlers.NewLeafsRequestHandler(serverTrieDB, nil, message.Codec, handlerstats.NewNoopHandlerStats(), nil)
+	codeRequestHandler := handlers.NewCodeRequestHandler(serverDB, message.Codec, handlerstats.NewNoopHandlerStats(), nil)
 	mockClient := statesyncclient.NewMockClient(message.Codec, leafsRequestHandler, codeRequestHandler, nil)
 	// Set intercept functions for the mock client
 	mockClient.GetLeafsIntercept = test.GetLeafsIntercept
@@ -229,6 +230,157 @@ func TestCancelSync(t *testing.T) {
 	})
 }
 
+func TestSyncPathScheme(t *testing.T) {
+	serverDB := rawdb.NewMemoryDatabase()
+	serverTrieDB := trie.NewDatabase(serverDB, nil)
+	root, _ := FillAccountsWithOverlappingStorage(t, serverTrieDB, common.Hash{}, 250, 3)
+
+	leafsRequestHandler := handlers.NewLeafsRequestHandler(serverTrieDB, nil, message.Codec, handlerstats.NewNoopHandlerStats(), nil)
+	codeRequestHandler := handlers.NewCodeRequestHandler(serverDB, message.Codec, handlerstats.NewNoopHandlerStats(), nil)
+	mockClient := statesyncclient.NewMockClient(message.Codec, leafsRequestHandler, codeRequestHandler, nil)
+
+	clientDB := rawdb.NewMemoryDatabase()
+	s, err := NewStateSyncer(&StateSyncerConfig{
+		Client:                   mockClient,
+		Root:                     root,
+		DB:                       clientDB,
+		BatchSize:                1000,
+		NumCodeFetchingWorkers:   DefaultNumCodeFetchingWorkers,
+		MaxOutstandingCodeHashes: DefaultMaxOutstandingCodeHashes,
+		RequestSize:              1024,
+		Scheme:                   rawdb.PathScheme,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Start(context.Background())
+	waitFor(t, s.Done(), nil, testSyncTimeout)
+	assert.Equal(t, rawdb.PathScheme, rawdb.ReadStateScheme(clientDB))
+
+	// The synced state must be readable with the path scheme, including the
+	// storage tries shared by several accounts.
+	clientTrieDB := trie.NewDatabase(clientDB, &trie.Config{PathDB: pathdb.Defaults})
+	serverTrie, err := trie.New(trie.StateTrieID(root), serverTrieDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientTrie, err := trie.New(trie.StateTrieID(root), clientTrieDB)
+	if err != nil {
+		t.Fatalf("error opening synced account trie: %v", err)
+	}
+	assertTriesEqual(t, serverTrie, clientTrie, func(key, val []byte) {
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(val, &acc); err != nil {
+			t.Fatal(err)
+		}
+		if acc.Root == types.EmptyRootHash {
+			return
+		}
+		serverStorageTrie, err := trie.New(trie.TrieID(acc.Root), serverTrieDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clientStorageTrie, err := trie.New(trie.StorageTrieID(root, common.BytesToHash(key), acc.Root), clientTrieDB)
+		if err != nil {
+			t.Fatalf("error opening synced storage trie of account %x: %v", key, err)
+		}
+		assertTriesEqual(t, serverStorageTrie, clientStorageTrie, nil)
+	})
+}
+
+func TestSyncProgress(t *testing.T) {
+	serverDB := rawdb.NewMemoryDatabase()
+	serverTrieDB := trie.NewDatabase(serverDB, nil)
+	root, _ := syncutils.FillAccounts(t, serverTrieDB, common.Hash{}, 1000, func(t *testing.T, i int, account types.StateAccount) types.StateAccount {
+		if i%100 == 0 {
+			account.Root, _, _ = syncutils.GenerateTrie(t, serverTrieDB, 16, common.HashLength)
+		}
+		return account
+	})
+
+	leafsRequestHandler := handlers.NewLeafsRequestHandler(serverTrieDB, nil, message.Codec, handlerstats.NewNoopHandlerStats(), nil)
+	codeRequestHandler := handlers.NewCodeRequestHandler(serverDB, message.Codec, handlerstats.NewNoopHandlerStats(), nil)
+	mockClient := statesyncclient.NewMockClient(message.Codec, leafsRequestHandler, codeRequestHandler, nil)
+
+	s, err := NewStateSyncer(&StateSyncerConfig{
+		Client:                   mockClient,
+		Root:                     root,
+		DB:                       rawdb.NewMemoryDatabase(),
+		BatchSize:                1000,
+		NumCodeFetchingWorkers:   DefaultNumCodeFetchingWorkers,
+		MaxOutstandingCodeHashes: DefaultMaxOutstandingCodeHashes,
+		RequestSize:              100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Take a snapshot of the progress while the main trie is syncing, when
+	// the leafs of two requests have been processed.
+	var (
+		mainTrieRequests int
+		midSyncProgress  Progress
+	)
+	mockClient.GetLeafsIntercept = func(request message.LeafsRequest, response message.LeafsResponse) (message.LeafsResponse, error) {
+		if request.Root == root {
+			mainTrieRequests++
+			if mainTrieRequests == 3 {
+				midSyncProgress = s.Progress()
+			}
+		}
+		return response, nil
+	}
+	s.Start(context.Background())
+	waitFor(t, s.Done(), nil, testSyncTimeout)
+
+	assert.Equal(t, root, midSyncProgress.Root)
+	assert.False(t, midSyncProgress.MainTrieDone)
+	assert.Equal(t, uint64(200), midSyncProgress.LeafsFetched)
+	assert.Equal(t, []TrieProgress{{
+		Root:              root,
+		LeafsFetched:      200,
+		Segments:          1,
+		SegmentsRemaining: 1,
+	}}, midSyncProgress.TriesInProgress)
+
+	progress := s.Progress()
+	assert.True(t, progress.MainTrieDone)
+	assert.Equal(t, uint64(1000+10*16), progress.LeafsFetched)
+	assert.Equal(t, 11, progress.TriesSynced)
+	assert.Zero(t, progress.TriesRemaining)
+	assert.Empty(t, progress.TriesInProgress)
+	assert.Zero(t, progress.CodeHashesPending)
+}
+
+// assertTriesEqual ensures [a] and [b] have the same non-empty key/value pairs,
+// invoking [onLeaf] for each of them if non-nil.
+func assertTriesEqual(t *testing.T, a, b *trie.Trie, onLeaf func(key, val []byte)) {
+	t.Helper()
+	nodeItA, err := a.NodeIterator(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeItB, err := b.NodeIterator(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	itA, itB := trie.NewIterator(nodeItA), trie.NewIterator(nodeItB)
+	count := 0
+	for itA.Next() && itB.Next() {
+		count++
+		assert.Equal(t, itA.Key, itB.Key)
+		assert.Equal(t, itA.Value, itB.Value)
+		if onLeaf != nil {
+			onLeaf(itA.Key, itA.Value)
+		}
+	}
+	assert.NoError(t, itA.Err)
+	assert.NoError(t, itB.Err)
+	assert.False(t, itA.Next())
+	assert.False(t, itB.Next())
+	assert.Greater(t, count, 0)
+}
+
 // interruptLeafsIntercept provides the parameters to the getLeafsIntercept
 // function which returns [errInterrupted] after passing through [numRequests]
 // leafs requests for [root].
@@ -9,6 +9,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/Juneo-io/juneogo/utils/wrappers"
 	"github.com/Juneo-io/jeth/core/rawdb"
@@ -44,6 +45,12 @@ type trieToSync struct {
 	segmentsDone      map[int]struct{}
 	segmentToHashNext int
 
+	// These fields count the segments of the trie and the
+	// segments still syncing, so progress can be reported
+	// without waiting for [lock] while segments are hashed.
+	numSegments       atomic.Int32
+	segmentsRemaining atomic.Int32
+
 	// We use a stack trie to hash the leafs and have
 	// a batch used for writing it to disk.
 	batch     ethdb.Batch
@@ -62,15 +69,28 @@ type trieToSync struct {
 }
 
 // NewTrieToSync initializes a trieToSync and restores any previously started segments.
-func NewTrieToSync(sync *stateSync, root common.Hash, account common.Hash, syncTask syncTask) (*trieToSync, error) {
-	batch := sync.db.NewBatch() // TODO: migrate state sync to use database schemes.
+// [owners] are the account hashes of the accounts with [root] as their storage root,
+// or empty for the main trie. [owners] must not be empty for storage tries.
+func NewTrieToSync(sync *stateSync, root common.Hash, owners []common.Hash, syncTask syncTask) (*trieToSync, error) {
+	if len(owners) == 0 {
+		owners = []common.Hash{{}}
+	}
+	batch := sync.db.NewBatch()
 	writeFn := func(owner common.Hash, path []byte, hash common.Hash, blob []byte) {
-		rawdb.WriteTrieNode(batch, owner, path, hash, blob, rawdb.HashScheme)
+		if sync.scheme == rawdb.HashScheme {
+			rawdb.WriteTrieNode(batch, owner, path, hash, blob, rawdb.HashScheme)
+			return
+		}
+		// With the path scheme, nodes are keyed by their owner, so the nodes of a
+		// storage trie are written once for each account it belongs to.
+		for _, account := range owners {
+			rawdb.WriteTrieNode(batch, account, path, hash, blob, rawdb.PathScheme)
+		}
 	}
 	trieToSync := &trieToSync{
 		sync:         sync,
 		root:         root,
-		account:      account,
+		account:      owners[0], // Arbitrarily use the first account for making requests to the server.
 		batch:        batch,
 		stackTrie:    trie.NewStackTrie(writeFn),
 		isMainTrie:   (root == sync.root),
@@ -157,12 +177,16 @@ func (t *trieToSync) addSegment(start, end []byte) *trieSegment {
 		batch: t.sync.db.NewBatch(),
 	}
 	t.segments = append(t.segments, segment)
+	t.numSegments.Add(1)
+	t.segmentsRemaining.Add(1)
 	return segment
 }
 
 // segmentFinished is called when one the trie segment with index [idx] finishes syncing.
 // creates intermediary hash nodes for the trie up to the last contiguous segment received from start.
 func (t *trieToSync) segmentFinished(ctx context.Context, idx int) error {
+	t.segmentsRemaining.Add(-1)
+
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
@@ -241,6 +265,20 @@ func (t *trieToSync) segmentFinished(ctx context.Context, idx int) error {
 	return t.task.OnFinish()
 }
 
+// progress returns the number of segments of the trie and of segments
+// still syncing. The leafs fetched are set by [trieSyncStats.progress].
+func (t *trieToSync) progress() TrieProgress {
+	progress := TrieProgress{
+		Root:              t.root,
+		Segments:          int(t.numSegments.Load()),
+		SegmentsRemaining: int(t.segmentsRemaining.Load()),
+	}
+	if !t.isMainTrie {
+		progress.Account = t.account
+	}
+	return progress
+}
+
 // createSegmentsIfNeeded is called from the leaf handler. In case the trie syncing only has
 // one segment but a large number of leafs ([t.estimateSize() > segmentThreshold], it will
 // create [numSegments-1] additional segments to sync the trie.
@@ -35,6 +35,11 @@ type trieSyncStats struct {
 
 	remainingLeafs map[*trieSegment]uint64
 
+	// progress reported by [stateSync.Progress]
+	leafs     uint64                 // leafs fetched since the sync started
+	trieLeafs map[common.Hash]uint64 // leafs fetched for each trie in progress
+	eta       time.Duration          // last estimate of the time left
+
 	// metrics
 	totalLeafs     metrics.Counter
 	triesSegmented metrics.Counter
@@ -45,6 +50,7 @@ func newTrieSyncStats() *trieSyncStats {
 	now := time.Now()
 	return &trieSyncStats{
 		remainingLeafs: make(map[*trieSegment]uint64),
+		trieLeafs:      make(map[common.Hash]uint64),
 		lastUpdated:    now,
 
 		// metrics
@@ -66,8 +72,10 @@ func (t *trieSyncStats) incLeafs(segment *trieSegment, count uint64, remaining u
 	defer t.lock.Unlock()
 
 	t.totalLeafs.Inc(int64(count))
+	t.leafs += count
 	t.leafsSinceUpdate += count
 	t.remainingLeafs[segment] = remaining
+	t.trieLeafs[segment.trie.root] += count
 
 	now := time.Now()
 	sinceUpdate := now.Sub(t.lastUpdated)
@@ -106,6 +114,7 @@ func (t *trieSyncStats) trieDone(root common.Hash) {
 			delete(t.remainingLeafs, segment)
 		}
 	}
+	delete(t.trieLeafs, root)
 
 	t.triesSynced++
 	t.triesRemaining--
@@ -127,11 +136,13 @@ func (t *trieSyncStats) updateETA(sinceUpdate time.Duration, now time.Time) {
 	if t.triesSynced == 0 {
 		// provide a separate ETA for the account trie syncing step since we
 		// don't know the total number of storage tries yet.
+		t.eta = leafsTime
 		log.Info("state sync: syncing account trie", "ETA", roundETA(leafsTime))
 		return
 	}
 
 	triesTime := now.Sub(t.triesStartTime) * time.Duration(t.triesRemaining) / time.Duration(t.triesSynced)
+	t.eta = leafsTime + triesTime
 	log.Info(
 		"state sync: syncing storage tries",
 		"triesRemaining", t.triesRemaining,
@@ -147,6 +158,28 @@ func (t *trieSyncStats) setTriesRemaining(triesRemaining int) {
 	t.triesStartTime = time.Now()
 }
 
+// progress takes a lock and returns the leafs fetched, the tries synced and
+// remaining and the last ETA. The leafs fetched for each of [tries] are set.
+func (t *trieSyncStats) progress(tries []TrieProgress) Progress {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for i := range tries {
+		tries[i].LeafsFetched = t.trieLeafs[tries[i].Root]
+	}
+	progress := Progress{
+		LeafsFetched:    t.leafs,
+		TriesSynced:     t.triesSynced,
+		TriesRemaining:  t.triesRemaining,
+		TriesInProgress: tries,
+		ETASeconds:      uint64(t.eta.Seconds()),
+	}
+	if t.leafsRate != nil {
+		progress.LeafsPerSecond = t.leafsRate.Read()
+	}
+	return progress
+}
+
 // roundETA rounds [d] to a minute and chops off the "0s" suffix
 // returns "<1m" if [d] rounds to 0 minutes.
 func roundETA(d time.Duration) string {
@@ -109,6 +109,11 @@ func (s *storageTrieTask) IterateLeafs(seek common.Hash) ethdb.Iterator {
 }
 
 func (s *storageTrieTask) OnStart() (bool, error) {
+	// With the path scheme, the nodes of a storage trie on disk cannot be shared
+	// with other accounts, so the trie is always synced.
+	if s.sync.scheme == rawdb.PathScheme {
+		return false, nil
+	}
 	// check if this storage root is on disk
 	var firstAccount common.Hash
 	if len(s.accounts) > 0 {
@@ -250,6 +250,29 @@ func (db *Database) Dereference(root common.Hash) error {
 	return nil
 }
 
+// SetUpdateHook sets the hook invoked with the nodes of every update of the
+// database, or removes it if [hook] is nil. It's only supported by hash-based
+// database and will return an error for others.
+func (db *Database) SetUpdateHook(hook hashdb.UpdateHook) error {
+	hdb, ok := db.backend.(*hashdb.Database)
+	if !ok {
+		return errors.New("not supported")
+	}
+	hdb.SetUpdateHook(hook)
+	return nil
+}
+
+// DeleteNodes deletes the given trie nodes from the persistent database, except
+// the ones still cached in memory and the ones [keep] returns true for. It's
+// only supported by hash-based database and will return an error for others.
+func (db *Database) DeleteNodes(hashes []common.Hash, keep func(common.Hash) bool) (int, error) {
+	hdb, ok := db.backend.(*hashdb.Database)
+	if !ok {
+		return 0, errors.New("not supported")
+	}
+	return hdb.DeleteNodes(hashes, keep)
+}
+
 // Node retrieves the rlp-encoded node blob with provided node hash. It's
 // only supported by hash-based database and will return an error for others.
 // Note, this function should be deprecated once ETH66 is deprecated.
@@ -136,9 +136,16 @@ type Database struct {
 	dirtiesSize  common.StorageSize // Storage size of the dirty node cache (exc. metadata)
 	childrenSize common.StorageSize // Storage size of the external children tracking
 
+	updateHook UpdateHook // Invoked with the nodes of every update, nil if not set
+
 	lock sync.RWMutex
 }
 
+// UpdateHook is invoked with the state root and the hashes of the nodes of
+// every update, before the nodes are inserted into the dirty cache. It's
+// invoked while holding the database lock.
+type UpdateHook func(root common.Hash, nodes []common.Hash)
+
 // cachedNode is all the information we know about a single cached trie node
 // in the memory database write layer.
 type cachedNode struct {
@@ -674,7 +681,54 @@ func (db *Database) UpdateAndReferenceRoot(root common.Hash, parent common.Hash,
 	return nil
 }
 
+// SetUpdateHook sets the hook invoked on every update of the database, or
+// removes it if [hook] is nil.
+func (db *Database) SetUpdateHook(hook UpdateHook) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.updateHook = hook
+}
+
+// DeleteNodes deletes the nodes of [hashes] from the persistent database,
+// skipping the ones held in the dirty cache and the ones [keep] returns true
+// for. The database lock is held throughout, so none of the nodes can be
+// inserted or flushed concurrently. It returns the number of deleted nodes.
+func (db *Database) DeleteNodes(hashes []common.Hash, keep func(common.Hash) bool) (int, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	var (
+		batch   = db.diskdb.NewBatch()
+		deleted int
+	)
+	for _, hash := range hashes {
+		if _, ok := db.dirties[hash]; ok || keep(hash) {
+			continue
+		}
+		rawdb.DeleteLegacyTrieNode(batch, hash)
+		if db.cleans != nil {
+			db.cleans.Del(hash[:])
+		}
+		deleted++
+	}
+	return deleted, batch.Write()
+}
+
 func (db *Database) update(root common.Hash, parent common.Hash, nodes *trienode.MergedNodeSet) error {
+	// Notify the hook of all the nodes of the update, including the ones that
+	// are already cached and will be skipped below.
+	if db.updateHook != nil {
+		var hashes []common.Hash
+		for _, subset := range nodes.Sets {
+			for _, n := range subset.Nodes {
+				if !n.IsDeleted() {
+					hashes = append(hashes, n.Hash)
+				}
+			}
+		}
+		db.updateHook(root, hashes)
+	}
 	// Insert dirty nodes into the database. In the same tree, it must be
 	// ensured that children are inserted first, then parent so that children
 	// can be linked with their parent correctly.
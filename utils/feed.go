@@ -0,0 +1,76 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// NonBlockingFeed delivers values of type T to its subscribers. Unlike
+// event.Feed, Send never blocks: values are dropped for the subscribers whose
+// channel is full. This allows sending from paths which must not be stalled by
+// a slow subscriber, such as block acceptance.
+//
+// The zero value is ready to use.
+type NonBlockingFeed[T any] struct {
+	lock sync.Mutex
+	subs map[*nonBlockingSub[T]]struct{}
+}
+
+// Subscribe adds [ch] to the feed. The subscription's Err channel is closed
+// when it is unsubscribed.
+func (f *NonBlockingFeed[T]) Subscribe(ch chan<- T) event.Subscription {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.subs == nil {
+		f.subs = make(map[*nonBlockingSub[T]]struct{})
+	}
+	sub := &nonBlockingSub[T]{
+		feed: f,
+		ch:   ch,
+		err:  make(chan error),
+	}
+	f.subs[sub] = struct{}{}
+	return sub
+}
+
+// Send delivers [value] to the subscribers with room in their channel and
+// returns the number of subscribers it was delivered to.
+func (f *NonBlockingFeed[T]) Send(value T) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sent := 0
+	for sub := range f.subs {
+		select {
+		case sub.ch <- value:
+			sent++
+		default:
+		}
+	}
+	return sent
+}
+
+type nonBlockingSub[T any] struct {
+	feed *NonBlockingFeed[T]
+	ch   chan<- T
+	err  chan error
+	once sync.Once
+}
+
+func (s *nonBlockingSub[T]) Unsubscribe() {
+	s.once.Do(func() {
+		s.feed.lock.Lock()
+		delete(s.feed.subs, s)
+		s.feed.lock.Unlock()
+		close(s.err)
+	})
+}
+
+func (s *nonBlockingSub[T]) Err() <-chan error {
+	return s.err
+}
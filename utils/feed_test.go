@@ -0,0 +1,37 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonBlockingFeedDropsValues(t *testing.T) {
+	require := require.New(t)
+
+	var feed NonBlockingFeed[int]
+	fullCh := make(chan int, 1)
+	fullSub := feed.Subscribe(fullCh)
+	otherCh := make(chan int, 2)
+	otherSub := feed.Subscribe(otherCh)
+
+	// Sending must not block on the subscriber whose channel is full.
+	require.Equal(2, feed.Send(1))
+	require.Equal(1, feed.Send(2))
+	require.Equal(1, <-fullCh)
+	require.Equal(1, <-otherCh)
+	require.Equal(2, <-otherCh)
+
+	fullSub.Unsubscribe()
+	_, open := <-fullSub.Err()
+	require.False(open)
+	fullSub.Unsubscribe()
+	require.Equal(1, feed.Send(3))
+	require.Empty(fullCh)
+
+	otherSub.Unsubscribe()
+	require.Zero(feed.Send(4))
+}
@@ -32,19 +32,20 @@ import (
 
 // List evm execution errors
 var (
-	ErrOutOfGas                 = errors.New("out of gas")
-	ErrCodeStoreOutOfGas        = errors.New("contract creation code storage out of gas")
-	ErrDepth                    = errors.New("max call depth exceeded")
-	ErrInsufficientBalance      = errors.New("insufficient balance for transfer")
-	ErrContractAddressCollision = errors.New("contract address collision")
-	ErrExecutionReverted        = errors.New("execution reverted")
-	ErrMaxInitCodeSizeExceeded  = errors.New("max initcode size exceeded")
-	ErrMaxCodeSizeExceeded      = errors.New("max code size exceeded")
-	ErrInvalidJump              = errors.New("invalid jump destination")
-	ErrWriteProtection          = errors.New("write protection")
-	ErrReturnDataOutOfBounds    = errors.New("return data out of bounds")
-	ErrGasUintOverflow          = errors.New("gas uint64 overflow")
-	ErrInvalidCode              = errors.New("invalid code: must not begin with 0xef")
-	ErrNonceUintOverflow        = errors.New("nonce uint64 overflow")
-	ErrAddrProhibited           = errors.New("prohibited address cannot be sender or created contract address")
+	ErrOutOfGas                    = errors.New("out of gas")
+	ErrCodeStoreOutOfGas           = errors.New("contract creation code storage out of gas")
+	ErrDepth                       = errors.New("max call depth exceeded")
+	ErrInsufficientBalance         = errors.New("insufficient balance for transfer")
+	ErrContractAddressCollision    = errors.New("contract address collision")
+	ErrExecutionReverted           = errors.New("execution reverted")
+	ErrMaxInitCodeSizeExceeded     = errors.New("max initcode size exceeded")
+	ErrMaxCodeSizeExceeded         = errors.New("max code size exceeded")
+	ErrInvalidJump                 = errors.New("invalid jump destination")
+	ErrWriteProtection             = errors.New("write protection")
+	ErrReturnDataOutOfBounds       = errors.New("return data out of bounds")
+	ErrGasUintOverflow             = errors.New("gas uint64 overflow")
+	ErrInvalidCode                 = errors.New("invalid code: must not begin with 0xef")
+	ErrNonceUintOverflow           = errors.New("nonce uint64 overflow")
+	ErrAddrProhibited              = errors.New("prohibited address cannot be sender or created contract address")
+	ErrSenderAddressNotAllowListed = errors.New("cannot issue transaction from non-allow listed address")
 )
@@ -7,16 +7,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
+	"github.com/Juneo-io/jeth/utils"
 	"github.com/Juneo-io/juneogo/cache"
 	"github.com/Juneo-io/juneogo/database"
+	"github.com/Juneo-io/juneogo/database/prefixdb"
 	"github.com/Juneo-io/juneogo/ids"
 	"github.com/Juneo-io/juneogo/snow/choices"
 	"github.com/Juneo-io/juneogo/snow/consensus/snowman"
 	"github.com/Juneo-io/juneogo/utils/crypto/bls"
+	"github.com/Juneo-io/juneogo/utils/set"
 	avalancheWarp "github.com/Juneo-io/juneogo/vms/platformvm/warp"
 	"github.com/Juneo-io/juneogo/vms/platformvm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -46,7 +52,41 @@ type Backend interface {
 	// GetMessage retrieves the [unsignedMessage] from the warp backend database if available
 	GetMessage(messageHash ids.ID) (*avalancheWarp.UnsignedMessage, error)
 
-	// Clear clears the entire db
+	// IndexMessage records that [unsignedMessage] was sent by the log at
+	// [logIndex] of tx [txHash] accepted in block [blockHash] at [blockNumber].
+	IndexMessage(unsignedMessage *avalancheWarp.UnsignedMessage, blockHash common.Hash, blockNumber uint64, txHash common.Hash, logIndex int) error
+
+	// GetMessagesByBlockRange returns up to [limit] messages sent in blocks
+	// [fromBlock] to [toBlock] included, in the order they were accepted,
+	// starting at [startKey] if it is non-empty. The returned key is empty if
+	// there are no more messages, and otherwise is the startKey of the next page.
+	GetMessagesByBlockRange(fromBlock, toBlock uint64, startKey []byte, limit int) ([]SentMessage, []byte, error)
+
+	// GetMessagesByDestination returns up to [limit] messages sent to
+	// [address] on [chainID], in the order they were accepted, starting at
+	// [startKey] if it is non-empty. The returned key is empty if there are no
+	// more messages, and otherwise is the startKey of the next page. Only the
+	// messages with a destination payload (see PackDestinationPayload) are
+	// indexed by destination.
+	GetMessagesByDestination(chainID ids.ID, address common.Address, startKey []byte, limit int) ([]SentMessage, []byte, error)
+
+	// SubscribeSentMessages subscribes to the messages indexed by IndexMessage.
+	// Messages are dropped if the subscriber falls behind block acceptance.
+	SubscribeSentMessages(ch chan<- SentMessage) event.Subscription
+
+	// AddOffChainMessage validates [unsignedMessageBytes] like the off-chain
+	// messages of the config, and persists it so the node is willing to sign it.
+	AddOffChainMessage(unsignedMessageBytes []byte) (ids.ID, error)
+
+	// GetOffChainMessages returns the off-chain messages the node is willing to sign.
+	GetOffChainMessages() []OffChainMessage
+
+	// RevokeOffChainMessage removes the off-chain message [messageID] added
+	// with AddOffChainMessage, so the node no longer signs it.
+	RevokeOffChainMessage(messageID ids.ID) error
+
+	// Clear clears the messages and signatures of the db. The sent message
+	// index is kept in its own db, which is not cleared.
 	Clear() error
 }
 
@@ -60,16 +100,27 @@ type backend struct {
 	messageSignatureCache     *cache.LRU[ids.ID, [bls.SignatureLen]byte]
 	blockSignatureCache       *cache.LRU[ids.ID, [bls.SignatureLen]byte]
 	messageCache              *cache.LRU[ids.ID, *avalancheWarp.UnsignedMessage]
+	offchainLock              sync.RWMutex
 	offchainAddressedCallMsgs map[ids.ID]*avalancheWarp.UnsignedMessage
+	// staticOffChainMsgs are the off-chain messages loaded from the config,
+	// which cannot be revoked at runtime
+	staticOffChainMsgs set.Set[ids.ID]
+	offchainDB         database.Database
+
+	sentByHeightDB      database.Database
+	sentByDestinationDB database.Database
+	sentMessagesFeed    utils.NonBlockingFeed[SentMessage]
 }
 
 // NewBackend creates a new Backend, and initializes the signature cache and message tracking database.
+// The sent messages are indexed in [indexDB].
 func NewBackend(
 	networkID uint32,
 	sourceChainID ids.ID,
 	warpSigner avalancheWarp.Signer,
 	blockClient BlockClient,
 	db database.Database,
+	indexDB database.Database,
 	cacheSize int,
 	offchainMessages [][]byte,
 ) (Backend, error) {
@@ -83,39 +134,55 @@ func NewBackend(
 		blockSignatureCache:       &cache.LRU[ids.ID, [bls.SignatureLen]byte]{Size: cacheSize},
 		messageCache:              &cache.LRU[ids.ID, *avalancheWarp.UnsignedMessage]{Size: cacheSize},
 		offchainAddressedCallMsgs: make(map[ids.ID]*avalancheWarp.UnsignedMessage),
+		staticOffChainMsgs:        set.Set[ids.ID]{},
+		offchainDB:                prefixdb.New(offchainPrefix, db),
+		sentByHeightDB:            prefixdb.New(sentByHeightPrefix, indexDB),
+		sentByDestinationDB:       prefixdb.New(sentByDestinationPrefix, indexDB),
 	}
 	return b, b.initOffChainMessages(offchainMessages)
 }
 
 func (b *backend) initOffChainMessages(offchainMessages [][]byte) error {
 	for i, offchainMsg := range offchainMessages {
-		unsignedMsg, err := avalancheWarp.ParseUnsignedMessage(offchainMsg)
+		unsignedMsg, err := b.parseOffChainMessage(offchainMsg)
 		if err != nil {
-			return fmt.Errorf("%w at index %d: %w", errParsingOffChainMessage, i, err)
+			return fmt.Errorf("%w at index %d", err, i)
 		}
+		b.offchainAddressedCallMsgs[unsignedMsg.ID()] = unsignedMsg
+		b.staticOffChainMsgs.Add(unsignedMsg.ID())
+	}
 
-		if unsignedMsg.NetworkID != b.networkID {
-			return fmt.Errorf("%w at index %d", avalancheWarp.ErrWrongNetworkID, i)
-		}
+	return b.loadOffChainMessages()
+}
 
-		if unsignedMsg.SourceChainID != b.sourceChainID {
-			return fmt.Errorf("%w at index %d", avalancheWarp.ErrWrongSourceChainID, i)
-		}
+// parseOffChainMessage parses [offchainMsg] and verifies that it is an
+// AddressedCall sent from this chain.
+func (b *backend) parseOffChainMessage(offchainMsg []byte) (*avalancheWarp.UnsignedMessage, error) {
+	unsignedMsg, err := avalancheWarp.ParseUnsignedMessage(offchainMsg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errParsingOffChainMessage, err)
+	}
 
-		_, err = payload.ParseAddressedCall(unsignedMsg.Payload)
-		if err != nil {
-			return fmt.Errorf("%w at index %d as AddressedCall: %w", errParsingOffChainMessage, i, err)
-		}
-		b.offchainAddressedCallMsgs[unsignedMsg.ID()] = unsignedMsg
+	if unsignedMsg.NetworkID != b.networkID {
+		return nil, avalancheWarp.ErrWrongNetworkID
 	}
 
-	return nil
+	if unsignedMsg.SourceChainID != b.sourceChainID {
+		return nil, avalancheWarp.ErrWrongSourceChainID
+	}
+
+	_, err = payload.ParseAddressedCall(unsignedMsg.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w as AddressedCall: %w", errParsingOffChainMessage, err)
+	}
+	return unsignedMsg, nil
 }
 
 func (b *backend) Clear() error {
 	b.messageSignatureCache.Flush()
 	b.blockSignatureCache.Flush()
 	b.messageCache.Flush()
+	b.clearOffChainMessages()
 	return database.Clear(b.db, batchSize)
 }
 
@@ -147,7 +214,13 @@ func (b *backend) GetMessageSignature(messageID ids.ID) ([bls.SignatureLen]byte,
 		return sig, nil
 	}
 
-	unsignedMessage, err := b.GetMessage(messageID)
+	// The message is looked up and its signature cached while holding the
+	// off-chain lock, so that an off-chain message revoked concurrently cannot
+	// have its signature cached after RevokeOffChainMessage evicted it.
+	b.offchainLock.RLock()
+	defer b.offchainLock.RUnlock()
+
+	unsignedMessage, err := b.getMessage(messageID)
 	if err != nil {
 		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to get warp message %s from db: %w", messageID.String(), err)
 	}
@@ -197,6 +270,14 @@ func (b *backend) GetBlockSignature(blockID ids.ID) ([bls.SignatureLen]byte, err
 }
 
 func (b *backend) GetMessage(messageID ids.ID) (*avalancheWarp.UnsignedMessage, error) {
+	b.offchainLock.RLock()
+	defer b.offchainLock.RUnlock()
+
+	return b.getMessage(messageID)
+}
+
+// getMessage returns the message [messageID]. Assumes [offchainLock] is held.
+func (b *backend) getMessage(messageID ids.ID) (*avalancheWarp.UnsignedMessage, error) {
 	if message, ok := b.messageCache.Get(messageID); ok {
 		return message, nil
 	}
@@ -6,6 +6,7 @@ package warp
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/Juneo-io/juneogo/database/memdb"
@@ -20,6 +21,7 @@ import (
 	"github.com/Juneo-io/juneogo/utils/hashing"
 	avalancheWarp "github.com/Juneo-io/juneogo/vms/platformvm/warp"
 	"github.com/Juneo-io/juneogo/vms/platformvm/warp/payload"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -48,7 +50,7 @@ func TestClearDB(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backendIntf, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500, nil)
+	backendIntf, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, memdb.New(), 500, nil)
 	require.NoError(t, err)
 	backend, ok := backendIntf.(*backend)
 	require.True(t, ok)
@@ -92,7 +94,7 @@ func TestAddAndGetValidMessage(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500, nil)
+	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, memdb.New(), 500, nil)
 	require.NoError(t, err)
 
 	// Add testUnsignedMessage to the warp backend
@@ -115,7 +117,7 @@ func TestAddAndGetUnknownMessage(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500, nil)
+	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, memdb.New(), 500, nil)
 	require.NoError(t, err)
 
 	// Try getting a signature for a message that was not added.
@@ -147,7 +149,7 @@ func TestGetBlockSignature(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backend, err := NewBackend(networkID, sourceChainID, warpSigner, testVM, db, 500, nil)
+	backend, err := NewBackend(networkID, sourceChainID, warpSigner, testVM, db, memdb.New(), 500, nil)
 	require.NoError(err)
 
 	blockHashPayload, err := payload.NewHash(blkID)
@@ -173,7 +175,7 @@ func TestZeroSizedCache(t *testing.T) {
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
 
 	// Verify zero sized cache works normally, because the lru cache will be initialized to size 1 for any size parameter <= 0.
-	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 0, nil)
+	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, memdb.New(), 0, nil)
 	require.NoError(t, err)
 
 	// Add testUnsignedMessage to the warp backend
@@ -227,7 +229,7 @@ func TestOffChainMessages(t *testing.T) {
 			require := require.New(t)
 			db := memdb.New()
 
-			backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 0, test.offchainMessages)
+			backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, memdb.New(), 0, test.offchainMessages)
 			require.ErrorIs(err, test.err)
 			if test.check != nil {
 				test.check(require, backend)
@@ -235,3 +237,239 @@ func TestOffChainMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestRuntimeOffChainMessages(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, memdb.New(), 500, [][]byte{testUnsignedMessage.Bytes()})
+	require.NoError(err)
+
+	// Messages are validated like the off-chain messages of the config
+	_, err = backend.AddOffChainMessage([]byte{1, 2, 3})
+	require.ErrorIs(err, errParsingOffChainMessage)
+	otherChainPayload, err := payload.NewAddressedCall(testSourceAddress, []byte("other chain"))
+	require.NoError(err)
+	otherChainMessage, err := avalancheWarp.NewUnsignedMessage(networkID, ids.GenerateTestID(), otherChainPayload.Bytes())
+	require.NoError(err)
+	_, err = backend.AddOffChainMessage(otherChainMessage.Bytes())
+	require.ErrorIs(err, avalancheWarp.ErrWrongSourceChainID)
+
+	addressedCall, err := payload.NewAddressedCall(testSourceAddress, []byte("runtime"))
+	require.NoError(err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+	require.NoError(err)
+	messageID, err := backend.AddOffChainMessage(unsignedMessage.Bytes())
+	require.NoError(err)
+	require.Equal(unsignedMessage.ID(), messageID)
+
+	signature, err := backend.GetMessageSignature(messageID)
+	require.NoError(err)
+	expectedSig, err := warpSigner.Sign(unsignedMessage)
+	require.NoError(err)
+	require.Equal(expectedSig, signature[:])
+
+	messages := backend.GetOffChainMessages()
+	require.Len(messages, 2)
+	for _, msg := range messages {
+		require.Equal(msg.MessageID == testUnsignedMessage.ID(), msg.Static)
+	}
+
+	// Messages of the config cannot be revoked
+	err = backend.RevokeOffChainMessage(testUnsignedMessage.ID())
+	require.ErrorIs(err, errStaticOffChainMessage)
+	err = backend.RevokeOffChainMessage(ids.GenerateTestID())
+	require.ErrorIs(err, errUnknownOffChainMessage)
+
+	// Messages added at runtime are persisted
+	backend, err = NewBackend(networkID, sourceChainID, warpSigner, nil, db, memdb.New(), 500, [][]byte{testUnsignedMessage.Bytes()})
+	require.NoError(err)
+	require.Len(backend.GetOffChainMessages(), 2)
+
+	// Revoked messages are no longer signed, including from the cache
+	_, err = backend.GetMessageSignature(messageID)
+	require.NoError(err)
+	require.NoError(backend.RevokeOffChainMessage(messageID))
+	_, err = backend.GetMessageSignature(messageID)
+	require.Error(err)
+	_, err = backend.GetMessage(messageID)
+	require.Error(err)
+
+	backend, err = NewBackend(networkID, sourceChainID, warpSigner, nil, db, memdb.New(), 500, [][]byte{testUnsignedMessage.Bytes()})
+	require.NoError(err)
+	messages = backend.GetOffChainMessages()
+	require.Len(messages, 1)
+	require.Equal(testUnsignedMessage.ID(), messages[0].MessageID)
+}
+
+func TestRevokeOffChainMessageWhileSigning(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, memdb.New(), memdb.New(), 500, nil)
+	require.NoError(err)
+
+	for i := 0; i < 100; i++ {
+		addressedCall, err := payload.NewAddressedCall(testSourceAddress, []byte{byte(i)})
+		require.NoError(err)
+		unsignedMessage, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+		require.NoError(err)
+		messageID, err := backend.AddOffChainMessage(unsignedMessage.Bytes())
+		require.NoError(err)
+
+		// A signature requested concurrently must not be cached once the
+		// message is revoked.
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = backend.GetMessageSignature(messageID)
+		}()
+		require.NoError(backend.RevokeOffChainMessage(messageID))
+		wg.Wait()
+
+		_, err = backend.GetMessageSignature(messageID)
+		require.Error(err)
+	}
+}
+
+func TestSentMessagesIndex(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	indexDB := memdb.New()
+	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, indexDB, 500, nil)
+	require.NoError(err)
+
+	sentMessages := make(chan SentMessage, 10)
+	sub := backend.SubscribeSentMessages(sentMessages)
+	defer sub.Unsubscribe()
+	// Indexing must not block on a subscriber which never reads.
+	slowSub := backend.SubscribeSentMessages(make(chan SentMessage))
+	defer slowSub.Unsubscribe()
+
+	destinationChainID := ids.GenerateTestID()
+	destinationAddress := ethcommon.Address{1}
+	for height := uint64(1); height <= 5; height++ {
+		// Only destination payloads are indexed by destination, so ordinary
+		// ABI payloads are not mistaken for a destination.
+		payloadBytes := append(ethcommon.LeftPadBytes([]byte{byte(height)}, 32), ethcommon.LeftPadBytes(destinationAddress[:], 32)...)
+		if height%2 == 1 {
+			payloadBytes, err = PackDestinationPayload(destinationChainID, destinationAddress, []byte{byte(height)})
+			require.NoError(err)
+		}
+		addressedCall, err := payload.NewAddressedCall(testSourceAddress, payloadBytes)
+		require.NoError(err)
+		unsignedMessage, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+		require.NoError(err)
+		require.NoError(backend.IndexMessage(unsignedMessage, ethcommon.Hash{byte(height)}, height, ethcommon.Hash{byte(height), 1}, 0))
+
+		sent := <-sentMessages
+		require.Equal(unsignedMessage.ID(), sent.MessageID)
+		require.Equal(ethcommon.BytesToAddress(testSourceAddress), sent.SourceAddress)
+	}
+
+	sent, nextKey, err := backend.GetMessagesByBlockRange(2, 4, nil, 10)
+	require.NoError(err)
+	require.Empty(nextKey)
+	require.Len(sent, 3)
+	for i, msg := range sent {
+		require.Equal(uint64(i+2), uint64(msg.BlockNumber))
+	}
+
+	// Page through the messages with the returned keys.
+	sent, nextKey, err = backend.GetMessagesByBlockRange(1, 5, nil, 2)
+	require.NoError(err)
+	require.Len(sent, 2)
+	require.Equal(uint64(1), uint64(sent[0].BlockNumber))
+	require.Equal(uint64(2), uint64(sent[1].BlockNumber))
+	sent, nextKey, err = backend.GetMessagesByBlockRange(1, 5, nextKey, 2)
+	require.NoError(err)
+	require.Len(sent, 2)
+	require.Equal(uint64(3), uint64(sent[0].BlockNumber))
+	require.Equal(uint64(4), uint64(sent[1].BlockNumber))
+	sent, nextKey, err = backend.GetMessagesByBlockRange(1, 5, nextKey, 2)
+	require.NoError(err)
+	require.Empty(nextKey)
+	require.Len(sent, 1)
+	require.Equal(uint64(5), uint64(sent[0].BlockNumber))
+
+	// The last page of a range ending at the limit has no next key.
+	sent, nextKey, err = backend.GetMessagesByBlockRange(1, 2, nil, 2)
+	require.NoError(err)
+	require.Len(sent, 2)
+	require.Empty(nextKey)
+
+	_, _, err = backend.GetMessagesByBlockRange(1, 5, []byte{1}, 2)
+	require.ErrorIs(err, errInvalidStartKey)
+
+	sent, nextKey, err = backend.GetMessagesByDestination(destinationChainID, destinationAddress, nil, 10)
+	require.NoError(err)
+	require.Empty(nextKey)
+	require.Len(sent, 3)
+	for _, msg := range sent {
+		require.Equal(destinationChainID, msg.DestinationChainID)
+		require.Equal(destinationAddress, msg.DestinationAddress)
+		require.Equal(uint64(1), uint64(msg.BlockNumber)%2)
+	}
+	sent, nextKey, err = backend.GetMessagesByDestination(destinationChainID, destinationAddress, nil, 2)
+	require.NoError(err)
+	require.Len(sent, 2)
+	require.Equal(uint64(3), uint64(sent[1].BlockNumber))
+	sent, nextKey, err = backend.GetMessagesByDestination(destinationChainID, destinationAddress, nextKey, 2)
+	require.NoError(err)
+	require.Empty(nextKey)
+	require.Len(sent, 1)
+	require.Equal(uint64(5), uint64(sent[0].BlockNumber))
+
+	sent, nextKey, err = backend.GetMessagesByDestination(destinationChainID, ethcommon.Address{2}, nil, 10)
+	require.NoError(err)
+	require.Empty(nextKey)
+	require.Empty(sent)
+
+	// Pruning the warp db keeps the index.
+	require.NoError(backend.Clear())
+	sent, _, err = backend.GetMessagesByBlockRange(1, 5, nil, 10)
+	require.NoError(err)
+	require.Len(sent, 5)
+}
+
+func TestParseDestination(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	address := ethcommon.Address{1}
+	payloadBytes, err := PackDestinationPayload(chainID, address, []byte{1, 2, 3})
+	require.NoError(err)
+	parsedChainID, parsedAddress, parsedPayload, ok := parseDestination(payloadBytes)
+	require.True(ok)
+	require.Equal(chainID, parsedChainID)
+	require.Equal(address, parsedAddress)
+	require.Equal([]byte{1, 2, 3}, parsedPayload)
+
+	emptyChainPayload, err := PackDestinationPayload(ids.Empty, address, nil)
+	require.NoError(err)
+	tests := map[string][]byte{
+		"empty":                nil,
+		"selector only":        payloadBytes[:4],
+		"truncated":            payloadBytes[:len(payloadBytes)-1],
+		"trailing bytes":       append(ethcommon.CopyBytes(payloadBytes), 0),
+		"other selector":       append([]byte{0, 0, 0, 0}, payloadBytes[4:]...),
+		"empty chain":          emptyChainPayload,
+		"ABI without selector": payloadBytes[4:],
+	}
+	for name, payloadBytes := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, _, ok := parseDestination(payloadBytes)
+			require.False(t, ok)
+		})
+	}
+}
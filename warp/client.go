@@ -9,6 +9,7 @@ import (
 
 	"github.com/Juneo-io/juneogo/ids"
 	"github.com/Juneo-io/jeth/rpc"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -20,6 +21,9 @@ type Client interface {
 	GetMessageAggregateSignature(ctx context.Context, messageID ids.ID, quorumNum uint64, supernetIDStr string) ([]byte, error)
 	GetBlockSignature(ctx context.Context, blockID ids.ID) ([]byte, error)
 	GetBlockAggregateSignature(ctx context.Context, blockID ids.ID, quorumNum uint64, supernetIDStr string) ([]byte, error)
+	GetMessagesByBlockRange(ctx context.Context, fromBlock, toBlock uint64, startKey []byte) (*SentMessagesPage, error)
+	GetMessagesByDestination(ctx context.Context, chainID ids.ID, address common.Address, startKey []byte) (*SentMessagesPage, error)
+	VerifySignedMessage(ctx context.Context, signedMessageBytes []byte, quorumNum uint64, supernetIDStr string, pChainHeight *uint64) (*SignedMessageVerification, error)
 }
 
 // client implementation for interacting with EVM [chain]
@@ -77,3 +81,31 @@ func (c *client) GetBlockAggregateSignature(ctx context.Context, blockID ids.ID,
 	}
 	return res, nil
 }
+
+func (c *client) GetMessagesByBlockRange(ctx context.Context, fromBlock, toBlock uint64, startKey []byte) (*SentMessagesPage, error) {
+	var res SentMessagesPage
+	if err := c.client.CallContext(ctx, &res, "warp_getMessagesByBlockRange", fromBlock, toBlock, hexutil.Bytes(startKey)); err != nil {
+		return nil, fmt.Errorf("call to warp_getMessagesByBlockRange failed. err: %w", err)
+	}
+	return &res, nil
+}
+
+func (c *client) GetMessagesByDestination(ctx context.Context, chainID ids.ID, address common.Address, startKey []byte) (*SentMessagesPage, error) {
+	var res SentMessagesPage
+	if err := c.client.CallContext(ctx, &res, "warp_getMessagesByDestination", chainID, address, hexutil.Bytes(startKey)); err != nil {
+		return nil, fmt.Errorf("call to warp_getMessagesByDestination failed. err: %w", err)
+	}
+	return &res, nil
+}
+
+func (c *client) VerifySignedMessage(ctx context.Context, signedMessageBytes []byte, quorumNum uint64, supernetIDStr string, pChainHeight *uint64) (*SignedMessageVerification, error) {
+	var res SignedMessageVerification
+	args := []interface{}{hexutil.Bytes(signedMessageBytes), quorumNum, supernetIDStr}
+	if pChainHeight != nil {
+		args = append(args, *pChainHeight)
+	}
+	if err := c.client.CallContext(ctx, &res, "warp_verifySignedMessage", args...); err != nil {
+		return nil, fmt.Errorf("call to warp_verifySignedMessage failed. err: %w", err)
+	}
+	return &res, nil
+}
@@ -0,0 +1,124 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Juneo-io/juneogo/ids"
+	avalancheWarp "github.com/Juneo-io/juneogo/vms/platformvm/warp"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// offchainPrefix prefixes the off-chain messages added at runtime in the
+	// warp db: offchainPrefix + messageID -> unsigned message
+	offchainPrefix = []byte("offchain")
+
+	errUnknownOffChainMessage = errors.New("unknown off-chain message")
+	errStaticOffChainMessage  = errors.New("cannot revoke off-chain message loaded from the config")
+)
+
+// OffChainMessage is an off-chain message the node is willing to sign.
+type OffChainMessage struct {
+	MessageID ids.ID        `json:"messageID"`
+	Message   hexutil.Bytes `json:"message"`
+	// Static is true for the messages loaded from the config, which cannot be
+	// revoked at runtime.
+	Static bool `json:"static"`
+}
+
+// loadOffChainMessages loads the off-chain messages added at runtime by a
+// previous run, validating them again.
+func (b *backend) loadOffChainMessages() error {
+	it := b.offchainDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		unsignedMsg, err := b.parseOffChainMessage(it.Value())
+		if err != nil {
+			return fmt.Errorf("%w for persisted message %x", err, it.Key())
+		}
+		b.offchainAddressedCallMsgs[unsignedMsg.ID()] = unsignedMsg
+	}
+	return it.Error()
+}
+
+func (b *backend) AddOffChainMessage(unsignedMessageBytes []byte) (ids.ID, error) {
+	unsignedMsg, err := b.parseOffChainMessage(unsignedMessageBytes)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	b.offchainLock.Lock()
+	defer b.offchainLock.Unlock()
+
+	messageID := unsignedMsg.ID()
+	if _, ok := b.offchainAddressedCallMsgs[messageID]; ok {
+		return messageID, nil
+	}
+	if err := b.offchainDB.Put(messageID[:], unsignedMsg.Bytes()); err != nil {
+		return ids.Empty, fmt.Errorf("failed to put off-chain message in db: %w", err)
+	}
+	b.offchainAddressedCallMsgs[messageID] = unsignedMsg
+	log.Info("Added warp off-chain message", "messageID", messageID)
+	return messageID, nil
+}
+
+// GetOffChainMessages returns the off-chain messages sorted by messageID.
+func (b *backend) GetOffChainMessages() []OffChainMessage {
+	b.offchainLock.RLock()
+	defer b.offchainLock.RUnlock()
+
+	messages := make([]OffChainMessage, 0, len(b.offchainAddressedCallMsgs))
+	for messageID, unsignedMsg := range b.offchainAddressedCallMsgs {
+		messages = append(messages, OffChainMessage{
+			MessageID: messageID,
+			Message:   unsignedMsg.Bytes(),
+			Static:    b.staticOffChainMsgs.Contains(messageID),
+		})
+	}
+	slices.SortFunc(messages, func(a, b OffChainMessage) int {
+		return bytes.Compare(a.MessageID[:], b.MessageID[:])
+	})
+	return messages
+}
+
+func (b *backend) RevokeOffChainMessage(messageID ids.ID) error {
+	b.offchainLock.Lock()
+	defer b.offchainLock.Unlock()
+
+	if _, ok := b.offchainAddressedCallMsgs[messageID]; !ok {
+		return fmt.Errorf("%w: %s", errUnknownOffChainMessage, messageID)
+	}
+	if b.staticOffChainMsgs.Contains(messageID) {
+		return fmt.Errorf("%w: %s", errStaticOffChainMessage, messageID)
+	}
+	if err := b.offchainDB.Delete(messageID[:]); err != nil {
+		return fmt.Errorf("failed to delete off-chain message from db: %w", err)
+	}
+	delete(b.offchainAddressedCallMsgs, messageID)
+	// Drop the cached signature, so the message is no longer signed
+	b.messageSignatureCache.Evict(messageID)
+	log.Info("Revoked warp off-chain message", "messageID", messageID)
+	return nil
+}
+
+// clearOffChainMessages forgets the off-chain messages added at runtime, as
+// they are removed from the db along with the rest of the warp db.
+func (b *backend) clearOffChainMessages() {
+	b.offchainLock.Lock()
+	defer b.offchainLock.Unlock()
+
+	for messageID := range b.offchainAddressedCallMsgs {
+		if b.staticOffChainMsgs.Contains(messageID) {
+			continue
+		}
+		delete(b.offchainAddressedCallMsgs, messageID)
+	}
+}
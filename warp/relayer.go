@@ -0,0 +1,452 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Juneo-io/jeth/core/types"
+	"github.com/Juneo-io/jeth/ethclient"
+	"github.com/Juneo-io/jeth/interfaces"
+	warpPrecompile "github.com/Juneo-io/jeth/precompile/contracts/warp"
+	"github.com/Juneo-io/jeth/predicate"
+	"github.com/Juneo-io/juneogo/database"
+	"github.com/Juneo-io/juneogo/database/prefixdb"
+	"github.com/Juneo-io/juneogo/ids"
+	avalancheWarp "github.com/Juneo-io/juneogo/vms/platformvm/warp"
+	"github.com/Juneo-io/juneogo/vms/platformvm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// maxRelayerRetryBackoff caps the delay between two relay attempts of the
+	// same message.
+	maxRelayerRetryBackoff = time.Hour
+	// relayerSyncBlocks is the maximum number of blocks whose sent messages
+	// are recorded in a single batch.
+	relayerSyncBlocks = 1024
+)
+
+var (
+	// relayerPrefix prefixes the relay state of pending messages in the warp db.
+	relayerPrefix = []byte("relayer")
+	// relayerNextHeightKey is the next height whose sent messages are recorded
+	// by the relayer. It is shorter than the message IDs keying the relay state.
+	relayerNextHeightKey = []byte("nextHeight")
+
+	errRelayerNoDestinationRPC     = errors.New("warp relayer requires a destination RPC")
+	errRelayerNoDestinationChainID = errors.New("warp relayer requires a destination chain ID")
+	errRelayerNoPrivateKey         = errors.New("warp relayer requires a private key")
+	errRelayerNoGasLimit           = errors.New("warp relayer requires a non-zero gas limit")
+	errRelayerNoRetryInterval      = errors.New("warp relayer requires a positive retry interval")
+	errRelayTxReverted             = errors.New("relay tx reverted")
+	errRelayTxDropped              = errors.New("relay tx dropped")
+	errRelayWrongDestination       = errors.New("message is not addressed to the destination chain")
+)
+
+// RelayerConfig configures the in-process warp relayer.
+type RelayerConfig struct {
+	// DestinationRPC is the eth RPC endpoint of the destination chain.
+	DestinationRPC string
+	// DestinationChainID is the blockchain ID of the destination chain. Only
+	// the messages whose destination payload is addressed to this chain are
+	// relayed, calling their destination address with their inner payload as
+	// calldata. The signed message is available to the called contract through
+	// the warp precompile at index 0.
+	DestinationChainID ids.ID
+	// StartHeight is the height of the first block whose messages are relayed
+	// the first time the relayer runs. If zero, the relayer starts from the
+	// blocks accepted after it is first run.
+	StartHeight uint64
+	// PrivateKey signs and pays for the relay txs on the destination chain.
+	PrivateKey *ecdsa.PrivateKey
+	// QuorumNum is the quorum numerator used to aggregate signatures.
+	QuorumNum uint64
+	// GasLimit is the gas limit of the relay txs.
+	GasLimit uint64
+	// RetryInterval is the delay before the first retry of a failed relay.
+	// The delay doubles after every failed attempt. It is also the delay
+	// between two checks of a relay tx awaiting confirmation.
+	RetryInterval time.Duration
+	// MaxAttempts is the number of relay attempts after which a message is
+	// dropped. Zero means messages are retried forever.
+	MaxAttempts uint64
+}
+
+// relayState is the retry state of a message waiting to be relayed, persisted
+// in the warp db keyed by message ID.
+type relayState struct {
+	Attempts    uint64
+	NextAttempt uint64 // unix timestamp in seconds
+	LastError   string
+	// TxHash is the relay tx issued by the last attempt, which is awaiting
+	// confirmation. It is empty if no relay tx is pending.
+	TxHash common.Hash
+}
+
+// Relayer watches the messages sent by the blocks accepted by this chain,
+// aggregates the signatures of each message and submits it to the destination
+// chain in a predicate tx.
+type Relayer struct {
+	config  RelayerConfig
+	api     *API
+	client  ethclient.Client
+	db      database.Database
+	address common.Address
+
+	chainID *big.Int
+	nonce   uint64
+}
+
+// NewRelayer returns a Relayer submitting the messages of [api]'s backend to
+// the destination chain of [config]. The relay state is persisted in [db].
+func NewRelayer(config RelayerConfig, api *API, db database.Database) (*Relayer, error) {
+	switch {
+	case config.DestinationRPC == "":
+		return nil, errRelayerNoDestinationRPC
+	case config.DestinationChainID == ids.Empty:
+		return nil, errRelayerNoDestinationChainID
+	case config.PrivateKey == nil:
+		return nil, errRelayerNoPrivateKey
+	case config.GasLimit == 0:
+		return nil, errRelayerNoGasLimit
+	case config.RetryInterval <= 0:
+		return nil, errRelayerNoRetryInterval
+	}
+	client, err := ethclient.Dial(config.DestinationRPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial destination RPC: %w", err)
+	}
+	return newRelayer(config, api, client, db), nil
+}
+
+func newRelayer(config RelayerConfig, api *API, client ethclient.Client, db database.Database) *Relayer {
+	return &Relayer{
+		config:  config,
+		api:     api,
+		client:  client,
+		db:      prefixdb.New(relayerPrefix, db),
+		address: crypto.PubkeyToAddress(config.PrivateKey.PublicKey),
+	}
+}
+
+// Run relays the messages sent by the blocks accepted by this chain until
+// [ctx] is done. Messages are read from the sent message index of the backend
+// up to [lastAcceptedHeight], starting where the previous run stopped, so that
+// the messages accepted while the relayer was not running are relayed as well.
+// The first run starts from the configured start height, or from the blocks
+// accepted after it if no start height is configured.
+func (r *Relayer) Run(ctx context.Context, lastAcceptedHeight func() uint64) {
+	defer r.client.Close()
+
+	// Sent messages only wake up the relay loop, so it does not matter if
+	// they are dropped.
+	sent := make(chan SentMessage, 1)
+	sub := r.api.backend.SubscribeSentMessages(sent)
+	defer sub.Unsubscribe()
+
+	ticker := time.NewTicker(r.config.RetryInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.syncMessages(lastAcceptedHeight(), time.Now()); err != nil {
+			log.Error("failed to record warp messages to relay", "err", err)
+		}
+		r.relayDue(ctx, time.Now())
+
+		select {
+		case <-sent:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// nextHeight returns the next height whose sent messages are recorded. If the
+// relayer never ran, the configured start height, or the height following
+// [lastAccepted] if there is none, is persisted as the next height.
+func (r *Relayer) nextHeight(lastAccepted uint64) (uint64, error) {
+	height, err := database.GetUInt64(r.db, relayerNextHeightKey)
+	switch {
+	case err == nil:
+		return height, nil
+	case !errors.Is(err, database.ErrNotFound):
+		return 0, fmt.Errorf("failed to read next height to relay: %w", err)
+	}
+	height = r.config.StartHeight
+	if height == 0 {
+		height = lastAccepted + 1
+	}
+	if err := database.PutUInt64(r.db, relayerNextHeightKey, height); err != nil {
+		return 0, fmt.Errorf("failed to write next height to relay: %w", err)
+	}
+	log.Info("warp relayer starting", "height", height, "destinationChainID", r.config.DestinationChainID)
+	return height, nil
+}
+
+// syncMessages records the messages addressed to the destination chain sent
+// by the blocks accepted up to [lastAccepted] which were not recorded yet as
+// due at [now]. The next height to record is persisted along with the
+// messages.
+func (r *Relayer) syncMessages(lastAccepted uint64, now time.Time) error {
+	for {
+		from, err := r.nextHeight(lastAccepted)
+		if err != nil {
+			return err
+		}
+		if from > lastAccepted {
+			return nil
+		}
+		to := lastAccepted
+		if to-from >= relayerSyncBlocks {
+			to = from + relayerSyncBlocks - 1
+		}
+
+		batch := r.db.NewBatch()
+		var startKey []byte
+		for {
+			sent, nextKey, err := r.api.backend.GetMessagesByBlockRange(from, to, startKey, maxSentMessagesPerRequest)
+			if err != nil {
+				return fmt.Errorf("failed to get messages sent in blocks [%d, %d]: %w", from, to, err)
+			}
+			for _, msg := range sent {
+				if msg.DestinationChainID != r.config.DestinationChainID {
+					continue
+				}
+				// Keep the state of the messages already being relayed.
+				has, err := r.db.Has(msg.MessageID[:])
+				if err != nil {
+					return err
+				}
+				if has {
+					continue
+				}
+				stateBytes, err := rlp.EncodeToBytes(&relayState{NextAttempt: uint64(now.Unix())})
+				if err != nil {
+					return fmt.Errorf("failed to encode relay state of %s: %w", msg.MessageID, err)
+				}
+				if err := batch.Put(msg.MessageID[:], stateBytes); err != nil {
+					return err
+				}
+			}
+			if len(nextKey) == 0 {
+				break
+			}
+			startKey = nextKey
+		}
+		if err := batch.Put(relayerNextHeightKey, database.PackUInt64(to+1)); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return fmt.Errorf("failed to record messages sent in blocks [%d, %d]: %w", from, to, err)
+		}
+	}
+}
+
+// relayDue attempts to relay every pending message whose next attempt is due
+// at [now], or checks the relay tx of the messages awaiting confirmation.
+func (r *Relayer) relayDue(ctx context.Context, now time.Time) {
+	due, err := r.dueMessages(now)
+	if err != nil {
+		log.Error("failed to read pending warp messages", "err", err)
+		return
+	}
+	for messageID, state := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		if state.TxHash != (common.Hash{}) {
+			if err := r.confirm(ctx, messageID, state, now); err != nil {
+				log.Error("failed to confirm warp message relay tx", "messageID", messageID, "txHash", state.TxHash, "err", err)
+			}
+			continue
+		}
+		txHash, err := r.relay(ctx, messageID)
+		if err != nil {
+			if err := r.recordFailure(messageID, state, err, now); err != nil {
+				log.Error("failed to record warp message relay failure", "messageID", messageID, "err", err)
+			}
+			continue
+		}
+		log.Debug("issued warp message relay tx", "messageID", messageID, "txHash", txHash, "attempts", state.Attempts+1)
+		state.TxHash = txHash
+		state.NextAttempt = uint64(now.Add(r.config.RetryInterval).Unix())
+		if err := r.putState(messageID, state); err != nil {
+			log.Error("failed to record warp message relay tx", "messageID", messageID, "txHash", txHash, "err", err)
+		}
+	}
+}
+
+// confirm checks the relay tx of [messageID]. The message is only removed
+// once its relay tx succeeded. A reverted or dropped relay tx is a failed
+// attempt, and a pending relay tx is checked again after the retry interval.
+func (r *Relayer) confirm(ctx context.Context, messageID ids.ID, state *relayState, now time.Time) error {
+	receipt, err := r.client.TransactionReceipt(ctx, state.TxHash)
+	switch {
+	case err == nil && receipt.Status == types.ReceiptStatusSuccessful:
+		log.Info("relayed warp message", "messageID", messageID, "txHash", state.TxHash, "attempts", state.Attempts+1)
+		return r.db.Delete(messageID[:])
+	case err == nil:
+		return r.recordFailure(messageID, state, fmt.Errorf("%w: %s", errRelayTxReverted, state.TxHash), now)
+	case !errors.Is(err, interfaces.NotFound):
+		log.Debug("failed to get warp message relay receipt", "messageID", messageID, "txHash", state.TxHash, "err", err)
+		return r.checkLater(messageID, state, now)
+	}
+
+	_, _, err = r.client.TransactionByHash(ctx, state.TxHash)
+	switch {
+	case errors.Is(err, interfaces.NotFound):
+		// The nonce of the dropped tx may be reused.
+		r.nonce = 0
+		return r.recordFailure(messageID, state, fmt.Errorf("%w: %s", errRelayTxDropped, state.TxHash), now)
+	case err != nil:
+		log.Debug("failed to get warp message relay tx", "messageID", messageID, "txHash", state.TxHash, "err", err)
+	}
+	return r.checkLater(messageID, state, now)
+}
+
+// checkLater schedules the next check of the relay tx of [messageID] after the
+// retry interval, without counting it as a failed attempt.
+func (r *Relayer) checkLater(messageID ids.ID, state *relayState, now time.Time) error {
+	state.NextAttempt = uint64(now.Add(r.config.RetryInterval).Unix())
+	return r.putState(messageID, state)
+}
+
+// dueMessages returns the state of the pending messages due at [now].
+func (r *Relayer) dueMessages(now time.Time) (map[ids.ID]*relayState, error) {
+	it := r.db.NewIterator()
+	defer it.Release()
+
+	due := make(map[ids.ID]*relayState)
+	for it.Next() {
+		if len(it.Key()) != ids.IDLen {
+			continue
+		}
+		messageID, err := ids.ToID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		state := new(relayState)
+		if err := rlp.DecodeBytes(it.Value(), state); err != nil {
+			return nil, fmt.Errorf("failed to decode relay state of %s: %w", messageID, err)
+		}
+		if state.NextAttempt <= uint64(now.Unix()) {
+			due[messageID] = state
+		}
+	}
+	return due, it.Error()
+}
+
+// recordFailure persists the failed attempt to relay [messageID] at [now],
+// scheduling the next attempt with an exponential backoff or dropping the
+// message once it ran out of attempts.
+func (r *Relayer) recordFailure(messageID ids.ID, state *relayState, relayErr error, now time.Time) error {
+	state.Attempts++
+	state.LastError = relayErr.Error()
+	state.TxHash = common.Hash{}
+	if r.config.MaxAttempts != 0 && state.Attempts >= r.config.MaxAttempts {
+		log.Warn("dropping warp message after failing to relay it", "messageID", messageID, "attempts", state.Attempts, "err", relayErr)
+		return r.db.Delete(messageID[:])
+	}
+
+	backoff := r.config.RetryInterval
+	for i := uint64(1); i < state.Attempts && backoff < maxRelayerRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRelayerRetryBackoff {
+		backoff = maxRelayerRetryBackoff
+	}
+	state.NextAttempt = uint64(now.Add(backoff).Unix())
+	log.Debug("failed to relay warp message", "messageID", messageID, "attempts", state.Attempts, "nextAttempt", backoff, "err", relayErr)
+	return r.putState(messageID, state)
+}
+
+func (r *Relayer) putState(messageID ids.ID, state *relayState) error {
+	stateBytes, err := rlp.EncodeToBytes(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode relay state of %s: %w", messageID, err)
+	}
+	return r.db.Put(messageID[:], stateBytes)
+}
+
+// relay aggregates the signatures of [messageID] and issues the predicate tx
+// delivering it to the destination chain.
+func (r *Relayer) relay(ctx context.Context, messageID ids.ID) (common.Hash, error) {
+	unsignedMessage, err := r.api.backend.GetMessage(messageID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	addressedCall, err := payload.ParseAddressedCall(unsignedMessage.Payload)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to parse message payload as AddressedCall: %w", err)
+	}
+	destinationChainID, destinationAddress, calldata, ok := parseDestination(addressedCall.Payload)
+	if !ok || destinationChainID != r.config.DestinationChainID {
+		return common.Hash{}, errRelayWrongDestination
+	}
+	signedMessageBytes, err := r.api.aggregateSignatures(ctx, unsignedMessage, r.config.QuorumNum, "")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to aggregate signatures: %w", err)
+	}
+	if _, err := avalancheWarp.ParseMessage(signedMessageBytes); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to parse aggregated message: %w", err)
+	}
+
+	if r.chainID == nil {
+		chainID, err := r.client.ChainID(ctx)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to get destination chainID: %w", err)
+		}
+		r.chainID = chainID
+	}
+	// Txs issued by a previous relay may not be accepted yet, so the nonce
+	// tracked locally takes precedence over a lower accepted nonce.
+	nonce, err := r.client.NonceAt(ctx, r.address, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get relayer nonce: %w", err)
+	}
+	if nonce < r.nonce {
+		nonce = r.nonce
+	}
+	gasTipCap, err := r.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to suggest gas tip cap: %w", err)
+	}
+	baseFee, err := r.client.EstimateBaseFee(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to estimate base fee: %w", err)
+	}
+	gasFeeCap := new(big.Int).Add(new(big.Int).Mul(baseFee, common.Big2), gasTipCap)
+
+	tx := predicate.NewPredicateTx(
+		r.chainID,
+		nonce,
+		&destinationAddress,
+		r.config.GasLimit,
+		gasFeeCap,
+		gasTipCap,
+		common.Big0,
+		calldata,
+		types.AccessList{},
+		warpPrecompile.ContractAddress,
+		signedMessageBytes,
+	)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(r.chainID), r.config.PrivateKey)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to sign relay tx: %w", err)
+	}
+	if err := r.client.SendTransaction(ctx, signedTx); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to issue relay tx: %w", err)
+	}
+	r.nonce = nonce + 1
+	return signedTx.Hash(), nil
+}
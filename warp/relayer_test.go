@@ -0,0 +1,265 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Juneo-io/jeth/core/types"
+	"github.com/Juneo-io/jeth/ethclient"
+	"github.com/Juneo-io/jeth/interfaces"
+	"github.com/Juneo-io/juneogo/database"
+	"github.com/Juneo-io/juneogo/database/memdb"
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/Juneo-io/juneogo/utils/crypto/bls"
+	avalancheWarp "github.com/Juneo-io/juneogo/vms/platformvm/warp"
+	"github.com/Juneo-io/juneogo/vms/platformvm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	relayerDestinationChainID = ids.GenerateTestID()
+	relayerDestinationAddress = common.Address{0xde}
+)
+
+// testRelayerClient serves the receipts and txs of the destination chain
+type testRelayerClient struct {
+	ethclient.Client
+	receipts map[common.Hash]*types.Receipt
+	txs      map[common.Hash]*types.Transaction
+}
+
+func (c *testRelayerClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, ok := c.receipts[txHash]
+	if !ok {
+		return nil, interfaces.NotFound
+	}
+	return receipt, nil
+}
+
+func (c *testRelayerClient) TransactionByHash(_ context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	tx, ok := c.txs[txHash]
+	if !ok {
+		return nil, false, interfaces.NotFound
+	}
+	return tx, true, nil
+}
+
+// newTestRelayer returns a relayer of the messages sent in [db] starting at
+// [startHeight], and a function indexing a new message sent at a height to a
+// destination chain.
+func newTestRelayer(t *testing.T, db database.Database, client ethclient.Client, startHeight uint64) (*Relayer, func(height uint64, destinationChainID ids.ID) ids.ID) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, memdb.New(), db, 500, nil)
+	require.NoError(err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	config := RelayerConfig{
+		DestinationChainID: relayerDestinationChainID,
+		StartHeight:        startHeight,
+		PrivateKey:         key,
+		RetryInterval:      time.Minute,
+		MaxAttempts:        3,
+	}
+	relayer := newRelayer(config, &API{backend: backend}, client, db)
+
+	indexMessage := func(height uint64, destinationChainID ids.ID) ids.ID {
+		payloadBytes, err := PackDestinationPayload(destinationChainID, relayerDestinationAddress, []byte{byte(height)})
+		require.NoError(err)
+		addressedCall, err := payload.NewAddressedCall(testSourceAddress, payloadBytes)
+		require.NoError(err)
+		unsignedMessage, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+		require.NoError(err)
+		require.NoError(backend.IndexMessage(unsignedMessage, common.Hash{byte(height)}, height, common.Hash{byte(height), 1}, 0))
+		return unsignedMessage.ID()
+	}
+	return relayer, indexMessage
+}
+
+func TestRelayerSyncMessages(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	relayer, indexMessage := newTestRelayer(t, db, nil, 1)
+	// Messages sent from the start height before the relayer first runs are
+	// relayed, unless they are addressed to another chain.
+	firstID := indexMessage(1, relayerDestinationChainID)
+	secondID := indexMessage(2, relayerDestinationChainID)
+	otherID := indexMessage(2, ids.GenerateTestID())
+	thirdID := indexMessage(3, relayerDestinationChainID)
+
+	now := time.Unix(1_000_000, 0)
+	require.NoError(relayer.syncMessages(2, now))
+	due, err := relayer.dueMessages(now)
+	require.NoError(err)
+	require.Len(due, 2)
+	require.Contains(due, firstID)
+	require.Contains(due, secondID)
+	require.NotContains(due, otherID)
+
+	// Messages being relayed keep their state, and relayed messages are not
+	// recorded again.
+	require.NoError(relayer.recordFailure(firstID, due[firstID], errors.New("failed"), now))
+	require.NoError(relayer.db.Delete(secondID[:]))
+	relayer, _ = newTestRelayer(t, db, nil, 1)
+	require.NoError(relayer.syncMessages(3, now))
+	due, err = relayer.dueMessages(now.Add(time.Minute))
+	require.NoError(err)
+	require.Len(due, 2)
+	require.Equal(uint64(1), due[firstID].Attempts)
+	require.Zero(due[thirdID].Attempts)
+}
+
+func TestRelayerStartHeight(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	relayer, indexMessage := newTestRelayer(t, db, nil, 0)
+	// Without a start height, the messages accepted before the relayer first
+	// runs are not relayed.
+	indexMessage(1, relayerDestinationChainID)
+	indexMessage(2, relayerDestinationChainID)
+
+	now := time.Unix(1_000_000, 0)
+	require.NoError(relayer.syncMessages(2, now))
+	due, err := relayer.dueMessages(now)
+	require.NoError(err)
+	require.Empty(due)
+	nextHeight, err := database.GetUInt64(relayer.db, relayerNextHeightKey)
+	require.NoError(err)
+	require.Equal(uint64(3), nextHeight)
+
+	// The start height is only used the first time the relayer runs.
+	messageID := indexMessage(3, relayerDestinationChainID)
+	relayer, _ = newTestRelayer(t, db, nil, 1)
+	require.NoError(relayer.syncMessages(3, now))
+	due, err = relayer.dueMessages(now)
+	require.NoError(err)
+	require.Len(due, 1)
+	require.Contains(due, messageID)
+}
+
+func TestRelayerRetryState(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	relayer, indexMessage := newTestRelayer(t, db, nil, 1)
+	messageID := indexMessage(1, relayerDestinationChainID)
+	now := time.Unix(1_000_000, 0)
+	require.NoError(relayer.syncMessages(1, now))
+
+	due, err := relayer.dueMessages(now)
+	require.NoError(err)
+	require.Len(due, 1)
+	state, ok := due[messageID]
+	require.True(ok)
+	require.Zero(state.Attempts)
+
+	// The first failure delays the next attempt by the retry interval
+	relayErr := errors.New("destination unavailable")
+	require.NoError(relayer.recordFailure(messageID, state, relayErr, now))
+	due, err = relayer.dueMessages(now.Add(time.Minute - time.Second))
+	require.NoError(err)
+	require.Empty(due)
+
+	// The state is persisted, and the backoff doubles on the next failure
+	relayer, _ = newTestRelayer(t, db, nil, 1)
+	now = now.Add(time.Minute)
+	due, err = relayer.dueMessages(now)
+	require.NoError(err)
+	require.Len(due, 1)
+	state = due[messageID]
+	require.Equal(uint64(1), state.Attempts)
+	require.Equal(relayErr.Error(), state.LastError)
+	require.NoError(relayer.recordFailure(messageID, state, relayErr, now))
+	due, err = relayer.dueMessages(now.Add(time.Minute))
+	require.NoError(err)
+	require.Empty(due)
+
+	// The message is dropped once it runs out of attempts
+	now = now.Add(2 * time.Minute)
+	due, err = relayer.dueMessages(now)
+	require.NoError(err)
+	require.Len(due, 1)
+	require.NoError(relayer.recordFailure(messageID, due[messageID], relayErr, now))
+	due, err = relayer.dueMessages(now.Add(maxRelayerRetryBackoff))
+	require.NoError(err)
+	require.Empty(due)
+}
+
+func TestRelayerConfirm(t *testing.T) {
+	txHash := common.Hash{1}
+	tests := []struct {
+		name             string
+		receipt          *types.Receipt
+		pending          bool
+		expectedDeleted  bool
+		expectedAttempts uint64
+		expectedTxHash   common.Hash
+	}{
+		{
+			name:            "success",
+			receipt:         &types.Receipt{Status: types.ReceiptStatusSuccessful},
+			expectedDeleted: true,
+		},
+		{
+			name:             "reverted",
+			receipt:          &types.Receipt{Status: types.ReceiptStatusFailed},
+			expectedAttempts: 1,
+		},
+		{
+			name:           "pending",
+			pending:        true,
+			expectedTxHash: txHash,
+		},
+		{
+			name:             "dropped",
+			expectedAttempts: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			client := &testRelayerClient{
+				receipts: make(map[common.Hash]*types.Receipt),
+				txs:      make(map[common.Hash]*types.Transaction),
+			}
+			if test.receipt != nil {
+				client.receipts[txHash] = test.receipt
+			}
+			if test.pending {
+				client.txs[txHash] = types.NewTx(&types.DynamicFeeTx{})
+			}
+			relayer, indexMessage := newTestRelayer(t, memdb.New(), client, 1)
+			messageID := indexMessage(1, relayerDestinationChainID)
+			now := time.Unix(1_000_000, 0)
+			require.NoError(relayer.putState(messageID, &relayState{TxHash: txHash}))
+
+			require.NoError(relayer.confirm(context.Background(), messageID, &relayState{TxHash: txHash}, now))
+			due, err := relayer.dueMessages(now.Add(maxRelayerRetryBackoff))
+			require.NoError(err)
+			if test.expectedDeleted {
+				require.Empty(due)
+				return
+			}
+			require.Len(due, 1)
+			state := due[messageID]
+			require.Equal(test.expectedAttempts, state.Attempts)
+			require.Equal(test.expectedTxHash, state.TxHash)
+			// The relay tx is checked again after the retry interval.
+			require.Equal(uint64(now.Add(time.Minute).Unix()), state.NextAttempt)
+		})
+	}
+}
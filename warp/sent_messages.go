@@ -0,0 +1,216 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/Juneo-io/jeth/precompile/contract"
+	"github.com/Juneo-io/juneogo/database"
+	"github.com/Juneo-io/juneogo/ids"
+	"github.com/Juneo-io/juneogo/utils/wrappers"
+	avalancheWarp "github.com/Juneo-io/juneogo/vms/platformvm/warp"
+	"github.com/Juneo-io/juneogo/vms/platformvm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	// sentByHeightPrefix indexes the sent messages by
+	// height + txHash + logIndex -> SentMessage
+	sentByHeightPrefix = []byte("sentByHeight")
+	// sentByDestinationPrefix indexes the sent messages by
+	// destinationChainID + destinationAddress + height + txHash + logIndex -> SentMessage
+	sentByDestinationPrefix = []byte("sentByDestination")
+
+	errInvalidStartKey = errors.New("invalid start key")
+)
+
+// SentMessage is a warp message sent by a SendWarpMessage log accepted by
+// this chain.
+type SentMessage struct {
+	MessageID     ids.ID         `json:"messageID"`
+	Message       hexutil.Bytes  `json:"message"`
+	SourceAddress common.Address `json:"sourceAddress"`
+	// DestinationChainID and DestinationAddress are only set when the payload
+	// of the message is a destination payload, see PackDestinationPayload.
+	DestinationChainID ids.ID         `json:"destinationChainID"`
+	DestinationAddress common.Address `json:"destinationAddress"`
+	BlockHash          common.Hash    `json:"blockHash"`
+	BlockNumber        hexutil.Uint64 `json:"blockNumber"`
+	TxHash             common.Hash    `json:"transactionHash"`
+	LogIndex           hexutil.Uint   `json:"logIndex"`
+}
+
+// destinationABI declares the envelope of the payloads of the messages
+// addressed to a contract of another chain. Warp messages do not carry a
+// destination, so the messages are only indexed by destination if their
+// AddressedCall payload is the ABI encoding of a call to warpDestination.
+const destinationABI = `[{"type":"function","name":"warpDestination","inputs":[{"name":"destinationChainID","type":"bytes32"},{"name":"destinationAddress","type":"address"},{"name":"payload","type":"bytes"}],"outputs":[]}]`
+
+var destinationMethod = contract.ParseABI(destinationABI).Methods["warpDestination"]
+
+// PackDestinationPayload returns the payload of a message addressed to
+// [address] on [chainID], carrying [payloadBytes] to it. It is the encoding of
+// the call warpDestination(bytes32 destinationChainID, address destinationAddress, bytes payload),
+// which contracts sending messages build with the WarpDestination library.
+func PackDestinationPayload(chainID ids.ID, address common.Address, payloadBytes []byte) ([]byte, error) {
+	args, err := destinationMethod.Inputs.Pack(chainID, address, payloadBytes)
+	if err != nil {
+		return nil, err
+	}
+	return append(common.CopyBytes(destinationMethod.ID), args...), nil
+}
+
+// parseDestination returns the destination and the payload of the destination
+// payload [payloadBytes]. It returns false if [payloadBytes] is not exactly
+// the encoding returned by PackDestinationPayload for a non-empty chainID, so
+// other payloads are never indexed under a destination.
+func parseDestination(payloadBytes []byte) (ids.ID, common.Address, []byte, bool) {
+	if len(payloadBytes) < len(destinationMethod.ID) || !bytes.Equal(payloadBytes[:len(destinationMethod.ID)], destinationMethod.ID) {
+		return ids.Empty, common.Address{}, nil, false
+	}
+	values, err := destinationMethod.Inputs.Unpack(payloadBytes[len(destinationMethod.ID):])
+	if err != nil {
+		return ids.Empty, common.Address{}, nil, false
+	}
+	chainID, address, inner := ids.ID(values[0].([common.HashLength]byte)), values[1].(common.Address), values[2].([]byte)
+	if chainID == ids.Empty {
+		return ids.Empty, common.Address{}, nil, false
+	}
+	// Reject the encodings with padding or offsets other than the ones packed
+	// by PackDestinationPayload.
+	if packed, err := PackDestinationPayload(chainID, address, inner); err != nil || !bytes.Equal(packed, payloadBytes) {
+		return ids.Empty, common.Address{}, nil, false
+	}
+	return chainID, address, inner, true
+}
+
+// sentMessageKeyLen is the length of the keys returned by sentMessageKey,
+// which are also the cursors used to page through the sent messages.
+const sentMessageKeyLen = wrappers.LongLen + common.HashLength + wrappers.IntLen
+
+// sentMessageKey returns height + txHash + logIndex
+func sentMessageKey(blockNumber uint64, txHash common.Hash, logIndex uint) []byte {
+	key := make([]byte, sentMessageKeyLen)
+	binary.BigEndian.PutUint64(key, blockNumber)
+	copy(key[wrappers.LongLen:], txHash[:])
+	binary.BigEndian.PutUint32(key[wrappers.LongLen+common.HashLength:], uint32(logIndex))
+	return key
+}
+
+// destinationPrefix returns destinationChainID + destinationAddress
+func destinationPrefix(chainID ids.ID, address common.Address) []byte {
+	prefix := make([]byte, 0, common.HashLength+common.AddressLength)
+	prefix = append(prefix, chainID[:]...)
+	return append(prefix, address[:]...)
+}
+
+func (b *backend) IndexMessage(unsignedMessage *avalancheWarp.UnsignedMessage, blockHash common.Hash, blockNumber uint64, txHash common.Hash, logIndex int) error {
+	addressedCall, err := payload.ParseAddressedCall(unsignedMessage.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to parse sent message %s as AddressedCall: %w", unsignedMessage.ID(), err)
+	}
+	sent := SentMessage{
+		MessageID:     unsignedMessage.ID(),
+		Message:       unsignedMessage.Bytes(),
+		SourceAddress: common.BytesToAddress(addressedCall.SourceAddress),
+		BlockHash:     blockHash,
+		BlockNumber:   hexutil.Uint64(blockNumber),
+		TxHash:        txHash,
+		LogIndex:      hexutil.Uint(logIndex),
+	}
+	chainID, address, _, hasDestination := parseDestination(addressedCall.Payload)
+	if hasDestination {
+		sent.DestinationChainID = chainID
+		sent.DestinationAddress = address
+	}
+
+	sentBytes, err := rlp.EncodeToBytes(&sent)
+	if err != nil {
+		return fmt.Errorf("failed to encode sent message %s: %w", sent.MessageID, err)
+	}
+	key := sentMessageKey(blockNumber, txHash, uint(logIndex))
+	if err := b.sentByHeightDB.Put(key, sentBytes); err != nil {
+		return fmt.Errorf("failed to index sent message %s: %w", sent.MessageID, err)
+	}
+	if hasDestination {
+		if err := b.sentByDestinationDB.Put(append(destinationPrefix(chainID, address), key...), sentBytes); err != nil {
+			return fmt.Errorf("failed to index sent message %s by destination: %w", sent.MessageID, err)
+		}
+	}
+	log.Debug("Indexed sent warp message", "messageID", sent.MessageID, "blockNumber", blockNumber, "txHash", txHash)
+	b.sentMessagesFeed.Send(sent)
+	return nil
+}
+
+func (b *backend) GetMessagesByBlockRange(fromBlock, toBlock uint64, startKey []byte, limit int) ([]SentMessage, []byte, error) {
+	start := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(start, fromBlock)
+	if len(startKey) > 0 {
+		if len(startKey) != sentMessageKeyLen {
+			return nil, nil, fmt.Errorf("%w: expected %d bytes but got %d", errInvalidStartKey, sentMessageKeyLen, len(startKey))
+		}
+		if bytes.Compare(startKey, start) > 0 {
+			start = startKey
+		}
+	}
+	it := b.sentByHeightDB.NewIteratorWithStart(start)
+	defer it.Release()
+
+	return iterateSentMessages(it, nil, limit, func(key []byte) bool {
+		return binary.BigEndian.Uint64(key) <= toBlock
+	})
+}
+
+func (b *backend) GetMessagesByDestination(chainID ids.ID, address common.Address, startKey []byte, limit int) ([]SentMessage, []byte, error) {
+	if len(startKey) > 0 && len(startKey) != sentMessageKeyLen {
+		return nil, nil, fmt.Errorf("%w: expected %d bytes but got %d", errInvalidStartKey, sentMessageKeyLen, len(startKey))
+	}
+	prefix := destinationPrefix(chainID, address)
+	it := b.sentByDestinationDB.NewIteratorWithStartAndPrefix(append(prefix, startKey...), prefix)
+	defer it.Release()
+
+	return iterateSentMessages(it, prefix, limit, func([]byte) bool { return true })
+}
+
+// iterateSentMessages returns up to [limit] messages of [it] while [inRange]
+// returns true for their key, stripped of [prefix]. If more messages are in
+// range, the key of the next one is returned as well.
+func iterateSentMessages(it database.Iterator, prefix []byte, limit int, inRange func(key []byte) bool) ([]SentMessage, []byte, error) {
+	var sent []SentMessage
+	for it.Next() {
+		key := it.Key()[len(prefix):]
+		if !inRange(key) {
+			break
+		}
+		if len(sent) >= limit {
+			return sent, common.CopyBytes(key), it.Error()
+		}
+		msg, err := parseSentMessage(it.Value())
+		if err != nil {
+			return nil, nil, err
+		}
+		sent = append(sent, msg)
+	}
+	return sent, nil, it.Error()
+}
+
+func (b *backend) SubscribeSentMessages(ch chan<- SentMessage) event.Subscription {
+	return b.sentMessagesFeed.Subscribe(ch)
+}
+
+func parseSentMessage(sentBytes []byte) (SentMessage, error) {
+	var sent SentMessage
+	if err := rlp.DecodeBytes(sentBytes, &sent); err != nil {
+		return SentMessage{}, fmt.Errorf("failed to decode sent message: %w", err)
+	}
+	return sent, nil
+}
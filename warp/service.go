@@ -12,13 +12,24 @@ import (
 	"github.com/Juneo-io/juneogo/vms/platformvm/warp"
 	"github.com/Juneo-io/juneogo/vms/platformvm/warp/payload"
 	"github.com/Juneo-io/jeth/peer"
+	warpPrecompile "github.com/Juneo-io/jeth/precompile/contracts/warp"
+	"github.com/Juneo-io/jeth/rpc"
 	"github.com/Juneo-io/jeth/warp/aggregator"
 	"github.com/Juneo-io/jeth/warp/validators"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 )
 
-var errNoValidators = errors.New("cannot aggregate signatures from supernet with no validators")
+// maxSentMessagesPerRequest is the maximum number of sent messages returned
+// by a single request
+const maxSentMessagesPerRequest = 1024
+
+var (
+	errNoValidators      = errors.New("cannot aggregate signatures from supernet with no validators")
+	errInvalidBlockRange = errors.New("invalid block range")
+	errInvalidQuorumNum  = errors.New("invalid quorum numerator")
+)
 
 // API introduces snowman specific functionality to the evm
 type API struct {
@@ -90,6 +101,157 @@ func (a *API) GetBlockAggregateSignature(ctx context.Context, blockID ids.ID, qu
 	return a.aggregateSignatures(ctx, unsignedMessage, quorumNum, supernetIDStr)
 }
 
+// SentMessagesPage is a page of sent messages. NextKey is set if there are
+// more messages, and is passed as the startKey of the next request.
+type SentMessagesPage struct {
+	Messages []SentMessage `json:"messages"`
+	NextKey  hexutil.Bytes `json:"nextKey,omitempty"`
+}
+
+// GetMessagesByBlockRange returns the messages sent in blocks [fromBlock] to
+// [toBlock] included, up to [maxSentMessagesPerRequest] messages, starting at
+// [startKey] if provided.
+func (a *API) GetMessagesByBlockRange(ctx context.Context, fromBlock, toBlock uint64, startKey *hexutil.Bytes) (*SentMessagesPage, error) {
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("%w: from block %d is after to block %d", errInvalidBlockRange, fromBlock, toBlock)
+	}
+	var start []byte
+	if startKey != nil {
+		start = *startKey
+	}
+	sent, nextKey, err := a.backend.GetMessagesByBlockRange(fromBlock, toBlock, start, maxSentMessagesPerRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages sent in blocks [%d, %d] with error %w", fromBlock, toBlock, err)
+	}
+	return newSentMessagesPage(sent, nextKey), nil
+}
+
+// GetMessagesByDestination returns the messages sent to [address] on
+// [chainID], up to [maxSentMessagesPerRequest] messages, starting at
+// [startKey] if provided. Only the messages whose payload is a destination
+// payload, built with the WarpDestination library, have a destination.
+func (a *API) GetMessagesByDestination(ctx context.Context, chainID ids.ID, address common.Address, startKey *hexutil.Bytes) (*SentMessagesPage, error) {
+	var start []byte
+	if startKey != nil {
+		start = *startKey
+	}
+	sent, nextKey, err := a.backend.GetMessagesByDestination(chainID, address, start, maxSentMessagesPerRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages sent to %s on %s with error %w", address, chainID, err)
+	}
+	return newSentMessagesPage(sent, nextKey), nil
+}
+
+func newSentMessagesPage(sent []SentMessage, nextKey []byte) *SentMessagesPage {
+	if sent == nil {
+		sent = []SentMessage{}
+	}
+	return &SentMessagesPage{
+		Messages: sent,
+		NextKey:  nextKey,
+	}
+}
+
+// SentMessages creates a subscription that is triggered each time a warp
+// message is sent by an accepted block.
+func (a *API) SentMessages(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		sentMessages := make(chan SentMessage, 128)
+		sentSub := a.backend.SubscribeSentMessages(sentMessages)
+		defer sentSub.Unsubscribe()
+
+		for {
+			select {
+			case sent := <-sentMessages:
+				notifier.Notify(rpcSub.ID, sent)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// SignedMessageVerification is the result of verifying a signed warp message.
+type SignedMessageVerification struct {
+	MessageID      ids.ID         `json:"messageID"`
+	SourceChainID  ids.ID         `json:"sourceChainID"`
+	SupernetID     ids.ID         `json:"supernetID"`
+	PChainHeight   hexutil.Uint64 `json:"pChainHeight"`
+	NumSigners     hexutil.Uint   `json:"numSigners"`
+	SignerWeight   hexutil.Uint64 `json:"signerWeight"`
+	TotalWeight    hexutil.Uint64 `json:"totalWeight"`
+	RequiredWeight hexutil.Uint64 `json:"requiredWeight"`
+	MissingWeight  hexutil.Uint64 `json:"missingWeight"`
+	Valid          bool           `json:"valid"`
+	// Error is the reason the verification failed, if any
+	Error string `json:"error,omitempty"`
+}
+
+// VerifySignedMessage verifies [signedMessageBytes] the way the warp precompile
+// predicate does, requiring [quorumNum] of the weight of the validators of
+// [supernetIDStr] at [pChainHeight]. If [supernetIDStr] is empty, the supernet
+// of the source chain is used, and if [pChainHeight] is omitted, the current
+// P-chain height is used.
+// A signature that does not verify is not an error: the reason is reported in
+// the result along with the signer and missing weights.
+func (a *API) VerifySignedMessage(ctx context.Context, signedMessageBytes hexutil.Bytes, quorumNum uint64, supernetIDStr string, pChainHeight *uint64) (*SignedMessageVerification, error) {
+	signedMessage, err := warp.ParseMessage(signedMessageBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signed message: %w", err)
+	}
+	if quorumNum == 0 {
+		quorumNum = warpPrecompile.WarpDefaultQuorumNumerator
+	}
+	if quorumNum > warpPrecompile.WarpQuorumDenominator {
+		return nil, fmt.Errorf("%w: %d exceeds %d", errInvalidQuorumNum, quorumNum, warpPrecompile.WarpQuorumDenominator)
+	}
+	var supernetID ids.ID
+	if len(supernetIDStr) > 0 {
+		supernetID, err = ids.FromString(supernetIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse supernetID: %q", supernetIDStr)
+		}
+	}
+	var height uint64
+	if pChainHeight != nil {
+		height = *pChainHeight
+	} else {
+		height, err = a.state.GetCurrentHeight(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current P-chain height: %w", err)
+		}
+	}
+
+	result, err := warpPrecompile.VerifySignature(ctx, signedMessage, a.networkID, a.state, height, supernetID, quorumNum, warpPrecompile.WarpQuorumDenominator)
+	verification := &SignedMessageVerification{
+		MessageID:      signedMessage.ID(),
+		SourceChainID:  signedMessage.SourceChainID,
+		SupernetID:     result.SupernetID,
+		PChainHeight:   hexutil.Uint64(result.PChainHeight),
+		NumSigners:     hexutil.Uint(result.NumSigners),
+		SignerWeight:   hexutil.Uint64(result.SignerWeight),
+		TotalWeight:    hexutil.Uint64(result.TotalWeight),
+		RequiredWeight: hexutil.Uint64(result.RequiredWeight),
+		MissingWeight:  hexutil.Uint64(result.MissingWeight()),
+		Valid:          err == nil,
+	}
+	if err != nil {
+		verification.Error = err.Error()
+	}
+	return verification, nil
+}
+
 func (a *API) aggregateSignatures(ctx context.Context, unsignedMessage *warp.UnsignedMessage, quorumNum uint64, supernetIDStr string) (hexutil.Bytes, error) {
 	supernetID := a.sourceSupernetID
 	if len(supernetIDStr) > 0 {